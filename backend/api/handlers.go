@@ -17,6 +17,12 @@ func HealthCheck(c *gin.Context) {
 		"service":    "Star API (Go)",
 		"version":    "1.0.0",
 		"dataSource": astro.GetDataSource(), // 显示天文数据唯一来源
+		"ephemeris": gin.H{
+			"provider":  astro.CurrentEphemerisProvider().Name(),
+			"available": astro.AvailableEphemerisProviders(),
+			"cache":     astro.GetEphemerisCacheStats(),
+			"failures":  astro.GetEphemerisFailures(),
+		},
 		"features": []string{
 			"natal-chart",
 			"daily-forecast",
@@ -32,6 +38,29 @@ func HealthCheck(c *gin.Context) {
 	})
 }
 
+// validateBirthData 校验出生数据中的星盘选项，失败时返回 400
+func validateBirthData(c *gin.Context, birthData models.BirthData) bool {
+	if err := astro.ValidateBirthData(birthData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+	return true
+}
+
+// chartForRequest 校验出生数据与当前所在地并计算本命盘，失败时返回 400
+func chartForRequest(c *gin.Context, birthData models.BirthData, location *models.GeoLocation) (*models.NatalChart, bool) {
+	if !validateBirthData(c, birthData) {
+		return nil, false
+	}
+	if err := astro.ValidateLocation(location); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	chart := astro.CalculateNatalChart(birthData)
+	chart.CurrentLocation = location
+	return chart, true
+}
+
 // CalculateChart 计算本命盘
 func CalculateChart(c *gin.Context) {
 	var req models.BirthData
@@ -40,6 +69,10 @@ func CalculateChart(c *gin.Context) {
 		return
 	}
 
+	if !validateBirthData(c, req) {
+		return
+	}
+
 	chart := astro.CalculateNatalChart(req)
 	c.JSON(http.StatusOK, chart)
 }
@@ -47,10 +80,11 @@ func CalculateChart(c *gin.Context) {
 // CalculateDailyForecast 计算每日预测
 func CalculateDailyForecast(c *gin.Context) {
 	var req struct {
-		BirthData   models.BirthData `json:"birthData"`
-		Date        string           `json:"date"`
-		TargetDate  string           `json:"targetDate"`
-		WithFactors bool             `json:"withFactors"`
+		BirthData       models.BirthData    `json:"birthData"`
+		CurrentLocation *models.GeoLocation `json:"currentLocation,omitempty"` // 可选，当前所在地（行星时），默认出生地
+		Date            string              `json:"date"`
+		TargetDate      string              `json:"targetDate"`
+		WithFactors     bool                `json:"withFactors"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -74,7 +108,10 @@ func CalculateDailyForecast(c *gin.Context) {
 		}
 	}
 
-	chart := astro.CalculateNatalChart(req.BirthData)
+	chart, ok := chartForRequest(c, req.BirthData, req.CurrentLocation)
+	if !ok {
+		return
+	}
 	// 默认启用 factors 计算，以确保与时间序列 API 一致
 	forecast := astro.CalculateDailyForecast(chart, date, true)
 	c.JSON(http.StatusOK, forecast)
@@ -83,9 +120,10 @@ func CalculateDailyForecast(c *gin.Context) {
 // CalculateWeeklyForecast 计算每周预测
 func CalculateWeeklyForecast(c *gin.Context) {
 	var req struct {
-		BirthData   models.BirthData `json:"birthData"`
-		Date        string           `json:"date"`
-		WithFactors bool             `json:"withFactors"`
+		BirthData       models.BirthData    `json:"birthData"`
+		CurrentLocation *models.GeoLocation `json:"currentLocation,omitempty"` // 可选，当前所在地（行星时），默认出生地
+		Date            string              `json:"date"`
+		WithFactors     bool                `json:"withFactors"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -99,7 +137,10 @@ func CalculateWeeklyForecast(c *gin.Context) {
 		}
 	}
 
-	chart := astro.CalculateNatalChart(req.BirthData)
+	chart, ok := chartForRequest(c, req.BirthData, req.CurrentLocation)
+	if !ok {
+		return
+	}
 	forecast := astro.CalculateWeeklyForecast(chart, date, req.WithFactors)
 	c.JSON(http.StatusOK, forecast)
 }
@@ -117,6 +158,10 @@ func CalculateLifeTrend(c *gin.Context) {
 		return
 	}
 
+	if !validateBirthData(c, req.BirthData) {
+		return
+	}
+
 	chart := astro.CalculateNatalChart(req.BirthData)
 	trend := astro.CalculateLifeTrend(chart, req.StartYear, req.EndYear, req.Resolution)
 	c.JSON(http.StatusOK, trend)
@@ -125,17 +170,21 @@ func CalculateLifeTrend(c *gin.Context) {
 // CalculateTimeSeries 生成统一时间序列
 func CalculateTimeSeries(c *gin.Context) {
 	var req struct {
-		BirthData   models.BirthData `json:"birthData"`
-		Start       string           `json:"start"`
-		End         string           `json:"end"`
-		Granularity string           `json:"granularity"`
+		BirthData       models.BirthData    `json:"birthData"`
+		CurrentLocation *models.GeoLocation `json:"currentLocation,omitempty"` // 可选，当前所在地（行星时），默认出生地
+		Start           string              `json:"start"`
+		End             string              `json:"end"`
+		Granularity     string              `json:"granularity"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	chart := astro.CalculateNatalChart(req.BirthData)
+	chart, ok := chartForRequest(c, req.BirthData, req.CurrentLocation)
+	if !ok {
+		return
+	}
 	series := astro.CalculateTimeSeries(chart, req.Start, req.End, req.Granularity)
 	c.JSON(http.StatusOK, series)
 }
@@ -151,6 +200,10 @@ func CalculateProfection(c *gin.Context) {
 		return
 	}
 
+	if !validateBirthData(c, req.BirthData) {
+		return
+	}
+
 	chart := astro.CalculateNatalChart(req.BirthData)
 	profection := astro.CalculateAnnualProfection(chart, req.Age)
 	c.JSON(http.StatusOK, profection)
@@ -166,6 +219,10 @@ func CalculateProfectionMap(c *gin.Context) {
 		return
 	}
 
+	if !validateBirthData(c, req.BirthData) {
+		return
+	}
+
 	chart := astro.CalculateNatalChart(req.BirthData)
 	profectionMap := astro.CalculateProfectionMap(chart)
 	c.JSON(http.StatusOK, profectionMap)
@@ -183,6 +240,10 @@ func CalculateTransits(c *gin.Context) {
 		return
 	}
 
+	if !validateBirthData(c, req.BirthData) {
+		return
+	}
+
 	chart := astro.CalculateNatalChart(req.BirthData)
 	transits := astro.CalculateTransits(chart, req.StartDate, req.EndDate)
 	c.JSON(http.StatusOK, transits)
@@ -199,11 +260,233 @@ func CalculateProgressions(c *gin.Context) {
 		return
 	}
 
+	if !validateBirthData(c, req.BirthData) {
+		return
+	}
+
 	chart := astro.CalculateNatalChart(req.BirthData)
 	progressions := astro.CalculateProgressions(chart, req.TargetDate)
 	c.JSON(http.StatusOK, progressions)
 }
 
+// CalculateSynastry 计算两张本命盘之间的合盘相位与平行/反平行
+func CalculateSynastry(c *gin.Context) {
+	var req struct {
+		ChartA models.BirthData `json:"chartA"`
+		ChartB models.BirthData `json:"chartB"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !validateBirthData(c, req.ChartA) || !validateBirthData(c, req.ChartB) {
+		return
+	}
+
+	chartA := astro.CalculateNatalChart(req.ChartA)
+	chartB := astro.CalculateNatalChart(req.ChartB)
+	c.JSON(http.StatusOK, gin.H{
+		"aspects":   astro.CalculateSynastryAspects(chartA, chartB),
+		"parallels": astro.CalculateSynastryParallels(chartA, chartB),
+		"patterns":  astro.DetectSynastryPatterns(chartA, chartB),
+	})
+}
+
+// CalculateStations 计算时间范围内的留与逆行周期（含前后影区）
+func CalculateStations(c *gin.Context) {
+	var req struct {
+		StartDate string            `json:"startDate"` // 可选，默认今天
+		EndDate   string            `json:"endDate"`   // 可选，默认一年后
+		Planets   []models.PlanetID `json:"planets"`   // 可选，默认全部会逆行的天体
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	start, end, ok := parseDateRange(c, req.StartDate, req.EndDate)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"startDate": start.Format("2006-01-02"),
+		"endDate":   end.Format("2006-01-02"),
+		"stations":  astro.FindStations(start, end, req.Planets...),
+		"cycles":    astro.FindRetrogradeCycles(start, end, req.Planets...),
+	})
+}
+
+// CalculateIngresses 计算时间范围内的换座时刻（含逆行退回与再次入座）
+func CalculateIngresses(c *gin.Context) {
+	var req struct {
+		StartDate string            `json:"startDate"` // 可选，默认今天
+		EndDate   string            `json:"endDate"`   // 可选，默认一年后
+		Planets   []models.PlanetID `json:"planets"`   // 可选，默认全部天体
+		Zodiac    models.ZodiacMode `json:"zodiac"`    // 可选，tropical/sidereal
+		Ayanamsa  models.Ayanamsa   `json:"ayanamsa"`  // 可选，恒星黄道岁差体系
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	start, end, ok := parseDateRange(c, req.StartDate, req.EndDate)
+	if !ok {
+		return
+	}
+
+	zodiac, ayanamsa, ok := parseZodiac(c, req.Zodiac, req.Ayanamsa)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"startDate": start.Format("2006-01-02"),
+		"endDate":   end.Format("2006-01-02"),
+		"zodiac":    zodiac,
+		"ayanamsa":  ayanamsa,
+		"ingresses": astro.FindIngresses(start, end, zodiac, ayanamsa, req.Planets...),
+	})
+}
+
+// CalculateLunations 计算时间范围内的新月、上弦、满月、下弦与日月食
+func CalculateLunations(c *gin.Context) {
+	var req struct {
+		StartDate string            `json:"startDate"` // 可选，默认今天
+		EndDate   string            `json:"endDate"`   // 可选，默认一年后
+		Zodiac    models.ZodiacMode `json:"zodiac"`    // 可选，tropical/sidereal（影响朔望所在星座）
+		Ayanamsa  models.Ayanamsa   `json:"ayanamsa"`  // 可选，恒星黄道岁差体系
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	start, end, ok := parseDateRange(c, req.StartDate, req.EndDate)
+	if !ok {
+		return
+	}
+	zodiac, ayanamsa, ok := parseZodiac(c, req.Zodiac, req.Ayanamsa)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"startDate": start.Format("2006-01-02"),
+		"endDate":   end.Format("2006-01-02"),
+		"zodiac":    zodiac,
+		"ayanamsa":  ayanamsa,
+		"lunations": astro.FindLunations(start, end, zodiac, ayanamsa),
+		"eclipses":  astro.FindEclipses(start, end),
+	})
+}
+
+// CalculateEclipses 计算时间范围内的日月食及其触发的本命点
+func CalculateEclipses(c *gin.Context) {
+	var req struct {
+		BirthData models.BirthData `json:"birthData"`
+		StartDate string           `json:"startDate"` // 可选，默认今天
+		EndDate   string           `json:"endDate"`   // 可选，默认一年后
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !validateBirthData(c, req.BirthData) {
+		return
+	}
+	start, end, ok := parseDateRange(c, req.StartDate, req.EndDate)
+	if !ok {
+		return
+	}
+
+	chart := astro.CalculateNatalChart(req.BirthData)
+	c.JSON(http.StatusOK, gin.H{
+		"startDate":   start.Format("2006-01-02"),
+		"endDate":     end.Format("2006-01-02"),
+		"eclipses":    astro.FindEclipses(start, end),
+		"activations": astro.FindEclipseActivations(chart, start, end),
+	})
+}
+
+// CalculateFixedStars 计算恒星位置、本命恒星接触及时间范围内慢行星的恒星接触
+func CalculateFixedStars(c *gin.Context) {
+	var req struct {
+		BirthData models.BirthData `json:"birthData"`
+		StartDate string           `json:"startDate"` // 可选，默认今天
+		EndDate   string           `json:"endDate"`   // 可选，默认一年后
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !validateBirthData(c, req.BirthData) {
+		return
+	}
+	start, end, ok := parseDateRange(c, req.StartDate, req.EndDate)
+	if !ok {
+		return
+	}
+
+	chart := astro.CalculateNatalChart(req.BirthData)
+	jd := astro.DateToJulianDay(start.UTC())
+	c.JSON(http.StatusOK, gin.H{
+		"startDate": start.Format("2006-01-02"),
+		"endDate":   end.Format("2006-01-02"),
+		"stars":     astro.CalculateFixedStarPositions(jd, astro.ChartZodiacOffset(chart, jd)),
+		"natal":     chart.FixedStars,
+		"transits":  astro.FindFixedStarTransits(start, end),
+	})
+}
+
+// parseZodiac 解析黄道模式与岁差体系（仅恒星黄道需要），出错时写入 400 响应
+func parseZodiac(c *gin.Context, mode models.ZodiacMode, requested models.Ayanamsa) (models.ZodiacMode, models.Ayanamsa, bool) {
+	zodiac, err := astro.ParseZodiacMode(mode)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return zodiac, "", false
+	}
+	var ayanamsa models.Ayanamsa
+	if zodiac == models.ZodiacSidereal {
+		if ayanamsa, err = astro.ParseAyanamsa(requested); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return zodiac, "", false
+		}
+	}
+	return zodiac, ayanamsa, true
+}
+
+// parseDateRange 解析日期范围（YYYY-MM-DD），默认今天起一年，最长 10 年；出错时写入 400 响应
+func parseDateRange(c *gin.Context, startDate, endDate string) (time.Time, time.Time, bool) {
+	start := time.Now().UTC().Truncate(24 * time.Hour)
+	if startDate != "" {
+		parsed, err := time.Parse("2006-01-02", startDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "无效的开始日期格式"})
+			return start, start, false
+		}
+		start = parsed
+	}
+	end := start.AddDate(1, 0, 0)
+	if endDate != "" {
+		parsed, err := time.Parse("2006-01-02", endDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "无效的结束日期格式"})
+			return start, end, false
+		}
+		end = parsed
+	}
+	if !end.After(start) || end.Sub(start) > 10*366*24*time.Hour {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "日期范围必须为正且不超过 10 年"})
+		return start, end, false
+	}
+	return start, end, true
+}
+
 // GetUsers 获取所有用户
 func GetUsers(c *gin.Context) {
 	users := services.GetAllUsers()
@@ -221,6 +504,10 @@ func CreateUser(c *gin.Context) {
 		return
 	}
 
+	if !validateBirthData(c, req.BirthData) {
+		return
+	}
+
 	user := services.CreateUser(req.Name, req.BirthData)
 	c.JSON(http.StatusCreated, user)
 }
@@ -240,15 +527,24 @@ func GetUser(c *gin.Context) {
 func UpdateUser(c *gin.Context) {
 	id := c.Param("id")
 	var req struct {
-		Name      string           `json:"name"`
-		BirthData models.BirthData `json:"birthData"`
+		Name            string              `json:"name"`
+		BirthData       models.BirthData    `json:"birthData"`
+		CurrentLocation *models.GeoLocation `json:"currentLocation,omitempty"` // 可选，当前所在地
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	user, err := services.UpdateUser(id, req.Name, req.BirthData)
+	if !validateBirthData(c, req.BirthData) {
+		return
+	}
+	if err := astro.ValidateLocation(req.CurrentLocation); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := services.UpdateUser(id, req.Name, req.BirthData, req.CurrentLocation)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
@@ -278,6 +574,7 @@ func GetUserForecast(c *gin.Context) {
 	}
 
 	chart := astro.CalculateNatalChart(user.BirthData)
+	chart.CurrentLocation = user.Settings.CurrentLocation
 	var forecast interface{}
 	switch forecastType {
 	case "weekly":
@@ -327,19 +624,29 @@ func AgentQuery(c *gin.Context) {
 // CalculateVoidOfCourse 计算月亮空亡
 func CalculateVoidOfCourse(c *gin.Context) {
 	var req struct {
-		Date      string  `json:"date"`      // 可选，默认当前时间
-		Latitude  float64 `json:"latitude"`  // 可选
-		Longitude float64 `json:"longitude"` // 可选
+		Date      string            `json:"date"`      // 可选，默认当前时间
+		Latitude  float64           `json:"latitude"`  // 可选
+		Longitude float64           `json:"longitude"` // 可选
+		Zodiac    models.ZodiacMode `json:"zodiac"`    // 可选，tropical/sidereal
+		Ayanamsa  models.Ayanamsa   `json:"ayanamsa"`  // 可选，恒星黄道岁差体系
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	// 解析日期
+	zodiac, ayanamsa, ok := parseZodiac(c, req.Zodiac, req.Ayanamsa)
+	if !ok {
+		return
+	}
+
+	// 解析日期（不带时区的时间按 UTC 处理）
 	var date time.Time
 	if req.Date != "" {
-		parsed, err := time.Parse("2006-01-02T15:04:05", req.Date)
+		parsed, err := time.Parse(time.RFC3339, req.Date)
+		if err != nil {
+			parsed, err = time.Parse("2006-01-02T15:04:05", req.Date)
+		}
 		if err != nil {
 			parsed, err = time.Parse("2006-01-02", req.Date)
 			if err != nil {
@@ -352,12 +659,50 @@ func CalculateVoidOfCourse(c *gin.Context) {
 		date = time.Now()
 	}
 
-	jd := astro.DateToJulianDay(date)
-	vocInfo := astro.CalculateVoidOfCourse(jd, nil)
+	jd := astro.DateToJulianDay(date.UTC())
+	vocInfo := astro.CalculateVoidOfCourse(jd, zodiac, ayanamsa)
 
 	c.JSON(http.StatusOK, vocInfo)
 }
 
+// CalculateVoidOfCourseCalendar 计算一个月内的全部月亮空亡窗口
+func CalculateVoidOfCourseCalendar(c *gin.Context) {
+	var req struct {
+		Month    string            `json:"month"`    // 可选，YYYY-MM，默认本月（UTC）
+		Zodiac   models.ZodiacMode `json:"zodiac"`   // 可选，tropical/sidereal
+		Ayanamsa models.Ayanamsa   `json:"ayanamsa"` // 可选，恒星黄道岁差体系
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	zodiac, ayanamsa, ok := parseZodiac(c, req.Zodiac, req.Ayanamsa)
+	if !ok {
+		return
+	}
+
+	var month time.Time
+	if req.Month != "" {
+		parsed, err := time.Parse("2006-01", req.Month)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "无效的月份格式，应为 YYYY-MM"})
+			return
+		}
+		month = parsed
+	} else {
+		now := time.Now().UTC()
+		month = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"month":    month.Format("2006-01"),
+		"zodiac":   zodiac,
+		"ayanamsa": ayanamsa,
+		"windows":  astro.FindVoidOfCourseWindows(month, month.AddDate(0, 1, 0), zodiac, ayanamsa),
+	})
+}
+
 // CalculatePlanetaryHour 计算行星时
 func CalculatePlanetaryHour(c *gin.Context) {
 	var req struct {
@@ -371,10 +716,18 @@ func CalculatePlanetaryHour(c *gin.Context) {
 		return
 	}
 
-	// 解析日期
+	if err := astro.ValidateLocation(&models.GeoLocation{Latitude: req.Latitude, Longitude: req.Longitude}); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// 解析日期（不带时区的时间按 UTC 处理）
 	var date time.Time
 	if req.Date != "" {
-		parsed, err := time.Parse("2006-01-02T15:04:05", req.Date)
+		parsed, err := time.Parse(time.RFC3339, req.Date)
+		if err != nil {
+			parsed, err = time.Parse("2006-01-02T15:04:05", req.Date)
+		}
 		if err != nil {
 			parsed, err = time.Parse("2006-01-02", req.Date)
 			if err != nil {
@@ -388,11 +741,15 @@ func CalculatePlanetaryHour(c *gin.Context) {
 	}
 
 	if req.FullDay {
-		// 返回全天行星时
+		// 返回全天行星时（当日日出起的 24 个不等长行星时）
 		hours := astro.GetPlanetaryHoursForDate(date, req.Latitude, req.Longitude)
 		c.JSON(http.StatusOK, gin.H{
-			"date":  date.Format("2006-01-02"),
-			"hours": hours,
+			"date":        date.Format("2006-01-02"),
+			"sunrise":     hours[0].Start,
+			"sunset":      hours[12].Start,
+			"nextSunrise": hours[23].End,
+			"polar":       hours[0].Polar,
+			"hours":       hours,
 		})
 	} else {
 		// 返回当前行星时
@@ -405,21 +762,28 @@ func CalculatePlanetaryHour(c *gin.Context) {
 
 // GetFactorWeights 获取因子权重配置
 func GetFactorWeights(c *gin.Context) {
-	weights := astro.GetCurrentFactorWeights()
+	cfg := astro.CurrentScoringConfig()
 	c.JSON(http.StatusOK, gin.H{
-		"weights": weights,
+		"weights": cfg.FactorWeights,
+		"version": cfg.Version,
 		"description": gin.H{
-			"dignity":        "尊贵度因子权重（入庙/旺相/落陷/失势）",
-			"retrograde":     "逆行因子权重",
-			"aspectPhase":    "相位阶段因子权重（入相/离相）",
-			"aspectOrb":      "相位容许度因子权重",
-			"outerPlanet":    "外行星因子权重",
-			"profectionLord": "年主星因子权重",
-			"lunarPhase":     "月相因子权重",
-			"planetaryHour":  "行星时因子权重",
-			"voidOfCourse":   "月亮空亡因子权重",
-			"personal":       "个人因子权重",
-			"custom":         "自定义因子权重",
+			"dignity":           "尊贵度因子权重（入庙/旺相/三分性/界/面/落陷/失势/游离）",
+			"retrograde":        "逆行因子权重",
+			"aspectPhase":       "相位阶段因子权重（入相/离相）",
+			"aspectOrb":         "相位容许度因子权重",
+			"outerPlanet":       "外行星因子权重",
+			"profectionLord":    "年主星因子权重",
+			"lunarPhase":        "月相因子权重",
+			"planetaryHour":     "行星时因子权重",
+			"voidOfCourse":      "月亮空亡因子权重",
+			"personal":          "个人因子权重",
+			"custom":            "自定义因子权重",
+			"parallel":          "赤纬平行/反平行因子权重",
+			"outOfBounds":       "行星出界因子权重",
+			"ingress":           "重要换座因子权重",
+			"eclipse":           "日月食触发本命因子权重",
+			"fixedStar":         "慢行星合相/平行恒星因子权重",
+			"accidentalDignity": "后天尊贵因子权重（始宫/续宫/果宫、核心/焦伤/日光下、快慢、东出西入、日夜区分与得时）",
 		},
 	})
 }
@@ -432,18 +796,20 @@ func UpdateFactorWeights(c *gin.Context) {
 		return
 	}
 
-	astro.UpdateFactorWeights(req)
+	cfg := astro.UpdateFactorWeights(req)
 	c.JSON(http.StatusOK, gin.H{
 		"message": "因子权重已更新",
-		"weights": req,
+		"weights": cfg.FactorWeights,
+		"version": cfg.Version,
 	})
 }
 
 // GetDimensionWeights 获取维度权重配置
 func GetDimensionWeights(c *gin.Context) {
-	weights := astro.GetCurrentDimensionWeights()
+	cfg := astro.CurrentScoringConfig()
 	c.JSON(http.StatusOK, gin.H{
-		"weights": weights,
+		"weights": cfg.DimensionWeights,
+		"version": cfg.Version,
 		"description": gin.H{
 			"career":       "事业维度权重（默认0.25，对应10/6/1宫）",
 			"relationship": "关系维度权重（默认0.20，对应7/5/11宫）",
@@ -473,18 +839,20 @@ func UpdateDimensionWeights(c *gin.Context) {
 		return
 	}
 
-	astro.UpdateDimensionWeights(req)
+	cfg := astro.UpdateDimensionWeights(req)
 	c.JSON(http.StatusOK, gin.H{
 		"message": "维度权重已更新",
-		"weights": req,
+		"weights": cfg.DimensionWeights,
+		"version": cfg.Version,
 	})
 }
 
 // GetJitterConfig 获取抖动配置
 func GetJitterConfig(c *gin.Context) {
-	config := astro.DefaultJitterConfig
+	cfg := astro.CurrentScoringConfig()
 	c.JSON(http.StatusOK, gin.H{
-		"config": config,
+		"config":  cfg.Jitter,
+		"version": cfg.Version,
 		"description": gin.H{
 			"enabled":   "是否启用视觉抖动（仅影响显示，不影响计算）",
 			"magnitude": "抖动幅度（±范围，默认0.5）",
@@ -501,10 +869,104 @@ func UpdateJitterConfig(c *gin.Context) {
 		return
 	}
 
-	astro.UpdateJitterConfig(req)
+	cfg := astro.UpdateJitterConfig(req)
 	c.JSON(http.StatusOK, gin.H{
 		"message": "抖动配置已更新",
-		"config":  req,
+		"config":  cfg.Jitter,
+		"version": cfg.Version,
+	})
+}
+
+// GetAspectCatalogue 获取相位目录
+func GetAspectCatalogue(c *gin.Context) {
+	catalogue := astro.CurrentAspectCatalogue()
+	c.JSON(http.StatusOK, gin.H{
+		"catalogue": catalogue,
+		"version":   catalogue.Version,
+		"description": gin.H{
+			"aspects":    "相位定义：角度、基准容许度、权重、性质（harmonious/tense/neutral）、是否次要相位、维度相位分基础分值",
+			"planetOrbs": "天体容许度系数，两颗天体取较大者（默认日月 1.2）",
+			"contexts":   "情境配置（natal/transit/progressed/synastry）：容许度系数与是否计算次要相位",
+		},
+		"note": "实际容许度 = 基准容许度 × 天体系数 × 情境系数",
+	})
+}
+
+// UpdateAspectCatalogue 更新相位目录（未提供的字段保持当前值）
+func UpdateAspectCatalogue(c *gin.Context) {
+	req := astro.CurrentAspectCatalogue().Clone()
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	catalogue, err := astro.UpdateAspectCatalogue(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "相位目录已更新",
+		"catalogue": catalogue,
+		"version":   catalogue.Version,
+	})
+}
+
+// ResetAspectCatalogue 恢复默认相位目录
+func ResetAspectCatalogue(c *gin.Context) {
+	catalogue := astro.ResetAspectCatalogue()
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "相位目录已恢复默认",
+		"catalogue": catalogue,
+		"version":   catalogue.Version,
+	})
+}
+
+// GetLotCatalogue 获取阿拉伯点目录
+func GetLotCatalogue(c *gin.Context) {
+	catalogue := astro.CurrentLotCatalogue()
+	c.JSON(http.StatusOK, gin.H{
+		"catalogue": catalogue,
+		"version":   catalogue.Version,
+		"description": gin.H{
+			"add":            "公式 ASC + A - B 中的 A：天体标识、ascendant、midheaven、house1-house12（宫头）、ruler1-ruler12（宫主星）或排在前面的点",
+			"subtract":       "公式中的 B，取值同 add",
+			"reverseAtNight": "夜间盘（太阳在地平线下）交换 A 与 B",
+			"weight":         "相位权重，与天体权重同一量纲",
+			"dimensions":     "行运触发该点时的维度影响分配",
+		},
+	})
+}
+
+// UpdateLotCatalogue 更新阿拉伯点目录（lots 数组整体替换）
+func UpdateLotCatalogue(c *gin.Context) {
+	req := astro.CurrentLotCatalogue().Clone()
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	catalogue, err := astro.UpdateLotCatalogue(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	services.RecalculateNatalCharts()
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "阿拉伯点目录已更新",
+		"catalogue": catalogue,
+		"version":   catalogue.Version,
+	})
+}
+
+// ResetLotCatalogue 恢复默认阿拉伯点目录
+func ResetLotCatalogue(c *gin.Context) {
+	catalogue := astro.ResetLotCatalogue()
+	services.RecalculateNatalCharts()
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "阿拉伯点目录已恢复默认",
+		"catalogue": catalogue,
+		"version":   catalogue.Version,
 	})
 }
 
@@ -590,10 +1052,11 @@ func ClearCustomFactors(c *gin.Context) {
 //	}
 func GetScoreBreakdown(c *gin.Context) {
 	var req struct {
-		BirthData   models.BirthData `json:"birthData"`
-		QueryTime   string           `json:"queryTime"`
-		Granularity string           `json:"granularity"`
-		UserID      string           `json:"userId,omitempty"`
+		BirthData       models.BirthData    `json:"birthData"`
+		CurrentLocation *models.GeoLocation `json:"currentLocation,omitempty"` // 可选，当前所在地（行星时），默认出生地
+		QueryTime       string              `json:"queryTime"`
+		Granularity     string              `json:"granularity"`
+		UserID          string              `json:"userId,omitempty"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -628,7 +1091,10 @@ func GetScoreBreakdown(c *gin.Context) {
 	}
 
 	// 计算本命盘
-	chart := astro.CalculateNatalChart(req.BirthData)
+	chart, ok := chartForRequest(c, req.BirthData, req.CurrentLocation)
+	if !ok {
+		return
+	}
 
 	// 计算分值组成
 	breakdown := astro.CalculateScoreBreakdown(chart, queryTime, req.Granularity, req.UserID)
@@ -641,9 +1107,10 @@ func GetScoreBreakdown(c *gin.Context) {
 // 一次返回 hour/day/month/year 四个粒度的分值组成
 func GetMultiGranularityBreakdown(c *gin.Context) {
 	var req struct {
-		BirthData models.BirthData `json:"birthData"`
-		QueryTime string           `json:"queryTime"`
-		UserID    string           `json:"userId,omitempty"`
+		BirthData       models.BirthData    `json:"birthData"`
+		CurrentLocation *models.GeoLocation `json:"currentLocation,omitempty"` // 可选，当前所在地（行星时），默认出生地
+		QueryTime       string              `json:"queryTime"`
+		UserID          string              `json:"userId,omitempty"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -665,7 +1132,10 @@ func GetMultiGranularityBreakdown(c *gin.Context) {
 	}
 
 	// 计算本命盘
-	chart := astro.CalculateNatalChart(req.BirthData)
+	chart, ok := chartForRequest(c, req.BirthData, req.CurrentLocation)
+	if !ok {
+		return
+	}
 
 	// 获取多粒度分值组成
 	result := astro.GetMultiGranularityBreakdown(chart, queryTime, req.UserID)
@@ -684,11 +1154,12 @@ func GetMultiGranularityBreakdown(c *gin.Context) {
 //   - "core": 按可见性规则过滤（年度级在年/月/周/日/小时可见，月级在月/周/日/小时可见...）
 func GetActiveFactorsInRange(c *gin.Context) {
 	var req struct {
-		BirthData   models.BirthData `json:"birthData"`
-		QueryTime   string           `json:"queryTime"`   // 范围内任意时间点
-		Granularity string           `json:"granularity"` // year/month/week/day
-		Infect      string           `json:"infect"`      // all/core，默认 all
-		UserID      string           `json:"userId,omitempty"`
+		BirthData       models.BirthData    `json:"birthData"`
+		CurrentLocation *models.GeoLocation `json:"currentLocation,omitempty"` // 可选，当前所在地（行星时），默认出生地
+		QueryTime       string              `json:"queryTime"`                 // 范围内任意时间点
+		Granularity     string              `json:"granularity"`               // year/month/week/day
+		Infect          string              `json:"infect"`                    // all/core，默认 all
+		UserID          string              `json:"userId,omitempty"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -742,7 +1213,10 @@ func GetActiveFactorsInRange(c *gin.Context) {
 	}
 
 	// 计算本命盘
-	chart := astro.CalculateNatalChart(req.BirthData)
+	chart, ok := chartForRequest(c, req.BirthData, req.CurrentLocation)
+	if !ok {
+		return
+	}
 
 	// 获取时间范围内活跃的因子
 	result := astro.GetActiveFactorsInRange(chart, queryTime, req.Granularity, req.Infect, req.UserID)
@@ -757,11 +1231,12 @@ func GetActiveFactorsInRange(c *gin.Context) {
 // 用通俗易懂的语言解释分数是如何计算的，受哪些天文现象影响
 func GetScoreExplanation(c *gin.Context) {
 	var req struct {
-		BirthData   models.BirthData `json:"birthData"`
-		QueryTime   string           `json:"queryTime"`
-		Granularity string           `json:"granularity"` // hour, day, week, month, year
-		Dimension   string           `json:"dimension"`   // career, relationship, health, finance, spiritual, overall
-		UserID      string           `json:"userId,omitempty"`
+		BirthData       models.BirthData    `json:"birthData"`
+		CurrentLocation *models.GeoLocation `json:"currentLocation,omitempty"` // 可选，当前所在地（行星时），默认出生地
+		QueryTime       string              `json:"queryTime"`
+		Granularity     string              `json:"granularity"` // hour, day, week, month, year
+		Dimension       string              `json:"dimension"`   // career, relationship, health, finance, spiritual, overall
+		UserID          string              `json:"userId,omitempty"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -816,7 +1291,10 @@ func GetScoreExplanation(c *gin.Context) {
 	}
 
 	// 计算本命盘
-	chart := astro.CalculateNatalChart(req.BirthData)
+	chart, ok := chartForRequest(c, req.BirthData, req.CurrentLocation)
+	if !ok {
+		return
+	}
 
 	// 获取分数解释
 	explanation := astro.GetScoreExplanation(chart, queryTime, req.Granularity, req.Dimension, req.UserID)
@@ -35,8 +35,15 @@ func SetupRouter() *gin.Engine {
 			calc.POST("/profection-map", CalculateProfectionMap)
 			calc.POST("/transits", CalculateTransits)
 			calc.POST("/progressions", CalculateProgressions)
+			calc.POST("/synastry", CalculateSynastry)
+			calc.POST("/stations", CalculateStations)
+			calc.POST("/ingresses", CalculateIngresses)
+			calc.POST("/lunations", CalculateLunations)
+			calc.POST("/eclipses", CalculateEclipses)
 			calc.POST("/void-of-course", CalculateVoidOfCourse)
+			calc.POST("/void-of-course/calendar", CalculateVoidOfCourseCalendar)
 			calc.POST("/planetary-hour", CalculatePlanetaryHour)
+			calc.POST("/fixed-stars", CalculateFixedStars)
 			
 			// 分值组成查询（详细因子分解）
 			calc.POST("/score-breakdown", GetScoreBreakdown)         // 单粒度（开发调试用）
@@ -81,6 +88,16 @@ func SetupRouter() *gin.Engine {
 			admin.GET("/jitter-config", GetJitterConfig)
 			admin.PUT("/jitter-config", UpdateJitterConfig)
 
+			// 相位目录（相位种类、天体与情境容许度）
+			admin.GET("/aspect-catalogue", GetAspectCatalogue)
+			admin.PUT("/aspect-catalogue", UpdateAspectCatalogue)
+			admin.DELETE("/aspect-catalogue", ResetAspectCatalogue)
+
+			// 阿拉伯点目录（含自定义点公式）
+			admin.GET("/lot-catalogue", GetLotCatalogue)
+			admin.PUT("/lot-catalogue", UpdateLotCatalogue)
+			admin.DELETE("/lot-catalogue", ResetLotCatalogue)
+
 			// 自定义因子管理
 			admin.POST("/custom-factors", AddCustomFactor)
 			admin.GET("/custom-factors/:userId", GetCustomFactors)
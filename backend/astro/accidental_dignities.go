@@ -0,0 +1,338 @@
+package astro
+
+import (
+	"fmt"
+	"math"
+	"star/models"
+	"strings"
+	"time"
+)
+
+// ==================== 后天尊贵 ====================
+// 后天（偶然）尊贵描述行星在具体星盘中的处境，参照 Lilly 的计分：
+// 宫位：始宫 +4、续宫 +2、果宫 −2；
+// 与太阳：核心（cazimi，17′ 内）+5、焦伤（combust，8.5° 内）−5、在日光下（under the beams，17° 内）−4；
+// 速度：顺行且快于平均速度 +2，慢于平均速度或逆行 −2（逆行本身另由逆行因子计入）；
+// 东出西入：外行星（火木土）东出 +2、西入 −2，内行星（水金）与月亮相反；
+// 日夜区分：合乎日夜 +1、不合 −1，得时（hayz）+2。
+// 与太阳的距离、东出西入与日夜区分只对古典行星计分，外行星只计宫位与速度
+
+// 后天尊贵的各项状态
+const (
+	HouseAngular    = "angular"
+	HouseSuccedent  = "succedent"
+	HouseCadent     = "cadent"
+	SolarCazimi     = "cazimi"
+	SolarCombust    = "combust"
+	SolarUnderBeams = "underBeams"
+	MotionSwift     = "swift"
+	MotionSlow      = "slow"
+	Oriental        = "oriental"
+	Occidental      = "occidental"
+	InSect          = "inSect"
+	OutOfSect       = "outOfSect"
+	Hayz            = "hayz"
+)
+
+// AccidentalPoints 各项后天尊贵的分数（东出西入的正负按行星另行判断）
+var AccidentalPoints = map[string]float64{
+	HouseAngular:    4,
+	HouseSuccedent:  2,
+	HouseCadent:     -2,
+	SolarCazimi:     5,
+	SolarCombust:    -5,
+	SolarUnderBeams: -4,
+	MotionSwift:     2,
+	MotionSlow:      -2,
+	InSect:          1,
+	OutOfSect:       -1,
+	Hayz:            2,
+}
+
+// orientationPoints 东出西入的分数
+const orientationPoints = 2.0
+
+// 与太阳的距离界限（度）
+const (
+	cazimiOrb     = 17.0 / 60
+	combustOrb    = 8.5
+	underBeamsOrb = 17.0
+)
+
+// MeanDailyMotion 行星的平均黄经速度（度/天）
+var MeanDailyMotion = map[models.PlanetID]float64{
+	models.Sun:     0.9856,
+	models.Moon:    13.1764,
+	models.Mercury: 0.9856,
+	models.Venus:   0.9856,
+	models.Mars:    0.5240,
+	models.Jupiter: 0.0831,
+	models.Saturn:  0.0335,
+	models.Uranus:  0.0117,
+	models.Neptune: 0.0060,
+	models.Pluto:   0.0040,
+}
+
+// houseAngularity 宫位的始宫/续宫/果宫归类
+func houseAngularity(house int) string {
+	switch house {
+	case 1, 4, 7, 10:
+		return HouseAngular
+	case 2, 5, 8, 11:
+		return HouseSuccedent
+	case 3, 6, 9, 12:
+		return HouseCadent
+	}
+	return ""
+}
+
+// solarPhase 行星与太阳的距离状态
+func solarPhase(distance float64) string {
+	switch {
+	case distance <= cazimiOrb:
+		return SolarCazimi
+	case distance <= combustOrb:
+		return SolarCombust
+	case distance <= underBeamsOrb:
+		return SolarUnderBeams
+	}
+	return ""
+}
+
+// orientation 东出（黄经在太阳之前 180° 内，先于太阳升起）或西入
+func orientation(longitude, sunLongitude float64) string {
+	if NormalizeAngle(longitude-sunLongitude) > 180 {
+		return Oriental
+	}
+	return Occidental
+}
+
+// orientationFavourable 东出西入对该行星是否有利：外行星宜东出，内行星与月亮（渐盈）宜西入
+func orientationFavourable(planet models.PlanetID, side string) bool {
+	switch planet {
+	case models.Mars, models.Jupiter, models.Saturn:
+		return side == Oriental
+	case models.Mercury, models.Venus, models.Moon:
+		return side == Occidental
+	}
+	return false
+}
+
+// planetSect 行星所属的日夜区分：太阳、木星、土星为日间，月亮、金星、火星为夜间，
+// 水星东出为日间、西入为夜间；外行星不分日夜
+func planetSect(planet models.PlanetID, side string) models.Sect {
+	switch planet {
+	case models.Sun, models.Jupiter, models.Saturn:
+		return models.SectDay
+	case models.Moon, models.Venus, models.Mars:
+		return models.SectNight
+	case models.Mercury:
+		if side == Oriental {
+			return models.SectDay
+		}
+		return models.SectNight
+	}
+	return ""
+}
+
+// inHayz 得时：行星合乎日夜时位于地平线以上、不合时位于地平线以下，且落在同性别星座（日间行星阳性、夜间行星阴性）
+func inHayz(own, sect models.Sect, sign models.ZodiacID, house int) bool {
+	if own == "" || house == 0 {
+		return false
+	}
+	element := GetZodiacInfo(sign).Element
+	masculine := element == "fire" || element == "air"
+	if masculine != (own == models.SectDay) {
+		return false
+	}
+	aboveHorizon := house >= 7
+	return (own == sect) == aboveHorizon
+}
+
+// AccidentalDignities 计算行星在星盘中的后天尊贵
+// 行星需已分配宫位（宫位为 0 时不计宫位与得时）；sun 为同一时刻的太阳，为 nil 时不计与太阳相关的各项
+func AccidentalDignities(p models.PlanetPosition, sun *models.PlanetPosition, sect models.Sect) *models.AccidentalDignity {
+	a := &models.AccidentalDignity{House: houseAngularity(p.House)}
+
+	if mean := MeanDailyMotion[p.ID]; mean > 0 {
+		// 逆行不算快
+		a.SpeedRatio = math.Round(p.Speed/mean*100) / 100
+		a.Motion = MotionSlow
+		if p.Speed >= mean {
+			a.Motion = MotionSwift
+		}
+	}
+
+	if sun != nil && p.ID != models.Sun {
+		distance := AngleDifference(p.Longitude, sun.Longitude)
+		a.SunDistance = math.Round(distance*100) / 100
+		a.SolarPhase = solarPhase(distance)
+		a.Orientation = orientation(p.Longitude, sun.Longitude)
+	}
+
+	if own := planetSect(p.ID, a.Orientation); own != "" && sect != "" && (p.ID != models.Mercury || a.Orientation != "") {
+		a.Sect = OutOfSect
+		if own == sect {
+			a.Sect = InSect
+		}
+		a.Hayz = inHayz(own, sect, p.Sign, p.House)
+	}
+
+	for _, c := range accidentalConditions(p.ID, a) {
+		a.Score += c.Points
+	}
+	return a
+}
+
+// accidentalCondition 一项后天尊贵及其分数
+type accidentalCondition struct {
+	Name   string
+	Points float64
+}
+
+// accidentalConditions 列出计分的各项后天尊贵（与太阳、宫位、速度、东出西入、日夜区分的顺序）
+func accidentalConditions(planet models.PlanetID, a *models.AccidentalDignity) []accidentalCondition {
+	var conditions []accidentalCondition
+	add := func(name string, points float64) {
+		if name != "" && points != 0 {
+			conditions = append(conditions, accidentalCondition{name, points})
+		}
+	}
+
+	classical := isClassicalPlanet(planet)
+	if classical {
+		add(a.SolarPhase, AccidentalPoints[a.SolarPhase])
+	}
+	add(a.House, AccidentalPoints[a.House])
+	add(a.Motion, AccidentalPoints[a.Motion])
+	if classical && a.Orientation != "" {
+		if orientationFavourable(planet, a.Orientation) {
+			add(a.Orientation, orientationPoints)
+		} else {
+			add(a.Orientation, -orientationPoints)
+		}
+	}
+	if a.Hayz {
+		add(Hayz, AccidentalPoints[Hayz])
+	} else {
+		add(a.Sect, AccidentalPoints[a.Sect])
+	}
+	return conditions
+}
+
+// ApplyAccidentalDignities 为有平均速度的行星（太阳至冥王星）填充后天尊贵，行星需已分配宫位
+func ApplyAccidentalDignities(planets []models.PlanetPosition, sect models.Sect) {
+	sun := findPosition(planets, models.Sun)
+	for i := range planets {
+		if _, ok := MeanDailyMotion[planets[i].ID]; !ok {
+			continue
+		}
+		planets[i].Accidental = AccidentalDignities(planets[i], sun, sect)
+	}
+}
+
+// accidentalValue 后天尊贵分换算为主导行星、基础分与因子的数值（与先天尊贵同一比例）
+func accidentalValue(p models.PlanetPosition) float64 {
+	if p.Accidental == nil {
+		return 0
+	}
+	return dignityValue(p.Accidental.Score)
+}
+
+// getAccidentalMultiplier 获取后天尊贵乘数：每 1 分 ±5%，限制在 0.75 至 1.25
+func getAccidentalMultiplier(p models.PlanetPosition) float64 {
+	if p.Accidental == nil {
+		return 1
+	}
+	return math.Max(0.75, math.Min(1.25, 1+p.Accidental.Score/20))
+}
+
+// ==================== 后天尊贵因子 ====================
+
+// accidentalLabels 各项后天尊贵的英文名称
+var accidentalLabels = map[string]string{
+	HouseAngular:    "Angular",
+	HouseSuccedent:  "Succedent",
+	HouseCadent:     "Cadent",
+	SolarCazimi:     "Cazimi",
+	SolarCombust:    "Combust",
+	SolarUnderBeams: "Under the Beams",
+	MotionSwift:     "Swift",
+	MotionSlow:      "Slow",
+	Oriental:        "Oriental",
+	Occidental:      "Occidental",
+	InSect:          "in Sect",
+	OutOfSect:       "out of Sect",
+	Hayz:            "in Hayz",
+}
+
+// accidentalReasons 各项后天尊贵的占星依据
+var accidentalReasons = map[string]string{
+	HouseAngular:    "Planets on the angles of the sky act openly and decisively",
+	HouseSuccedent:  "Succedent houses give a planet steady, resource-backed expression",
+	HouseCadent:     "Cadent houses turn away from the angles, leaving a planet with little visible effect",
+	SolarCazimi:     "In the heart of the Sun a planet is empowered by the king, the strongest accidental dignity",
+	SolarCombust:    "Burnt by the Sun a planet cannot act for itself and its significations are hidden or harmed",
+	SolarUnderBeams: "Under the Sun's beams a planet is weakened and obscured, though less severely than combust",
+	MotionSwift:     "Moving faster than its average pace, a planet brings matters about quickly",
+	MotionSlow:      "Moving slower than its average pace, a planet delays and drags its matters",
+	Oriental:        "Rising before the Sun strengthens Mars, Jupiter and Saturn but hastens Mercury, Venus and the waning Moon",
+	Occidental:      "Setting after the Sun favours Mercury, Venus and the waxing Moon but weakens Mars, Jupiter and Saturn",
+	InSect:          "A planet belonging to the sect of the chart works in its element and is more constructive",
+	OutOfSect:       "A planet contrary to the sect of the chart is out of its element and harder to manage",
+	Hayz:            "In hayz a planet matches the sect, hemisphere and gender of its sign, rejoicing in its condition",
+}
+
+// MomentAccidentalPositions 某一时刻在观测地点的行运后天尊贵：按该时刻的宫位与日夜区分计算
+func MomentAccidentalPositions(chart *models.NatalChart, date time.Time, transitPositions []models.PlanetPosition) []models.PlanetPosition {
+	jd := DateToJulianDay(date)
+	lat, lon := ObserverLocation(chart)
+	houses, asc, mc := CalculateHousesUnified(jd, lat, lon, chart.HouseSystem)
+	houses, asc, _ = ApplyZodiacToHouses(houses, asc, mc, chart.HouseSystem, ChartZodiacOffset(chart, jd))
+
+	positions := AssignHousesToPlanets(transitPositions, houses)
+	ApplyAccidentalDignities(positions, planetsSect(positions, asc))
+	return positions
+}
+
+// calculateAccidentalFactorsV2 计算后天尊贵因子
+// 行运行星按观测地点此刻的宫位、与太阳的距离、速度、东出西入与日夜区分计分，按分值最大的一项命名
+func calculateAccidentalFactorsV2(chart *models.NatalChart, transitPositions []models.PlanetPosition, weight float64, date time.Time) []models.InfluenceFactor {
+	var factors []models.InfluenceFactor
+
+	for _, p := range MomentAccidentalPositions(chart, date, transitPositions) {
+		if p.Accidental == nil {
+			continue
+		}
+		value := accidentalValue(p)
+		conditions := accidentalConditions(p.ID, p.Accidental)
+		if value == 0 || len(conditions) == 0 {
+			continue
+		}
+
+		main := conditions[0]
+		var labels []string
+		for _, c := range conditions {
+			if math.Abs(c.Points) > math.Abs(main.Points) {
+				main = c
+			}
+			labels = append(labels, strings.ToLower(accidentalLabels[c.Name]))
+		}
+
+		planetInfo := GetPlanetInfo(p.ID)
+		factors = append(factors, models.InfluenceFactor{
+			Type:            models.FactorAccidental,
+			Name:            planetInfo.Name + " " + accidentalLabels[main.Name],
+			Description:     fmt.Sprintf("%s is %s (accidental score %+.0f)", planetInfo.Name, strings.Join(labels, ", "), p.Accidental.Score),
+			TimeLevel:       models.TimeLevelDaily,
+			BaseValue:       value,
+			Weight:          weight,
+			DimensionImpact: GetPlanetDimensionImpact(p.ID),
+			SourcePlanet:    p.ID,
+			IsPositive:      value > 0,
+			AstroReason:     accidentalReasons[main.Name],
+		})
+	}
+
+	return factors
+}
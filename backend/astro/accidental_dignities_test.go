@@ -0,0 +1,101 @@
+package astro
+
+import (
+	"star/models"
+	"testing"
+	"time"
+)
+
+// TestAccidentalDignities 测试宫位、焦伤/核心、快慢、东出西入与得时的计分
+func TestAccidentalDignities(t *testing.T) {
+	place := func(id models.PlanetID, lon, speed float64, house int) models.PlanetPosition {
+		p := newPlanetPosition(id, lon, 0, speed < 0)
+		p.Speed = speed
+		p.House = house
+		return p
+	}
+	planets := []models.PlanetPosition{
+		place(models.Sun, 100, 0.95, 10),
+		place(models.Mercury, 100.1, 1.5, 10),
+		place(models.Mars, 95, 0.7, 9),
+		place(models.Jupiter, 190, -0.05, 10),
+		place(models.Pluto, 101, 0.01, 10),
+	}
+	ApplyAccidentalDignities(planets, models.SectDay)
+
+	cases := []struct {
+		name   string
+		planet models.PlanetID
+		score  float64
+	}{
+		// 始宫 4 − 慢 2 + 合乎日夜 1（巨蟹为阴性星座，不得时）
+		{"太阳天顶", models.Sun, 3},
+		// 核心 5 + 始宫 4 + 快 2 + 西入 2 − 西入的水星属夜间 1
+		{"水星核心", models.Mercury, 12},
+		// 焦伤 −5 − 果宫 2 + 快 2 + 东出 2 − 不合日夜 1
+		{"火星焦伤", models.Mars, -4},
+		// 始宫 4 − 逆行 2 − 西入 2 + 日间阳性星座在地平线上得时 2
+		{"木星逆行得时", models.Jupiter, 2},
+		// 外行星不计焦伤与日夜：始宫 4 + 快 2
+		{"冥王星", models.Pluto, 6},
+	}
+	for _, tc := range cases {
+		a := findPosition(planets, tc.planet).Accidental
+		if a == nil || a.Score != tc.score {
+			t.Errorf("%s: 后天尊贵分应为 %+.0f，实际 %+v", tc.name, tc.score, a)
+		}
+	}
+
+	mercury := findPosition(planets, models.Mercury).Accidental
+	if mercury.SolarPhase != SolarCazimi || mercury.Orientation != Occidental || mercury.Sect != OutOfSect {
+		t.Errorf("水星应为核心、西入且不合日夜: %+v", mercury)
+	}
+	if jupiter := findPosition(planets, models.Jupiter).Accidental; jupiter.Motion != MotionSlow || !jupiter.Hayz {
+		t.Errorf("逆行的木星应计为慢且得时: %+v", jupiter)
+	}
+}
+
+// TestAccidentalDignityFactors 测试本命盘的后天尊贵与行运后天尊贵因子
+func TestAccidentalDignityFactors(t *testing.T) {
+	useProvider(t, NewPreciseProvider())
+
+	birth := models.BirthData{
+		Year: 1990, Month: 6, Day: 15, Hour: 14, Minute: 30,
+		Latitude: 39.9042, Longitude: 116.4074, Timezone: 8,
+	}
+	chart := CalculateNatalChart(birth)
+	for _, p := range chart.Planets {
+		if _, ok := MeanDailyMotion[p.ID]; ok != (p.Accidental != nil) {
+			t.Errorf("%s 的后天尊贵填充有误: %+v", p.ID, p.Accidental)
+		}
+	}
+
+	date := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	transits := GetTransitPositionsForChart(chart, date)
+	expected := map[models.PlanetID]float64{}
+	for _, p := range MomentAccidentalPositions(chart, date, transits) {
+		if v := accidentalValue(p); v != 0 {
+			expected[p.ID] = v
+		}
+	}
+	if len(expected) == 0 {
+		t.Fatal("此刻应有行星带后天尊贵分")
+	}
+
+	found := 0
+	for _, f := range CalculateInfluenceFactorsV2(chart, date, transits).Factors {
+		if f.Type != models.FactorAccidental {
+			continue
+		}
+		found++
+		if f.BaseValue != expected[f.SourcePlanet] || f.TimeLevel != models.TimeLevelDaily {
+			t.Errorf("%s 的基础值应为 %.1f，实际 %.1f（%s）", f.Name, expected[f.SourcePlanet], f.BaseValue, f.Description)
+		}
+	}
+	if found != len(expected) {
+		t.Errorf("应为 %d 颗行星生成后天尊贵因子，实际 %d", len(expected), found)
+	}
+	if transits[0].Accidental != nil {
+		t.Error("计算因子不应修改传入的行运位置")
+	}
+}
@@ -0,0 +1,250 @@
+package astro
+
+import (
+	"fmt"
+	"math"
+	"star/models"
+	"sync"
+	"sync/atomic"
+)
+
+// ==================== 相位目录 ====================
+// 相位种类、容许度与情境系数由运营配置，与评分配置一样以不可变的版本化快照发布。
+// 实际容许度 = 相位基准容许度 × 天体容许度系数（两颗天体取较大者）× 情境系数
+// 赤纬相位（平行/反平行）的容许度按赤纬差计算，同样乘以天体与情境系数
+
+// AspectContext 相位计算情境
+type AspectContext string
+
+const (
+	AspectContextNatal      AspectContext = "natal"      // 本命盘内部相位
+	AspectContextTransit    AspectContext = "transit"    // 行运对本命
+	AspectContextProgressed AspectContext = "progressed" // 推运盘内部相位
+	AspectContextSynastry   AspectContext = "synastry"   // 合盘（双方本命之间）
+)
+
+// AspectContextConfig 情境配置
+type AspectContextConfig struct {
+	OrbFactor    float64 `json:"orbFactor"`    // 容许度系数
+	IncludeMinor bool    `json:"includeMinor"` // 是否计算次要相位
+}
+
+// DefaultPlanetOrbFactors 默认天体容许度系数（发光体放宽，未列出的天体为 1）
+var DefaultPlanetOrbFactors = map[models.PlanetID]float64{
+	models.Sun:       1.2,
+	models.Moon:      1.2,
+	models.NorthNode: 0.6,
+	models.Chiron:    0.6,
+	models.SouthNode: 0.6,
+	models.MeanNode:  0.6,
+	models.Ceres:     0.5,
+	models.Pallas:    0.5,
+	models.Juno:      0.5,
+	models.Vesta:     0.5,
+	models.Lilith:    0.5,
+	models.OscLilith: 0.5,
+	models.Eris:      0.5,
+}
+
+// DefaultAspectContexts 默认情境配置（行运容许度沿用本命的 80%，行运评分不计次要相位）
+var DefaultAspectContexts = map[AspectContext]AspectContextConfig{
+	AspectContextNatal:      {OrbFactor: 1, IncludeMinor: true},
+	AspectContextTransit:    {OrbFactor: 0.8, IncludeMinor: false},
+	AspectContextProgressed: {OrbFactor: 1, IncludeMinor: true},
+	AspectContextSynastry:   {OrbFactor: 0.8, IncludeMinor: false},
+}
+
+// AspectCatalogue 相位目录快照（发布后不可修改）
+type AspectCatalogue struct {
+	Version      int64                                 `json:"version"`
+	Aspects      []AspectDefinition                    `json:"aspects"`
+	Declinations []AspectDefinition                    `json:"declinations"` // 赤纬相位
+	PlanetOrbs   map[models.PlanetID]float64           `json:"planetOrbs"`
+	Contexts     map[AspectContext]AspectContextConfig `json:"contexts"`
+}
+
+var (
+	aspectCatalogue   atomic.Pointer[AspectCatalogue]
+	aspectCatalogueMu sync.Mutex // 串行化写操作
+)
+
+func init() {
+	catalogue := defaultAspectCatalogue()
+	catalogue.Version = 1
+	aspectCatalogue.Store(catalogue)
+}
+
+// defaultAspectCatalogue 由默认定义构造相位目录
+func defaultAspectCatalogue() *AspectCatalogue {
+	catalogue := &AspectCatalogue{
+		Aspects:      append([]AspectDefinition(nil), AspectDefinitions...),
+		Declinations: append([]AspectDefinition(nil), DeclinationDefinitions...),
+		PlanetOrbs:   make(map[models.PlanetID]float64, len(DefaultPlanetOrbFactors)),
+		Contexts:     make(map[AspectContext]AspectContextConfig, len(DefaultAspectContexts)),
+	}
+	for planet, factor := range DefaultPlanetOrbFactors {
+		catalogue.PlanetOrbs[planet] = factor
+	}
+	for ctx, cfg := range DefaultAspectContexts {
+		catalogue.Contexts[ctx] = cfg
+	}
+	return catalogue
+}
+
+// CurrentAspectCatalogue 获取当前相位目录快照
+func CurrentAspectCatalogue() *AspectCatalogue {
+	return aspectCatalogue.Load()
+}
+
+// Clone 深拷贝目录，供管理接口在副本上修改
+func (c *AspectCatalogue) Clone() *AspectCatalogue {
+	clone := *c
+	clone.Aspects = append([]AspectDefinition(nil), c.Aspects...)
+	clone.Declinations = append([]AspectDefinition(nil), c.Declinations...)
+	clone.PlanetOrbs = make(map[models.PlanetID]float64, len(c.PlanetOrbs))
+	for planet, factor := range c.PlanetOrbs {
+		clone.PlanetOrbs[planet] = factor
+	}
+	clone.Contexts = make(map[AspectContext]AspectContextConfig, len(c.Contexts))
+	for ctx, cfg := range c.Contexts {
+		clone.Contexts[ctx] = cfg
+	}
+	return &clone
+}
+
+// Validate 校验目录配置
+func (c *AspectCatalogue) Validate() error {
+	if len(c.Aspects) == 0 {
+		return fmt.Errorf("相位目录不能为空")
+	}
+	seen := make(map[models.AspectType]bool, len(c.Aspects))
+	for _, def := range c.Aspects {
+		if def.Type == "" {
+			return fmt.Errorf("相位类型不能为空")
+		}
+		if seen[def.Type] {
+			return fmt.Errorf("相位类型重复: %s", def.Type)
+		}
+		seen[def.Type] = true
+		if def.Angle < 0 || def.Angle > 180 {
+			return fmt.Errorf("相位 %s 的角度必须在 0-180° 之间", def.Type)
+		}
+		if def.Orb < 0 || def.Orb > 20 {
+			return fmt.Errorf("相位 %s 的容许度必须在 0-20° 之间", def.Type)
+		}
+		switch def.Nature {
+		case "harmonious", "tense", "neutral":
+		default:
+			return fmt.Errorf("相位 %s 的性质无效: %s", def.Type, def.Nature)
+		}
+	}
+	for _, def := range c.Declinations {
+		switch def.Type {
+		case models.Parallel, models.Contraparallel:
+		default:
+			return fmt.Errorf("赤纬相位类型无效: %s", def.Type)
+		}
+		if seen[def.Type] {
+			return fmt.Errorf("相位类型重复: %s", def.Type)
+		}
+		seen[def.Type] = true
+		if def.Orb < 0 || def.Orb > 5 {
+			return fmt.Errorf("赤纬相位 %s 的容许度必须在 0-5° 之间", def.Type)
+		}
+		switch def.Nature {
+		case "harmonious", "tense", "neutral":
+		default:
+			return fmt.Errorf("赤纬相位 %s 的性质无效: %s", def.Type, def.Nature)
+		}
+	}
+	for planet, factor := range c.PlanetOrbs {
+		if factor <= 0 || factor > 3 {
+			return fmt.Errorf("天体 %s 的容许度系数必须在 0-3 之间", planet)
+		}
+	}
+	for ctx, cfg := range c.Contexts {
+		switch ctx {
+		case AspectContextNatal, AspectContextTransit, AspectContextProgressed, AspectContextSynastry:
+		default:
+			return fmt.Errorf("未知的相位情境: %s", ctx)
+		}
+		if cfg.OrbFactor <= 0 || cfg.OrbFactor > 3 {
+			return fmt.Errorf("情境 %s 的容许度系数必须在 0-3 之间", ctx)
+		}
+	}
+	return nil
+}
+
+// UpdateAspectCatalogue 校验并发布新的相位目录
+func UpdateAspectCatalogue(next *AspectCatalogue) (*AspectCatalogue, error) {
+	if err := next.Validate(); err != nil {
+		return nil, err
+	}
+
+	aspectCatalogueMu.Lock()
+	defer aspectCatalogueMu.Unlock()
+
+	published := next.Clone()
+	published.Version = aspectCatalogue.Load().Version + 1
+	aspectCatalogue.Store(published)
+	return published, nil
+}
+
+// ResetAspectCatalogue 恢复默认相位目录
+func ResetAspectCatalogue() *AspectCatalogue {
+	catalogue, _ := UpdateAspectCatalogue(defaultAspectCatalogue())
+	return catalogue
+}
+
+// Definition 按类型查找相位定义（含赤纬相位）
+func (c *AspectCatalogue) Definition(t models.AspectType) *AspectDefinition {
+	for _, def := range c.Aspects {
+		if def.Type == t {
+			return &def
+		}
+	}
+	for _, def := range c.Declinations {
+		if def.Type == t {
+			return &def
+		}
+	}
+	return nil
+}
+
+// contextConfig 情境配置，未配置时按本命处理
+func (c *AspectCatalogue) contextConfig(ctx AspectContext) AspectContextConfig {
+	if cfg, ok := c.Contexts[ctx]; ok {
+		return cfg
+	}
+	return AspectContextConfig{OrbFactor: 1, IncludeMinor: true}
+}
+
+// Definitions 情境下参与计算的相位
+func (c *AspectCatalogue) Definitions(ctx AspectContext) []AspectDefinition {
+	includeMinor := c.contextConfig(ctx).IncludeMinor
+	defs := make([]AspectDefinition, 0, len(c.Aspects))
+	for _, def := range c.Aspects {
+		if def.Minor && !includeMinor {
+			continue
+		}
+		defs = append(defs, def)
+	}
+	return defs
+}
+
+// planetOrbFactor 天体容许度系数（未配置的阿拉伯点按 lotOrbFactor）
+func (c *AspectCatalogue) planetOrbFactor(planet models.PlanetID) float64 {
+	if factor, ok := c.PlanetOrbs[planet]; ok {
+		return factor
+	}
+	if IsLot(planet) {
+		return lotOrbFactor
+	}
+	return 1
+}
+
+// Orb 两颗天体在指定情境下形成该相位的容许度
+func (c *AspectCatalogue) Orb(def AspectDefinition, p1, p2 models.PlanetID, ctx AspectContext) float64 {
+	factor := math.Max(c.planetOrbFactor(p1), c.planetOrbFactor(p2))
+	return def.Orb * factor * c.contextConfig(ctx).OrbFactor
+}
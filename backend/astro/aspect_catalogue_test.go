@@ -0,0 +1,73 @@
+package astro
+
+import (
+	"math"
+	"star/models"
+	"testing"
+)
+
+// findAspect 在相位列表中查找两颗行星之间的相位
+func findAspect(aspects []models.AspectData, p1, p2 models.PlanetID) *models.AspectData {
+	for i, a := range aspects {
+		if a.Planet1 == p1 && a.Planet2 == p2 {
+			return &aspects[i]
+		}
+	}
+	return nil
+}
+
+// TestAspectCatalogueOrbs 测试天体与情境容许度及次要相位
+func TestAspectCatalogueOrbs(t *testing.T) {
+	t.Cleanup(func() { ResetAspectCatalogue() })
+	catalogue := CurrentAspectCatalogue()
+
+	square := *catalogue.Definition(models.Square)
+	if orb := catalogue.Orb(square, models.Sun, models.Mars, AspectContextNatal); math.Abs(orb-9.6) > 1e-9 {
+		t.Errorf("日火四分相本命容许度应为 9.6°（发光体放宽），实际 %.2f°", orb)
+	}
+	if orb := catalogue.Orb(square, models.Venus, models.Mars, AspectContextTransit); math.Abs(orb-6.4) > 1e-9 {
+		t.Errorf("金火四分相行运容许度应为 6.4°，实际 %.2f°", orb)
+	}
+
+	planets := []models.PlanetPosition{
+		newPlanetPosition(models.Sun, 10, 0, false),
+		newPlanetPosition(models.Mars, 101, 0, false),  // 与太阳 91°：四分相
+		newPlanetPosition(models.Venus, 160, 0, false), // 与太阳 150°：梅花相
+	}
+	natal := CalculateAspects(planets)
+	if a := findAspect(natal, models.Sun, models.Venus); a == nil || a.AspectType != models.Quincunx {
+		t.Errorf("本命情境应识别梅花相: %+v", a)
+	}
+	if a := findAspect(natal, models.Sun, models.Mars); a == nil || a.AllowedOrb != 9.6 {
+		t.Errorf("相位应记录实际容许度: %+v", a)
+	}
+
+	transit := CalculateTransitToNatalAspects(planets[2:], planets[:1])
+	if len(transit) != 0 {
+		t.Errorf("行运情境默认不计次要相位: %+v", transit)
+	}
+
+	// 管理端开启行运次要相位并调整火星容许度
+	next := catalogue.Clone()
+	next.Contexts[AspectContextTransit] = AspectContextConfig{OrbFactor: 0.8, IncludeMinor: true}
+	next.PlanetOrbs[models.Mars] = 0.1
+	updated, err := UpdateAspectCatalogue(next)
+	if err != nil {
+		t.Fatalf("更新相位目录失败: %v", err)
+	}
+	if updated.Version != catalogue.Version+1 {
+		t.Errorf("版本号应加一: %d -> %d", catalogue.Version, updated.Version)
+	}
+	if len(CalculateTransitToNatalAspects(planets[2:], planets[:1])) != 1 {
+		t.Errorf("开启后行运情境应计算梅花相")
+	}
+	if _, ok := catalogue.PlanetOrbs[models.Mars]; ok {
+		t.Errorf("已发布的旧快照不应被修改")
+	}
+
+	invalid := updated.Clone()
+	invalid.Aspects = append(invalid.Aspects, invalid.Aspects[0])
+	if _, err := UpdateAspectCatalogue(invalid); err == nil {
+		t.Errorf("重复的相位类型应被拒绝")
+	}
+}
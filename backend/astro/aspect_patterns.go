@@ -0,0 +1,548 @@
+package astro
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"star/models"
+)
+
+// ==================== 图形相位 ====================
+// 在一组相位（本命、行运与本命合并、合盘）上检测图形相位，返回参与者、顶点、元素/模式与紧密度：
+// 大三角、T 三角、大十字、风筝、上帝之指（Yod）、神秘长方形、大六角、雷神之锤、摇篮与群星（同星座或同宫位）。
+// 紧密度为组成相位强度（1 − 偏差/容许度）的平均值；格局分值 × 紧密度即格局强度，计入本命基础分
+
+// 图形相位所属的盘
+const (
+	PatternChartNatal   = "natal"
+	PatternChartTransit = "transit"
+	PatternChartA       = "a"
+	PatternChartB       = "b"
+)
+
+// PatternValues 各格局的分值：和谐格局为正，紧张格局为负
+var PatternValues = map[models.PatternType]float64{
+	models.PatternGrandTrine:      3,
+	models.PatternKite:            3.5,
+	models.PatternGrandSextile:    4,
+	models.PatternMysticRectangle: 2,
+	models.PatternCradle:          2,
+	models.PatternStellium:        1.5,
+	models.PatternTSquare:         -2,
+	models.PatternGrandCross:      -3,
+	models.PatternYod:             -1.5,
+	models.PatternThorsHammer:     -2,
+}
+
+// patternNames 格局的英文名称
+var patternNames = map[models.PatternType]string{
+	models.PatternGrandTrine:      "Grand Trine",
+	models.PatternTSquare:         "T-Square",
+	models.PatternGrandCross:      "Grand Cross",
+	models.PatternKite:            "Kite",
+	models.PatternYod:             "Yod",
+	models.PatternMysticRectangle: "Mystic Rectangle",
+	models.PatternGrandSextile:    "Grand Sextile",
+	models.PatternStellium:        "Stellium",
+	models.PatternThorsHammer:     "Thor's Hammer",
+	models.PatternCradle:          "Cradle",
+}
+
+// patternContainers 包含该格局的更大格局：被包含时只报告更大的格局
+var patternContainers = map[models.PatternType][]models.PatternType{
+	models.PatternGrandTrine:      {models.PatternKite, models.PatternGrandSextile},
+	models.PatternTSquare:         {models.PatternGrandCross},
+	models.PatternKite:            {models.PatternGrandSextile},
+	models.PatternMysticRectangle: {models.PatternGrandSextile},
+	models.PatternCradle:          {models.PatternGrandSextile},
+}
+
+// stelliumMinimum 构成群星的最少行星数
+const stelliumMinimum = 3
+
+// patternPoint 参与检测的点
+type patternPoint struct {
+	Chart    string
+	Position models.PlanetPosition
+}
+
+// patternGraph 点与点之间的相位图
+type patternGraph struct {
+	points []patternPoint
+	index  map[string]int
+	edges  map[[2]int]models.AspectData
+	mixed  bool // 只保留跨盘的格局（行运与本命、合盘）
+	// 各盘共用同一套宫位（行运落入本命宫位）；否则宫位群星只在同一张盘内统计
+	sharedHouses bool
+}
+
+func newPatternGraph() *patternGraph {
+	return &patternGraph{index: map[string]int{}, edges: map[[2]int]models.AspectData{}}
+}
+
+func patternKey(chart string, planet models.PlanetID) string {
+	return chart + "/" + string(planet)
+}
+
+// addPoints 加入一张盘的点
+func (g *patternGraph) addPoints(chart string, positions []models.PlanetPosition) {
+	for _, p := range positions {
+		key := patternKey(chart, p.ID)
+		if _, ok := g.index[key]; ok {
+			continue
+		}
+		g.index[key] = len(g.points)
+		g.points = append(g.points, patternPoint{chart, p})
+	}
+}
+
+// addAspects 加入相位，Planet1 属于 chart1、Planet2 属于 chart2；端点不在图中的相位忽略
+func (g *patternGraph) addAspects(chart1, chart2 string, aspects []models.AspectData) {
+	for _, a := range aspects {
+		i, ok1 := g.index[patternKey(chart1, a.Planet1)]
+		j, ok2 := g.index[patternKey(chart2, a.Planet2)]
+		if !ok1 || !ok2 || i == j {
+			continue
+		}
+		key := [2]int{min(i, j), max(i, j)}
+		if _, ok := g.edges[key]; !ok {
+			g.edges[key] = a
+		}
+	}
+}
+
+// aspect 两点之间的相位
+func (g *patternGraph) aspect(i, j int) (models.AspectData, bool) {
+	a, ok := g.edges[[2]int{min(i, j), max(i, j)}]
+	return a, ok
+}
+
+// is 两点之间是否成指定相位
+func (g *patternGraph) is(i, j int, t models.AspectType) bool {
+	a, ok := g.aspect(i, j)
+	return ok && a.AspectType == t
+}
+
+// patternCandidate 检测过程中的格局
+type patternCandidate struct {
+	Type    models.PatternType
+	Members []int
+	Apex    int // -1 表示没有顶点
+	Edges   [][2]int
+}
+
+// DetectPatterns 检测同一张盘内的图形相位
+func DetectPatterns(aspects []models.AspectData, planets []models.PlanetPosition) []models.AspectPattern {
+	g := newPatternGraph()
+	g.addPoints("", planets)
+	g.addAspects("", "", aspects)
+	return g.detect()
+}
+
+// DetectTransitPatterns 检测行运与本命共同构成的图形相位（至少各有一个行运点与本命点参与）
+func DetectTransitPatterns(chart *models.NatalChart, transitPositions []models.PlanetPosition) []models.AspectPattern {
+	g := newPatternGraph()
+	g.mixed, g.sharedHouses = true, true
+	g.addPoints(PatternChartNatal, chart.Planets)
+	g.addPoints(PatternChartTransit, AssignHousesToPlanets(transitPositions, chart.Houses))
+	g.addAspects(PatternChartNatal, PatternChartNatal, chart.Aspects)
+	g.addAspects(PatternChartTransit, PatternChartNatal, CalculateTransitToNatalAspects(transitPositions, chart.Planets))
+	g.addAspects(PatternChartTransit, PatternChartTransit, CalculateAspectsFor(AspectContextTransit, transitPositions))
+	return g.detect()
+}
+
+// DetectSynastryPatterns 检测两张本命盘共同构成的图形相位（双方都有点参与）
+func DetectSynastryPatterns(chartA, chartB *models.NatalChart) []models.AspectPattern {
+	g := newPatternGraph()
+	g.mixed = true
+	g.addPoints(PatternChartA, chartA.Planets)
+	g.addPoints(PatternChartB, chartB.Planets)
+	g.addAspects(PatternChartA, PatternChartA, chartA.Aspects)
+	g.addAspects(PatternChartB, PatternChartB, chartB.Aspects)
+	g.addAspects(PatternChartA, PatternChartB, CalculateSynastryAspects(chartA, chartB))
+	return g.detect()
+}
+
+// detect 检测全部格局，去掉被更大格局包含的部分，按强度绝对值排序
+func (g *patternGraph) detect() []models.AspectPattern {
+	var candidates []patternCandidate
+	candidates = append(candidates, g.triangles()...)
+	candidates = append(candidates, g.kites(candidates)...)
+	candidates = append(candidates, g.grandSextiles(candidates)...)
+	candidates = append(candidates, g.quadrilaterals()...)
+
+	seen := map[string]bool{}
+	var unique []patternCandidate
+	for _, c := range candidates {
+		key := candidateKey(c)
+		if seen[key] || (g.mixed && !g.spansCharts(c.Members)) {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, c)
+	}
+
+	var patterns []models.AspectPattern
+	for _, c := range unique {
+		if contained(c, unique) {
+			continue
+		}
+		patterns = append(patterns, g.build(c))
+	}
+	patterns = append(patterns, g.stelliums()...)
+
+	sort.SliceStable(patterns, func(i, j int) bool {
+		return math.Abs(patterns[i].Strength) > math.Abs(patterns[j].Strength)
+	})
+	return patterns
+}
+
+// triangles 三点格局：大三角、T 三角、上帝之指、雷神之锤
+func (g *patternGraph) triangles() []patternCandidate {
+	var found []patternCandidate
+	n := len(g.points)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			for k := j + 1; k < n; k++ {
+				if g.is(i, j, models.Trine) && g.is(j, k, models.Trine) && g.is(i, k, models.Trine) {
+					found = append(found, patternCandidate{models.PatternGrandTrine, []int{i, j, k}, -1, [][2]int{{i, j}, {j, k}, {i, k}}})
+				}
+				// 底边与两条指向顶点的相位
+				for _, tri := range [][3]int{{i, j, k}, {i, k, j}, {j, k, i}} {
+					a, b, apex := tri[0], tri[1], tri[2]
+					edges := [][2]int{{a, b}, {a, apex}, {b, apex}}
+					switch {
+					case g.is(a, b, models.Opposition) && g.is(a, apex, models.Square) && g.is(b, apex, models.Square):
+						found = append(found, patternCandidate{models.PatternTSquare, []int{a, b, apex}, apex, edges})
+					case g.is(a, b, models.Sextile) && g.is(a, apex, models.Quincunx) && g.is(b, apex, models.Quincunx):
+						found = append(found, patternCandidate{models.PatternYod, []int{a, b, apex}, apex, edges})
+					case g.is(a, b, models.Square) && g.is(a, apex, models.Sesquiquadrate) && g.is(b, apex, models.Sesquiquadrate):
+						found = append(found, patternCandidate{models.PatternThorsHammer, []int{a, b, apex}, apex, edges})
+					}
+				}
+			}
+		}
+	}
+	return found
+}
+
+// kites 风筝：大三角的一点被第四点对分，第四点与另两点六分
+func (g *patternGraph) kites(candidates []patternCandidate) []patternCandidate {
+	var found []patternCandidate
+	for _, c := range candidates {
+		if c.Type != models.PatternGrandTrine {
+			continue
+		}
+		for d := range g.points {
+			for x := 0; x < 3; x++ {
+				tail, y, z := c.Members[x], c.Members[(x+1)%3], c.Members[(x+2)%3]
+				if g.is(d, tail, models.Opposition) && g.is(d, y, models.Sextile) && g.is(d, z, models.Sextile) {
+					edges := append(append([][2]int{}, c.Edges...), [2]int{d, tail}, [2]int{d, y}, [2]int{d, z})
+					found = append(found, patternCandidate{models.PatternKite, append(append([]int{}, c.Members...), d), d, edges})
+				}
+			}
+		}
+	}
+	return found
+}
+
+// grandSextiles 大六角：两个大三角的各点两两对分
+func (g *patternGraph) grandSextiles(candidates []patternCandidate) []patternCandidate {
+	var trines []patternCandidate
+	for _, c := range candidates {
+		if c.Type == models.PatternGrandTrine {
+			trines = append(trines, c)
+		}
+	}
+
+	var found []patternCandidate
+	for i := range trines {
+		for j := i + 1; j < len(trines); j++ {
+			t1, t2 := trines[i], trines[j]
+			edges := append(append([][2]int{}, t1.Edges...), t2.Edges...)
+			matched := 0
+			for _, x := range t1.Members {
+				for _, y := range t2.Members {
+					if g.is(x, y, models.Opposition) {
+						edges = append(edges, [2]int{x, y})
+						matched++
+					}
+				}
+			}
+			if matched == 3 {
+				found = append(found, patternCandidate{models.PatternGrandSextile, append(append([]int{}, t1.Members...), t2.Members...), -1, edges})
+			}
+		}
+	}
+	return found
+}
+
+// quadrilaterals 四点格局：大十字、神秘长方形、摇篮
+func (g *patternGraph) quadrilaterals() []patternCandidate {
+	var oppositions [][2]int
+	for key, a := range g.edges {
+		if a.AspectType == models.Opposition {
+			oppositions = append(oppositions, key)
+		}
+	}
+	sort.Slice(oppositions, func(i, j int) bool {
+		if oppositions[i][0] != oppositions[j][0] {
+			return oppositions[i][0] < oppositions[j][0]
+		}
+		return oppositions[i][1] < oppositions[j][1]
+	})
+
+	var found []patternCandidate
+	for x := range oppositions {
+		for y := x + 1; y < len(oppositions); y++ {
+			a, c := oppositions[x][0], oppositions[x][1]
+			b, d := oppositions[y][0], oppositions[y][1]
+			if a == b || a == d || c == b || c == d {
+				continue
+			}
+			members := []int{a, b, c, d}
+			if g.is(a, b, models.Square) && g.is(a, d, models.Square) && g.is(c, b, models.Square) && g.is(c, d, models.Square) {
+				found = append(found, patternCandidate{models.PatternGrandCross, members, -1,
+					[][2]int{{a, c}, {b, d}, {a, b}, {a, d}, {c, b}, {c, d}}})
+			}
+			// 神秘长方形：两组对分，相邻两点六分、另一对相邻两点三分
+			for _, quad := range [][4]int{{a, b, c, d}, {a, d, c, b}} {
+				p, q, r, s := quad[0], quad[1], quad[2], quad[3]
+				if g.is(p, q, models.Sextile) && g.is(r, s, models.Sextile) && g.is(q, r, models.Trine) && g.is(s, p, models.Trine) {
+					found = append(found, patternCandidate{models.PatternMysticRectangle, members, -1,
+						[][2]int{{a, c}, {b, d}, {p, q}, {r, s}, {q, r}, {s, p}}})
+				}
+			}
+		}
+
+		// 摇篮：对分的两端之间有两点，依次六分，且隔一点三分
+		a, d := oppositions[x][0], oppositions[x][1]
+		for b := range g.points {
+			for c := range g.points {
+				if b == c || b == a || b == d || c == a || c == d {
+					continue
+				}
+				if g.is(a, b, models.Sextile) && g.is(b, c, models.Sextile) && g.is(c, d, models.Sextile) &&
+					g.is(a, c, models.Trine) && g.is(b, d, models.Trine) {
+					found = append(found, patternCandidate{models.PatternCradle, []int{a, b, c, d}, -1,
+						[][2]int{{a, d}, {a, b}, {b, c}, {c, d}, {a, c}, {b, d}}})
+				}
+			}
+		}
+	}
+	return found
+}
+
+// stelliums 群星：太阳至冥王星中至少三颗落在同一星座或同一宫位
+func (g *patternGraph) stelliums() []models.AspectPattern {
+	bySign := map[models.ZodiacID][]int{}
+	byHouse := map[string][]int{}
+	var signs []models.ZodiacID
+	var houses []string
+	for i, p := range g.points {
+		if _, ok := MeanDailyMotion[p.Position.ID]; !ok {
+			continue
+		}
+		if len(bySign[p.Position.Sign]) == 0 {
+			signs = append(signs, p.Position.Sign)
+		}
+		bySign[p.Position.Sign] = append(bySign[p.Position.Sign], i)
+
+		if p.Position.House > 0 {
+			key := fmt.Sprintf("%d", p.Position.House)
+			if !g.sharedHouses {
+				key = p.Chart + "/" + key
+			}
+			if len(byHouse[key]) == 0 {
+				houses = append(houses, key)
+			}
+			byHouse[key] = append(byHouse[key], i)
+		}
+	}
+
+	// 同一组行星常常既同星座又同宫位，重叠的群星只保留成员较多的一个（同样多时保留星座群星），
+	// 以免同一批行星被重复计分
+	type group struct {
+		members []int
+		sign    models.ZodiacID
+		house   string
+	}
+	var groups []group
+	for _, sign := range signs {
+		groups = append(groups, group{members: bySign[sign], sign: sign})
+	}
+	for _, key := range houses {
+		groups = append(groups, group{members: byHouse[key], house: key})
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return len(groups[i].members) > len(groups[j].members)
+	})
+
+	var patterns []models.AspectPattern
+	used := map[int]bool{}
+	for _, gr := range groups {
+		if len(gr.members) < stelliumMinimum || (g.mixed && !g.spansCharts(gr.members)) {
+			continue
+		}
+		overlaps := false
+		for _, m := range gr.members {
+			overlaps = overlaps || used[m]
+		}
+		if overlaps {
+			continue
+		}
+		for _, m := range gr.members {
+			used[m] = true
+		}
+
+		pattern := g.stellium(gr.members)
+		if gr.house == "" {
+			pattern.Name = "Stellium in " + GetZodiacInfo(gr.sign).Name
+			pattern.Sign = gr.sign
+		} else {
+			pattern.House = g.points[gr.members[0]].Position.House
+			pattern.Name = fmt.Sprintf("Stellium in House %d", pattern.House)
+			pattern.Element, pattern.Modality = "", ""
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns
+}
+
+// stellium 按黄经跨度计算群星的紧密度（30° 内越集中越紧密）
+func (g *patternGraph) stellium(members []int) models.AspectPattern {
+	lons := make([]float64, len(members))
+	for i, m := range members {
+		lons[i] = g.points[m].Position.Longitude
+	}
+	span := longitudeSpan(lons)
+	tightness := math.Max(0, 1-span/30)
+
+	pattern := models.AspectPattern{
+		Type:      models.PatternStellium,
+		Points:    g.patternPoints(members),
+		Orb:       round2(span),
+		Tightness: round2(tightness),
+		Strength:  round2(PatternValues[models.PatternStellium] * tightness),
+	}
+	pattern.Element, pattern.Modality = g.sharedQualities(members)
+	return pattern
+}
+
+// longitudeSpan 一组黄经所占的最小弧长
+func longitudeSpan(lons []float64) float64 {
+	sorted := append([]float64{}, lons...)
+	for i := range sorted {
+		sorted[i] = NormalizeAngle(sorted[i])
+	}
+	sort.Float64s(sorted)
+	// 最大空隙之外即为最小覆盖弧
+	gap := sorted[0] + 360 - sorted[len(sorted)-1]
+	for i := 1; i < len(sorted); i++ {
+		gap = math.Max(gap, sorted[i]-sorted[i-1])
+	}
+	return 360 - gap
+}
+
+// build 将检测结果整理为图形相位
+func (g *patternGraph) build(c patternCandidate) models.AspectPattern {
+	var orb, tightness float64
+	for _, e := range c.Edges {
+		a, _ := g.aspect(e[0], e[1])
+		orb += a.Orb
+		tightness += a.Strength
+	}
+	orb /= float64(len(c.Edges))
+	tightness /= float64(len(c.Edges))
+
+	pattern := models.AspectPattern{
+		Type:      c.Type,
+		Name:      patternNames[c.Type],
+		Points:    g.patternPoints(c.Members),
+		Orb:       round2(orb),
+		Tightness: round2(tightness),
+		Strength:  round2(PatternValues[c.Type] * tightness),
+	}
+	if c.Apex >= 0 {
+		apex := g.patternPoints([]int{c.Apex})[0]
+		pattern.Apex = &apex
+	}
+	pattern.Element, pattern.Modality = g.sharedQualities(c.Members)
+	return pattern
+}
+
+// sharedQualities 参与者共同的元素与模式
+func (g *patternGraph) sharedQualities(members []int) (element, modality string) {
+	for i, m := range members {
+		info := GetZodiacInfo(g.points[m].Position.Sign)
+		if info == nil {
+			return "", ""
+		}
+		if i == 0 {
+			element, modality = info.Element, info.Modality
+			continue
+		}
+		if info.Element != element {
+			element = ""
+		}
+		if info.Modality != modality {
+			modality = ""
+		}
+	}
+	return element, modality
+}
+
+// patternPoints 参与者列表
+func (g *patternGraph) patternPoints(members []int) []models.PatternPoint {
+	points := make([]models.PatternPoint, len(members))
+	for i, m := range members {
+		points[i] = models.PatternPoint{Planet: g.points[m].Position.ID, Chart: g.points[m].Chart}
+	}
+	return points
+}
+
+// spansCharts 参与者是否来自不止一张盘
+func (g *patternGraph) spansCharts(members []int) bool {
+	for _, m := range members[1:] {
+		if g.points[m].Chart != g.points[members[0]].Chart {
+			return true
+		}
+	}
+	return false
+}
+
+// candidateKey 格局类型与参与者集合
+func candidateKey(c patternCandidate) string {
+	members := append([]int{}, c.Members...)
+	sort.Ints(members)
+	return fmt.Sprintf("%s%v", c.Type, members)
+}
+
+// contained 格局是否被更大的格局包含
+func contained(c patternCandidate, all []patternCandidate) bool {
+	for _, container := range patternContainers[c.Type] {
+		for _, other := range all {
+			if other.Type == container && isSubset(c.Members, other.Members) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isSubset a 的成员是否都在 b 中
+func isSubset(a, b []int) bool {
+	for _, x := range a {
+		found := false
+		for _, y := range b {
+			if x == y {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
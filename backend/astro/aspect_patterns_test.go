@@ -0,0 +1,134 @@
+package astro
+
+import (
+	"star/models"
+	"testing"
+)
+
+// findPattern 查找指定类型的图形相位
+func findPattern(patterns []models.AspectPattern, t models.PatternType) *models.AspectPattern {
+	for i := range patterns {
+		if patterns[i].Type == t {
+			return &patterns[i]
+		}
+	}
+	return nil
+}
+
+// TestDetectPatterns 测试本命盘的图形相位：参与者、顶点、元素/模式、紧密度与包含关系
+func TestDetectPatterns(t *testing.T) {
+	detect := func(lons map[models.PlanetID]float64) []models.AspectPattern {
+		var planets []models.PlanetPosition
+		for _, id := range []models.PlanetID{models.Sun, models.Moon, models.Mercury, models.Venus, models.Mars, models.Jupiter, models.Saturn} {
+			if lon, ok := lons[id]; ok {
+				planets = append(planets, newPlanetPosition(id, lon, 0, false))
+			}
+		}
+		return DetectPatterns(CalculateAspects(planets), planets)
+	}
+
+	// 火象大三角加上对分太阳的金星：只报告风筝
+	patterns := detect(map[models.PlanetID]float64{models.Sun: 5, models.Moon: 125, models.Mars: 245, models.Venus: 185})
+	kite := findPattern(patterns, models.PatternKite)
+	if kite == nil || kite.Apex == nil || kite.Apex.Planet != models.Venus || len(kite.Points) != 4 {
+		t.Fatalf("应检测到以金星为头部的风筝: %+v", patterns)
+	}
+	if kite.Tightness != 1 || kite.Strength != PatternValues[models.PatternKite] {
+		t.Errorf("精确的风筝紧密度应为 1: %+v", kite)
+	}
+	if findPattern(patterns, models.PatternGrandTrine) != nil {
+		t.Error("风筝内的大三角不应单独报告")
+	}
+	if trine := findPattern(detect(map[models.PlanetID]float64{models.Sun: 5, models.Moon: 125, models.Mars: 247}), models.PatternGrandTrine); trine == nil || trine.Element != "fire" || trine.Tightness >= 1 {
+		t.Errorf("应检测到火象大三角且容许度降低紧密度: %+v", trine)
+	}
+
+	// 基本宫 T 三角，顶点为火星；加上对分火星的土星成为大十字
+	patterns = detect(map[models.PlanetID]float64{models.Sun: 10, models.Moon: 190, models.Mars: 100})
+	tSquare := findPattern(patterns, models.PatternTSquare)
+	if tSquare == nil || tSquare.Apex.Planet != models.Mars || tSquare.Modality != "cardinal" || tSquare.Strength >= 0 {
+		t.Errorf("应检测到以火星为顶点的基本宫 T 三角: %+v", patterns)
+	}
+	patterns = detect(map[models.PlanetID]float64{models.Sun: 10, models.Moon: 190, models.Mars: 100, models.Saturn: 280})
+	if findPattern(patterns, models.PatternGrandCross) == nil || findPattern(patterns, models.PatternTSquare) != nil {
+		t.Errorf("大十字应取代其中的 T 三角: %+v", patterns)
+	}
+
+	// 上帝之指：六分的太阳与月亮同以十五十分相指向土星
+	if yod := findPattern(detect(map[models.PlanetID]float64{models.Sun: 0, models.Moon: 60, models.Saturn: 210}), models.PatternYod); yod == nil || yod.Apex.Planet != models.Saturn {
+		t.Errorf("应检测到以土星为顶点的上帝之指: %+v", yod)
+	}
+
+	// 狮子座群星：黄经跨度 9°
+	patterns = detect(map[models.PlanetID]float64{models.Sun: 121, models.Mercury: 125, models.Venus: 130})
+	stellium := findPattern(patterns, models.PatternStellium)
+	if stellium == nil || stellium.Sign != models.Leo || stellium.Name != "Stellium in Leo" || stellium.Orb != 9 || stellium.Tightness != 0.7 {
+		t.Errorf("应检测到狮子座群星: %+v", patterns)
+	}
+}
+
+// TestDetectSynastryPatterns 测试合盘图形相位只保留双方共同参与的格局
+func TestDetectSynastryPatterns(t *testing.T) {
+	chart := func(lons map[models.PlanetID]float64) *models.NatalChart {
+		var planets []models.PlanetPosition
+		for _, id := range []models.PlanetID{models.Sun, models.Moon, models.Jupiter} {
+			if lon, ok := lons[id]; ok {
+				planets = append(planets, newPlanetPosition(id, lon, 0, false))
+			}
+		}
+		return &models.NatalChart{Planets: planets, Aspects: CalculateAspects(planets)}
+	}
+	// A 自身有大三角，B 的太阳与 A 的太阳、月亮构成另一个大三角
+	a := chart(map[models.PlanetID]float64{models.Sun: 0, models.Moon: 120, models.Jupiter: 240})
+	b := chart(map[models.PlanetID]float64{models.Sun: 241})
+
+	var trines []models.AspectPattern
+	for _, p := range DetectSynastryPatterns(a, b) {
+		if p.Type == models.PatternGrandTrine {
+			trines = append(trines, p)
+		}
+	}
+	if len(trines) != 1 {
+		t.Fatalf("合盘应只报告跨盘的大三角: %+v", trines)
+	}
+	charts := map[string]int{}
+	for _, point := range trines[0].Points {
+		charts[point.Chart]++
+	}
+	if charts[PatternChartA] != 2 || charts[PatternChartB] != 1 {
+		t.Errorf("大三角应由 A 的两颗行星与 B 的太阳构成: %+v", trines[0].Points)
+	}
+}
+
+// TestStelliumOverlap 测试同一组行星同时构成星座群星与宫位群星时只报告一个
+func TestStelliumOverlap(t *testing.T) {
+	detect := func(lons map[models.PlanetID]float64, house int) []models.AspectPattern {
+		var planets []models.PlanetPosition
+		for _, id := range []models.PlanetID{models.Sun, models.Mercury, models.Venus, models.Mars} {
+			if lon, ok := lons[id]; ok {
+				p := newPlanetPosition(id, lon, 0, false)
+				p.House = house
+				planets = append(planets, p)
+			}
+		}
+		var stelliums []models.AspectPattern
+		for _, p := range DetectPatterns(CalculateAspects(planets), planets) {
+			if p.Type == models.PatternStellium {
+				stelliums = append(stelliums, p)
+			}
+		}
+		return stelliums
+	}
+
+	// 三颗行星同在狮子座与第 5 宫：只报告星座群星
+	stelliums := detect(map[models.PlanetID]float64{models.Sun: 121, models.Mercury: 125, models.Venus: 130}, 5)
+	if len(stelliums) != 1 || stelliums[0].Sign != models.Leo || stelliums[0].House != 0 {
+		t.Errorf("同星座同宫位的群星应只报告一次: %+v", stelliums)
+	}
+
+	// 第四颗行星落入处女座但仍在第 5 宫：宫位群星成员更多，取代星座群星
+	stelliums = detect(map[models.PlanetID]float64{models.Sun: 121, models.Mercury: 125, models.Venus: 130, models.Mars: 151}, 5)
+	if len(stelliums) != 1 || stelliums[0].House != 5 || len(stelliums[0].Points) != 4 {
+		t.Errorf("成员更多的宫位群星应取代重叠的星座群星: %+v", stelliums)
+	}
+}
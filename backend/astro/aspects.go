@@ -6,51 +6,32 @@ import (
 	"star/models"
 )
 
-// CalculateAspects 计算行星之间的相位
+// CalculateAspects 计算本命盘行星之间的相位
 func CalculateAspects(planets []models.PlanetPosition) []models.AspectData {
+	return CalculateAspectsFor(AspectContextNatal, planets)
+}
+
+// CalculateAspectsFor 按情境（本命/推运）的相位目录计算同一组行星之间的相位
+func CalculateAspectsFor(ctx AspectContext, planets []models.PlanetPosition) []models.AspectData {
 	var aspects []models.AspectData
 
+	catalogue := CurrentAspectCatalogue()
+	defs := catalogue.Definitions(ctx)
 	for i := 0; i < len(planets); i++ {
 		for j := i + 1; j < len(planets); j++ {
 			p1, p2 := planets[i], planets[j]
-
-			// 计算角距
-			diff := math.Abs(p1.Longitude - p2.Longitude)
-			if diff > 180 {
-				diff = 360 - diff
+			if samePointPair(p1.ID, p2.ID) {
+				continue
 			}
 
 			// 检查每个相位类型
-			for _, def := range AspectDefinitions {
-				orb := math.Abs(diff - def.Angle)
-				if orb <= def.Orb {
-					// 计算强度 (0-1)
-					strength := 1.0 - orb/def.Orb
-
-					// 计算权重
-					p1Weight := PlanetWeights[p1.ID]
-					p2Weight := PlanetWeights[p2.ID]
-					weight := strength * def.Weight * (p1Weight + p2Weight) / 20.0
-
-					// 判断是否入相
-					applying := isApplying(p1, p2, def.Angle)
-
-					// 生成解读
-					interpretation := generateAspectInterpretation(p1, p2, def)
-
-					aspects = append(aspects, models.AspectData{
-						Planet1:        p1.ID,
-						Planet2:        p2.ID,
-						AspectType:     def.Type,
-						ExactAngle:     def.Angle,
-						ActualAngle:    diff,
-						Orb:            orb,
-						Applying:       applying,
-						Strength:       strength,
-						Weight:         weight,
-						Interpretation: interpretation,
-					})
+			for _, def := range defs {
+				aspect, ok := buildAspect(p1, p2, def, catalogue.Orb(def, p1.ID, p2.ID, ctx))
+				if !ok {
+					continue
 				}
+				aspect.Interpretation = generateAspectInterpretation(p1, p2, def)
+				aspects = append(aspects, aspect)
 			}
 		}
 	}
@@ -62,43 +43,14 @@ func CalculateAspects(planets []models.PlanetPosition) []models.AspectData {
 func CalculateTransitToNatalAspects(transitPositions, natalPositions []models.PlanetPosition) []models.AspectData {
 	var aspects []models.AspectData
 
-	// 行运容许度收紧到 80%
-	orbFactor := 0.8
-
+	catalogue := CurrentAspectCatalogue()
+	defs := catalogue.Definitions(AspectContextTransit)
 	for _, transit := range transitPositions {
 		for _, natal := range natalPositions {
-			// 计算角距
-			diff := math.Abs(transit.Longitude - natal.Longitude)
-			if diff > 180 {
-				diff = 360 - diff
-			}
-
-			for _, def := range AspectDefinitions {
-				adjustedOrb := def.Orb * orbFactor
-				orb := math.Abs(diff - def.Angle)
-
-				if orb <= adjustedOrb {
-					strength := 1.0 - orb/adjustedOrb
-
-					p1Weight := PlanetWeights[transit.ID]
-					p2Weight := PlanetWeights[natal.ID]
-					weight := strength * def.Weight * (p1Weight + p2Weight) / 20.0
-
-				interpretation := fmt.Sprintf("Transit %s forms %s with natal %s",
-					transit.Name, def.Name, natal.Name)
-
-					aspects = append(aspects, models.AspectData{
-						Planet1:        transit.ID,
-						Planet2:        natal.ID,
-						AspectType:     def.Type,
-						ExactAngle:     def.Angle,
-						ActualAngle:    diff,
-						Orb:            orb,
-						Applying:       true, // 简化处理
-						Strength:       strength,
-						Weight:         weight,
-						Interpretation: interpretation,
-					})
+			for _, def := range defs {
+				orb := catalogue.Orb(def, transit.ID, natal.ID, AspectContextTransit)
+				if aspect, ok := newTransitAspect(transit, natal, def, orb); ok {
+					aspects = append(aspects, aspect)
 				}
 			}
 		}
@@ -107,147 +59,149 @@ func CalculateTransitToNatalAspects(transitPositions, natalPositions []models.Pl
 	return aspects
 }
 
-// isApplying 判断相位是否入相（接近中）
-func isApplying(p1, p2 models.PlanetPosition, _ float64) bool {
-	// 简化判断：根据逆行状态
-	// 如果两颗行星都不逆行，且p1速度快，则入相
-	// 这里使用简化逻辑
-	if p1.Retrograde || p2.Retrograde {
-		return false
-	}
-	return true
-}
-
-// generateAspectInterpretation 生成相位解读
-func generateAspectInterpretation(p1, p2 models.PlanetPosition, def AspectDefinition) string {
-	p1Info := GetPlanetInfo(p1.ID)
-	p2Info := GetPlanetInfo(p2.ID)
+// CalculateSynastryAspects 计算两张本命盘之间的合盘相位（双方位置均视为静止）
+func CalculateSynastryAspects(chartA, chartB *models.NatalChart) []models.AspectData {
+	var aspects []models.AspectData
 
-	if p1Info == nil || p2Info == nil {
-		return ""
+	catalogue := CurrentAspectCatalogue()
+	defs := catalogue.Definitions(AspectContextSynastry)
+	for _, p1 := range chartA.Planets {
+		for _, p2 := range chartB.Planets {
+			p1.Speed, p2.Speed = 0, 0
+			for _, def := range defs {
+				aspect, ok := buildAspect(p1, p2, def, catalogue.Orb(def, p1.ID, p2.ID, AspectContextSynastry))
+				if !ok {
+					continue
+				}
+				aspect.Interpretation = generateAspectInterpretation(p1, p2, def)
+				aspects = append(aspects, aspect)
+			}
+		}
 	}
 
-	return fmt.Sprintf("%s forms %s with %s", p1Info.Name, def.Name, p2Info.Name)
+	return aspects
 }
 
-// DetectPatterns 检测图形相位
-func DetectPatterns(aspects []models.AspectData, planets []models.PlanetPosition) []string {
-	var patterns []string
+// newTransitAspect 在行运容许度内构造行运与本命的相位
+func newTransitAspect(transit, natal models.PlanetPosition, def AspectDefinition, allowedOrb float64) (models.AspectData, bool) {
+	// 本命位置固定不动，只有行运天体在运动
+	natal.Speed = 0
 
-	// 检测大三角 (三个三分相)
-	if hasGrandTrine(aspects) {
-		patterns = append(patterns, "Grand Trine")
+	aspect, ok := buildAspect(transit, natal, def, allowedOrb)
+	if !ok {
+		return aspect, false
 	}
+	aspect.Interpretation = fmt.Sprintf("Transit %s forms %s with natal %s",
+		transit.Name, def.Name, natal.Name)
+	return aspect, true
+}
 
-	// 检测T三角 (两个四分相和一个对分相)
-	if hasTSquare(aspects) {
-		patterns = append(patterns, "T-Square")
-	}
+// buildAspect 两颗天体的角距在容许度 allowedOrb 内时构造相位
+func buildAspect(p1, p2 models.PlanetPosition, def AspectDefinition, allowedOrb float64) (models.AspectData, bool) {
+	// 计算角距
+	diff := math.Abs(p1.Longitude - p2.Longitude)
+	if diff > 180 {
+		diff = 360 - diff
+	}
+
+	orb := math.Abs(diff - def.Angle)
+	if allowedOrb <= 0 || orb > allowedOrb {
+		return models.AspectData{}, false
+	}
+
+	// 计算强度 (0-1)
+	strength := 1.0 - orb/allowedOrb
+
+	// 计算权重
+	p1Weight := bodyWeight(p1.ID)
+	p2Weight := bodyWeight(p2.ID)
+	weight := strength * def.Weight * (p1Weight + p2Weight) / 20.0
+
+	// 由相对运动判断入相/离相
+	applying, toExact := aspectMotion(p1.Longitude, p1.Speed, p2.Longitude, p2.Speed, def.Angle)
+
+	return models.AspectData{
+		Planet1:     p1.ID,
+		Planet2:     p2.ID,
+		AspectType:  def.Type,
+		ExactAngle:  def.Angle,
+		ActualAngle: diff,
+		Orb:         orb,
+		AllowedOrb:  allowedOrb,
+		Applying:    applying,
+		TimeToExact: toExact,
+		Strength:    strength,
+		Weight:      weight,
+	}, true
+}
 
-	// 检测大十字 (四个四分相和两个对分相)
-	if hasGrandCross(aspects) {
-		patterns = append(patterns, "Grand Cross")
-	}
+// aspectMotion 由两颗天体的黄经速度判断相位入相/离相，并按当前速度线性估算
+// 距精确成相的小时数（入相为正、离相为负；相对静止时为 0）
+func aspectMotion(lon1, speed1, lon2, speed2, angle float64) (applying bool, hoursToExact float64) {
+	separation := normalizeSigned(lon1 - lon2)
+	deviation := math.Abs(separation) - angle
 
-	// 检测风筝
-	if hasKite(aspects) {
-		patterns = append(patterns, "Kite")
+	// 角距 |separation| 的变化率
+	rate := speed1 - speed2
+	if separation < 0 {
+		rate = -rate
 	}
-
-	return patterns
-}
-
-// hasGrandTrine 检测大三角
-func hasGrandTrine(aspects []models.AspectData) bool {
-	trines := filterAspectsByType(aspects, models.Trine)
-	if len(trines) < 3 {
-		return false
+	// 偏离精确角度的量 |deviation| 的变化率
+	if deviation < 0 {
+		rate = -rate
 	}
-
-	// 简化检测：检查是否有三颗行星互成三分相
-	planetTrines := make(map[models.PlanetID]int)
-	for _, a := range trines {
-		planetTrines[a.Planet1]++
-		planetTrines[a.Planet2]++
+	if math.Abs(rate) < 1e-9 {
+		return false, 0
 	}
 
-	count := 0
-	for _, v := range planetTrines {
-		if v >= 2 {
-			count++
-		}
+	hours := math.Abs(deviation) / math.Abs(rate) * 24
+	if rate < 0 {
+		return true, hours
 	}
-	return count >= 3
+	return false, -hours
 }
 
-// hasTSquare 检测T三角
-func hasTSquare(aspects []models.AspectData) bool {
-	squares := filterAspectsByType(aspects, models.Square)
-	oppositions := filterAspectsByType(aspects, models.Opposition)
-
-	if len(squares) < 2 || len(oppositions) < 1 {
-		return false
+// describeAspectTiming 描述相位阶段与距精确成相的时间
+func describeAspectTiming(aspect models.AspectData) string {
+	hours := math.Abs(aspect.TimeToExact)
+	span := fmt.Sprintf("%.0fh", hours)
+	if hours >= 48 {
+		span = fmt.Sprintf("%.1f days", hours/24)
 	}
-
-	// 检查是否有一颗行星同时与对分相的两颗行星成四分相
-	for _, opp := range oppositions {
-		for _, sq1 := range squares {
-			for _, sq2 := range squares {
-				if sq1.Planet1 == sq2.Planet1 && sq1.Planet1 != opp.Planet1 && sq1.Planet1 != opp.Planet2 {
-					if (sq1.Planet2 == opp.Planet1 || sq1.Planet2 == opp.Planet2) &&
-						(sq2.Planet2 == opp.Planet1 || sq2.Planet2 == opp.Planet2) {
-						return true
-					}
-				}
-			}
-		}
+	if aspect.Applying {
+		return "applying, exact in " + span
 	}
-	return false
+	return "separating, exact " + span + " ago"
 }
 
-// hasGrandCross 检测大十字
-func hasGrandCross(aspects []models.AspectData) bool {
-	squares := filterAspectsByType(aspects, models.Square)
-	oppositions := filterAspectsByType(aspects, models.Opposition)
-
-	return len(squares) >= 4 && len(oppositions) >= 2
-}
+// generateAspectInterpretation 生成相位解读
+func generateAspectInterpretation(p1, p2 models.PlanetPosition, def AspectDefinition) string {
+	p1Info := GetPlanetInfo(p1.ID)
+	p2Info := GetPlanetInfo(p2.ID)
 
-// hasKite 检测风筝
-func hasKite(aspects []models.AspectData) bool {
-	// 风筝 = 大三角 + 一颗行星与三角顶点对分相并与另两点六分相
-	if !hasGrandTrine(aspects) {
-		return false
+	if p1Info == nil || p2Info == nil {
+		return ""
 	}
 
-	oppositions := filterAspectsByType(aspects, models.Opposition)
-	sextiles := filterAspectsByType(aspects, models.Sextile)
-
-	return len(oppositions) >= 1 && len(sextiles) >= 2
-}
-
-// filterAspectsByType 按类型过滤相位
-func filterAspectsByType(aspects []models.AspectData, aspectType models.AspectType) []models.AspectData {
-	var result []models.AspectData
-	for _, a := range aspects {
-		if a.AspectType == aspectType {
-			result = append(result, a)
-		}
-	}
-	return result
+	return fmt.Sprintf("%s forms %s with %s", p1Info.Name, def.Name, p2Info.Name)
 }
 
 // CalculateTransitScore 计算行运分数
 func CalculateTransitScore(aspects []models.AspectData) models.TransitScore {
 	var harmonious, tense float64
 
+	catalogue := CurrentAspectCatalogue()
 	for _, a := range aspects {
-		switch a.AspectType {
-		case models.Trine, models.Sextile:
+		def := catalogue.Definition(a.AspectType)
+		if def == nil {
+			continue
+		}
+		switch def.Nature {
+		case "harmonious":
 			harmonious += a.Weight
-		case models.Square, models.Opposition:
+		case "tense":
 			tense += a.Weight
-		case models.Conjunction:
+		case "neutral":
 			// 合相根据行星性质判断
 			if isBenefic(a.Planet1) || isBenefic(a.Planet2) {
 				harmonious += a.Weight * 0.5
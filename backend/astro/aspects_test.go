@@ -0,0 +1,81 @@
+package astro
+
+import (
+	"errors"
+	"math"
+	"star/models"
+	"testing"
+)
+
+// TestAspectMotion 测试由相对速度判断入相/离相与距精确成相时间
+func TestAspectMotion(t *testing.T) {
+	tests := []struct {
+		name         string
+		lon1, speed1 float64
+		lon2, speed2 float64
+		angle        float64
+		applying     bool
+		hoursToExact float64
+	}{
+		{"快星追上慢星的合相", 10, 1, 12, 0, 0, true, 48},
+		{"快星越过慢星后的合相", 14, 1, 12, 0, 0, false, -48},
+		{"逆行退回合相", 14, -0.5, 12, 0, 0, true, 96},
+		{"四分相角距扩大到 90°", 100, 1, 12, 0, 90, true, 48},
+		{"另一侧的四分相角距收窄", 284, 1, 12, 0, 90, false, -48},
+		{"对分相角距扩大到 180°", 190, 1, 12, 0.5, 180, true, 96},
+		{"跨越 0° 白羊点的三分相", 358, 1, 240, 0, 120, true, 48},
+		{"双方静止", 10, 0, 12, 0, 0, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applying, hours := aspectMotion(tt.lon1, tt.speed1, tt.lon2, tt.speed2, tt.angle)
+			if applying != tt.applying {
+				t.Errorf("入相判断错误: 期望 %v, 实际 %v", tt.applying, applying)
+			}
+			if math.Abs(hours-tt.hoursToExact) > 1e-6 {
+				t.Errorf("距精确成相时间错误: 期望 %.2fh, 实际 %.2fh", tt.hoursToExact, hours)
+			}
+		})
+	}
+}
+
+// TestPlanetMotionFields 测试行星位置的速度、赤纬与距离（缓存插值与直接计算一致）
+func TestPlanetMotionFields(t *testing.T) {
+	provider := NewPreciseProvider()
+	useProvider(t, provider)
+	jd := 2460411.25 // 2024-04-12 18:00 UT，水星逆行中
+
+	for _, planet := range DefaultBodies {
+		direct, err := provider.PlanetPosition(planet, jd)
+		if errors.Is(err, ErrBodyNotProvided) {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s 计算失败: %v", planet, err)
+		}
+		cached := CalculatePlanetPositionUnified(planet, jd)
+
+		if math.Abs(direct.Speed-cached.Speed) > 1e-4 {
+			t.Errorf("%s 速度不一致: 直接 %.6f, 缓存 %.6f", planet, direct.Speed, cached.Speed)
+		}
+		if math.Abs(direct.Distance-cached.Distance) > 1e-7 {
+			t.Errorf("%s 距离不一致: 直接 %.8f, 缓存 %.8f", planet, direct.Distance, cached.Distance)
+		}
+		if math.Abs(direct.Declination-cached.Declination) > 1e-5 {
+			t.Errorf("%s 赤纬不一致: 直接 %.6f, 缓存 %.6f", planet, direct.Declination, cached.Declination)
+		}
+		if cached.Retrograde != (cached.Speed < 0) {
+			t.Errorf("%s 逆行标志应与速度符号一致", planet)
+		}
+	}
+
+	mercury := CalculatePlanetPositionUnified(models.Mercury, jd)
+	if !mercury.Retrograde || mercury.Speed > -0.1 {
+		t.Errorf("水星此时应逆行，速度 %.4f°/天", mercury.Speed)
+	}
+	sun := CalculatePlanetPositionUnified(models.Sun, jd)
+	if math.Abs(sun.Distance-1.0021) > 0.001 || math.Abs(sun.Speed-0.982) > 0.005 {
+		t.Errorf("太阳距离 %.4f AU / 速度 %.4f°/天 超出预期", sun.Distance, sun.Speed)
+	}
+}
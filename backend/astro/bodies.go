@@ -0,0 +1,123 @@
+package astro
+
+import (
+	"fmt"
+	"star/models"
+	"strings"
+)
+
+// ==================== 天体选择 ====================
+// 星盘默认包含十大行星、北交点与凯龙星（DefaultBodies）；南交点、四颗主要小行星、
+// 黑月莉莉丝与阋神星按请求加入，轻量客户端也可只选择部分天体。
+// 月亮交点可选真交点或平交点，南交点始终为所选北交点的对冲点
+
+// ExtendedBodies 可按请求加入星盘的扩展天体
+var ExtendedBodies = []models.PlanetID{
+	models.SouthNode, models.Ceres, models.Pallas, models.Juno, models.Vesta,
+	models.Lilith, models.OscLilith, models.Eris,
+}
+
+// SelectableBodies 请求中可选择的全部天体（默认天体在前）
+func SelectableBodies() []models.PlanetID {
+	bodies := make([]models.PlanetID, 0, len(DefaultBodies)+len(ExtendedBodies))
+	bodies = append(bodies, DefaultBodies...)
+	return append(bodies, ExtendedBodies...)
+}
+
+// ParseNodeType 解析月亮交点类型，空值返回真交点
+func ParseNodeType(value models.NodeType) (models.NodeType, error) {
+	switch strings.ToLower(strings.TrimSpace(string(value))) {
+	case "", string(models.NodeTrue):
+		return models.NodeTrue, nil
+	case string(models.NodeMean):
+		return models.NodeMean, nil
+	}
+	return "", fmt.Errorf("不支持的月亮交点类型: %s", value)
+}
+
+// ParseBodies 解析天体选择，空列表返回 DefaultBodies；结果去重并按 SelectableBodies 的顺序排列
+func ParseBodies(bodies []models.PlanetID) ([]models.PlanetID, error) {
+	if len(bodies) == 0 {
+		return DefaultBodies, nil
+	}
+
+	selectable := SelectableBodies()
+	wanted := make(map[models.PlanetID]bool, len(bodies))
+	for _, body := range bodies {
+		found := false
+		for _, id := range selectable {
+			if strings.EqualFold(strings.TrimSpace(string(body)), string(id)) {
+				wanted[id] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("不支持的天体: %s", body)
+		}
+	}
+
+	result := make([]models.PlanetID, 0, len(wanted))
+	for _, id := range selectable {
+		if wanted[id] {
+			result = append(result, id)
+		}
+	}
+	return result, nil
+}
+
+// ResolveBodies 解析出生数据中的天体选择与交点类型，无法识别时回退到默认值
+func ResolveBodies(birthData models.BirthData) ([]models.PlanetID, models.NodeType) {
+	bodies, err := ParseBodies(birthData.Bodies)
+	if err != nil {
+		bodies = DefaultBodies
+	}
+	nodeType, err := ParseNodeType(birthData.NodeType)
+	if err != nil {
+		nodeType = models.NodeTrue
+	}
+	return bodies, nodeType
+}
+
+// GetBodyPositionsUnified 获取指定天体的位置，北交点与南交点按 nodeType 取真交点或平交点
+func GetBodyPositionsUnified(jd float64, bodies []models.PlanetID, nodeType models.NodeType) []models.PlanetPosition {
+	provider := CurrentEphemerisProvider()
+	positions := make([]models.PlanetPosition, 0, len(bodies))
+	for _, body := range bodies {
+		positions = append(positions, bodyPositionFrom(provider, body, jd, nodeType))
+	}
+	return positions
+}
+
+// bodyPositionFrom 按交点类型获取天体位置；平交点以北交点的身份出现在星盘中
+func bodyPositionFrom(provider EphemerisProvider, body models.PlanetID, jd float64, nodeType models.NodeType) models.PlanetPosition {
+	if nodeType == models.NodeMean {
+		switch body {
+		case models.NorthNode:
+			node := planetPositionFrom(provider, models.MeanNode, jd)
+			info := GetPlanetInfo(models.NorthNode)
+			node.ID, node.Name, node.Symbol = models.NorthNode, info.Name, info.Symbol
+			return node
+		case models.SouthNode:
+			return southNodeOf(planetPositionFrom(provider, models.MeanNode, jd), jd)
+		}
+	}
+	return planetPositionFrom(provider, body, jd)
+}
+
+// southNodeOf 南交点：北交点的对冲点
+func southNodeOf(node models.PlanetPosition, jd float64) models.PlanetPosition {
+	return newMovingPosition(models.SouthNode, jd, NormalizeAngle(node.Longitude+180), -node.Latitude, planetMotion{
+		Distance:      node.Distance,
+		Speed:         node.Speed,
+		LatitudeSpeed: -node.LatitudeSpeed,
+	})
+}
+
+// samePointPair 同一轴线的两端或同一点的不同算法（南北交点、平/密切莉莉丝），彼此之间不计相位
+func samePointPair(a, b models.PlanetID) bool {
+	pair := func(x, y models.PlanetID) bool {
+		return (a == x && b == y) || (a == y && b == x)
+	}
+	return pair(models.NorthNode, models.SouthNode) || pair(models.Lilith, models.OscLilith)
+}
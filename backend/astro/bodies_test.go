@@ -0,0 +1,114 @@
+package astro
+
+import (
+	"math"
+	"star/models"
+	"testing"
+	"time"
+)
+
+// TestExtendedBodyPositions 测试小行星、阋神星、黑月莉莉丝与交点的位置
+func TestExtendedBodyPositions(t *testing.T) {
+	useProvider(t, NewPreciseProvider())
+
+	// 小行星冲日时与太阳相差 180°
+	oppositions := []struct {
+		body models.PlanetID
+		date time.Time
+	}{
+		{models.Ceres, time.Date(2023, 3, 21, 0, 0, 0, 0, time.UTC)},
+		{models.Vesta, time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC)},
+		{models.Juno, time.Date(2018, 11, 17, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, o := range oppositions {
+		jd := DateToJulianDay(o.date)
+		body := CalculatePlanetPositionUnified(o.body, jd)
+		sun := CalculatePlanetPositionUnified(models.Sun, jd)
+		diff := normalizeSigned(body.Longitude - sun.Longitude - 180)
+		t.Logf("%s %s 冲日: 黄经 %.2f°，偏差 %.2f°", body.Name, o.date.Format("2006-01-02"), body.Longitude, diff)
+		if math.Abs(diff) > 2 {
+			t.Errorf("%s 冲日黄经偏差过大: %.2f°", o.body, diff)
+		}
+	}
+
+	// 阋神星 2024 年位于白羊座 24° 附近
+	eris := CalculatePlanetPositionUnified(models.Eris, DateToJulianDay(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	if eris.Sign != models.Aries || eris.SignDegree < 23 || eris.SignDegree > 26 {
+		t.Errorf("阋神星应位于白羊座 24° 附近: %s %.2f°", eris.Sign, eris.SignDegree)
+	}
+
+	// J2000 平交点 125.04°，平远地点 263.35°（含章动）
+	jd := J2000
+	meanNode := CalculatePlanetPositionUnified(models.MeanNode, jd)
+	lilith := CalculatePlanetPositionUnified(models.Lilith, jd)
+	oscLilith := CalculatePlanetPositionUnified(models.OscLilith, jd)
+	t.Logf("J2000 平交点 %.3f°，平莉莉丝 %.3f°，密切莉莉丝 %.3f°", meanNode.Longitude, lilith.Longitude, oscLilith.Longitude)
+	if math.Abs(meanNode.Longitude-125.04) > 0.05 {
+		t.Errorf("J2000 平交点应为 125.04°，实际 %.3f°", meanNode.Longitude)
+	}
+	if math.Abs(lilith.Longitude-263.4) > 0.2 || lilith.Retrograde {
+		t.Errorf("J2000 平莉莉丝应约为 263.4° 且顺行，实际 %.3f°", lilith.Longitude)
+	}
+	if AngleDifference(lilith.Longitude, oscLilith.Longitude) > 35 {
+		t.Errorf("密切莉莉丝与平莉莉丝相差过大: %.2f°", AngleDifference(lilith.Longitude, oscLilith.Longitude))
+	}
+
+	// 南交点为北交点的对冲点
+	north := CalculatePlanetPositionUnified(models.NorthNode, jd)
+	south := CalculatePlanetPositionUnified(models.SouthNode, jd)
+	if AngleDifference(north.Longitude+180, south.Longitude) > 1e-9 || south.Name != "South Node" {
+		t.Errorf("南交点应与北交点对冲: 北 %.3f°，南 %+v", north.Longitude, south)
+	}
+}
+
+// TestBodySelection 测试按请求选择天体与交点类型
+func TestBodySelection(t *testing.T) {
+	useProvider(t, NewPreciseProvider())
+
+	birthData := models.BirthData{
+		Year: 1990, Month: 6, Day: 15, Hour: 14, Minute: 30,
+		Latitude: 39.9042, Longitude: 116.4074, Timezone: 8,
+	}
+
+	chart := CalculateNatalChart(birthData)
+	if len(chart.Planets) != len(DefaultBodies) || chart.BirthData.NodeType != models.NodeTrue {
+		t.Errorf("默认星盘应包含 %d 个天体并使用真交点，实际 %d / %s", len(DefaultBodies), len(chart.Planets), chart.BirthData.NodeType)
+	}
+
+	birthData.NodeType = models.NodeMean
+	birthData.Bodies = []models.PlanetID{"ceres", "Sun", "southNode", "northNode", "lilith", "sun"}
+	chart = CalculateNatalChart(birthData)
+
+	want := []models.PlanetID{models.Sun, models.NorthNode, models.SouthNode, models.Ceres, models.Lilith}
+	if len(chart.Planets) != len(want) {
+		t.Fatalf("应只包含所选天体 %v，实际 %d 个", want, len(chart.Planets))
+	}
+	for i, id := range want {
+		if chart.Planets[i].ID != id {
+			t.Errorf("第 %d 个天体应为 %s，实际 %s", i+1, id, chart.Planets[i].ID)
+		}
+	}
+
+	// 平交点以北交点身份出现，南交点与之对冲
+	jd := DateToJulianDay(birthData.ToTime())
+	meanNode := CalculatePlanetPositionUnified(models.MeanNode, jd)
+	north, south := GetPlanetFromChart(chart, models.NorthNode), GetPlanetFromChart(chart, models.SouthNode)
+	if AngleDifference(north.Longitude, meanNode.Longitude) > 1e-9 || north.Name != "North Node" {
+		t.Errorf("北交点应取平交点 %.3f°: %+v", meanNode.Longitude, north)
+	}
+	if AngleDifference(north.Longitude+180, south.Longitude) > 1e-9 {
+		t.Errorf("南交点应与北交点对冲: %.3f° / %.3f°", north.Longitude, south.Longitude)
+	}
+	for _, a := range chart.Aspects {
+		if samePointPair(a.Planet1, a.Planet2) {
+			t.Errorf("南北交点之间不应计相位: %+v", a)
+		}
+	}
+
+	if err := ValidateBirthData(models.BirthData{Bodies: []models.PlanetID{"vulcan"}}); err == nil {
+		t.Error("未知天体应校验失败")
+	}
+	if err := ValidateBirthData(models.BirthData{NodeType: "osculating"}); err == nil {
+		t.Error("未知交点类型应校验失败")
+	}
+}
@@ -0,0 +1,184 @@
+package astro
+
+import (
+	"sort"
+	"star/models"
+)
+
+// ==================== 星盘形状与半球分布 ====================
+// 星盘形状按 Marc Edmund Jones 的分类，只看太阳至冥王星十颗行星的黄经分布：
+// 集中型（占据 ≤ 120°）、碗型（≤ 180°）、提桶型（碗型加上空半边中的单颗把手，两侧空隙 ≥ 60°）、
+// 跷跷板型（两段 ≥ 60° 的空隙把行星分成两组）、扩散型（三段以上 ≥ 60° 的空隙）、火车头型（只有一段 ≥ 120° 的空隙）、分散型（其余）。
+// 半球与象限按宫位统计，与元素平衡一样以行星权重加权后换算为百分比
+
+// chartShapeNames 星盘形状的英文名称
+var chartShapeNames = map[models.ChartShapeType]string{
+	models.ShapeBundle:     "Bundle",
+	models.ShapeBowl:       "Bowl",
+	models.ShapeBucket:     "Bucket",
+	models.ShapeLocomotive: "Locomotive",
+	models.ShapeSeesaw:     "Seesaw",
+	models.ShapeSplash:     "Splash",
+	models.ShapeSplay:      "Splay",
+}
+
+// 形状判断的空隙阈值（度）
+const (
+	bundleGap     = 240.0
+	bowlGap       = 180.0
+	locomotiveGap = 120.0
+	handleGap     = 60.0
+	clusterGap    = 60.0 // 分隔行星组的空隙
+)
+
+// shapeGap 第 i 颗行星与下一颗之间的空隙：After 为按黄道顺序紧接空隙之后的行星
+type shapeGap struct {
+	Size  float64
+	After int
+}
+
+// CalculateChartShape 计算星盘形状，太阳至冥王星不足三颗时返回 nil
+func CalculateChartShape(planets []models.PlanetPosition) *models.ChartShape {
+	var bodies []models.PlanetPosition
+	for _, p := range planets {
+		if _, ok := MeanDailyMotion[p.ID]; ok {
+			bodies = append(bodies, p)
+		}
+	}
+	if len(bodies) < 3 {
+		return nil
+	}
+	sort.SliceStable(bodies, func(i, j int) bool {
+		return NormalizeAngle(bodies[i].Longitude) < NormalizeAngle(bodies[j].Longitude)
+	})
+
+	n := len(bodies)
+	gaps := make([]shapeGap, n)
+	for i := range bodies {
+		next := (i + 1) % n
+		gaps[i] = shapeGap{Size: NormalizeAngle(bodies[next].Longitude - bodies[i].Longitude), After: next}
+	}
+	largest := gaps[0]
+	clusters := 0
+	for _, g := range gaps {
+		if g.Size > largest.Size {
+			largest = g
+		}
+		if g.Size >= clusterGap {
+			clusters++
+		}
+	}
+
+	shape := &models.ChartShape{
+		Span:       round2(360 - largest.Size),
+		LargestGap: round2(largest.Size),
+	}
+	handle := bucketHandle(gaps)
+	switch {
+	case largest.Size >= bundleGap:
+		shape.Type = models.ShapeBundle
+	case largest.Size >= bowlGap:
+		shape.Type = models.ShapeBowl
+	case handle >= 0:
+		shape.Type = models.ShapeBucket
+		shape.Handle = bodies[handle].ID
+	case clusters == 2:
+		shape.Type = models.ShapeSeesaw
+	case clusters >= 3:
+		shape.Type = models.ShapeSplay
+	case largest.Size >= locomotiveGap:
+		shape.Type = models.ShapeLocomotive
+		shape.Leading = bodies[largest.After].ID
+	default:
+		shape.Type = models.ShapeSplash
+	}
+	shape.Name = chartShapeNames[shape.Type]
+	return shape
+}
+
+// bucketHandle 提桶的把手：两侧空隙都 ≥ 60° 且合计 ≥ 180°（其余行星在半圆内）的行星，
+// 有多颗时取两侧空隙合计最大者；没有时返回 -1
+func bucketHandle(gaps []shapeGap) int {
+	handle, best := -1, 0.0
+	n := len(gaps)
+	for i := range gaps {
+		before, after := gaps[(i-1+n)%n].Size, gaps[i].Size
+		if before < handleGap || after < handleGap || before+after < bowlGap {
+			continue
+		}
+		if before+after > best {
+			handle, best = i, before+after
+		}
+	}
+	return handle
+}
+
+// CalculateHemisphereBalance 计算半球分布：东（10-3 宫，上升一侧）、西（4-9 宫）、北（1-6 宫，地平线下）、南（7-12 宫，地平线上）
+func CalculateHemisphereBalance(planets []models.PlanetPosition) map[string]float64 {
+	hemispheres := map[string]float64{
+		"east":  0,
+		"west":  0,
+		"north": 0,
+		"south": 0,
+	}
+
+	totalWeight := 0.0
+
+	for _, p := range planets {
+		if p.House < 1 || p.House > 12 {
+			continue
+		}
+		weight := PlanetWeights[p.ID]
+		if p.House >= 4 && p.House <= 9 {
+			hemispheres["west"] += weight
+		} else {
+			hemispheres["east"] += weight
+		}
+		if p.House <= 6 {
+			hemispheres["north"] += weight
+		} else {
+			hemispheres["south"] += weight
+		}
+		totalWeight += weight
+	}
+
+	// 转换为百分比（东西、南北各合计 100）
+	if totalWeight > 0 {
+		for k := range hemispheres {
+			hemispheres[k] = (hemispheres[k] / totalWeight) * 100
+		}
+	}
+
+	return hemispheres
+}
+
+// quadrantNames 象限名称：第一象限为 1-3 宫，依次类推
+var quadrantNames = []string{"first", "second", "third", "fourth"}
+
+// CalculateQuadrantBalance 计算四个象限的分布（1-3、4-6、7-9、10-12 宫）
+func CalculateQuadrantBalance(planets []models.PlanetPosition) map[string]float64 {
+	quadrants := map[string]float64{}
+	for _, name := range quadrantNames {
+		quadrants[name] = 0
+	}
+
+	totalWeight := 0.0
+
+	for _, p := range planets {
+		if p.House < 1 || p.House > 12 {
+			continue
+		}
+		weight := PlanetWeights[p.ID]
+		quadrants[quadrantNames[(p.House-1)/3]] += weight
+		totalWeight += weight
+	}
+
+	// 转换为百分比
+	if totalWeight > 0 {
+		for k := range quadrants {
+			quadrants[k] = (quadrants[k] / totalWeight) * 100
+		}
+	}
+
+	return quadrants
+}
@@ -0,0 +1,79 @@
+package astro
+
+import (
+	"math"
+	"star/models"
+	"testing"
+)
+
+// shapePlanets 按黄经依次放置太阳至冥王星
+func shapePlanets(lons ...float64) []models.PlanetPosition {
+	ids := []models.PlanetID{
+		models.Sun, models.Moon, models.Mercury, models.Venus, models.Mars,
+		models.Jupiter, models.Saturn, models.Uranus, models.Neptune, models.Pluto,
+	}
+	planets := make([]models.PlanetPosition, len(lons))
+	for i, lon := range lons {
+		planets[i] = newPlanetPosition(ids[i], lon, 0, false)
+	}
+	return planets
+}
+
+// TestChartShape 测试 Jones 星盘形状分类、把手与领头行星
+func TestChartShape(t *testing.T) {
+	cases := []struct {
+		name  string
+		lons  []float64
+		shape models.ChartShapeType
+	}{
+		{"集中型", []float64{10, 20, 30, 45, 60, 70, 80, 95, 110, 125}, models.ShapeBundle},
+		{"碗型", []float64{10, 30, 50, 70, 90, 110, 130, 150, 170, 185}, models.ShapeBowl},
+		{"提桶型", []float64{10, 30, 50, 70, 90, 110, 130, 150, 170, 280}, models.ShapeBucket},
+		{"火车头型", []float64{10, 35, 60, 85, 110, 135, 160, 185, 210, 235}, models.ShapeLocomotive},
+		{"跷跷板型", []float64{10, 20, 30, 40, 50, 190, 200, 210, 220, 230}, models.ShapeSeesaw},
+		{"扩散型", []float64{0, 10, 20, 100, 110, 120, 200, 210, 220, 230}, models.ShapeSplay},
+		{"分散型", []float64{0, 36, 72, 108, 144, 180, 216, 252, 288, 324}, models.ShapeSplash},
+	}
+	for _, tc := range cases {
+		shape := CalculateChartShape(shapePlanets(tc.lons...))
+		if shape == nil || shape.Type != tc.shape {
+			t.Errorf("%s: 形状应为 %s，实际 %+v", tc.name, tc.shape, shape)
+		}
+	}
+
+	bucket := CalculateChartShape(shapePlanets(10, 30, 50, 70, 90, 110, 130, 150, 170, 280))
+	if bucket.Handle != models.Pluto || bucket.Span != 250 {
+		t.Errorf("提桶的把手应为冥王星: %+v", bucket)
+	}
+	// 空隙跨越白羊点时，领头行星为空隙之后的第一颗
+	locomotive := CalculateChartShape(shapePlanets(300, 325, 350, 15, 40, 65, 90, 115, 140, 165))
+	if locomotive.Leading != models.Sun || locomotive.LargestGap != 135 || locomotive.Name != "Locomotive" {
+		t.Errorf("火车头的领头行星应为太阳: %+v", locomotive)
+	}
+	if CalculateChartShape(shapePlanets(10, 20)) != nil {
+		t.Error("行星不足三颗时不应判断形状")
+	}
+}
+
+// TestHemisphereBalance 测试半球与象限按行星权重统计
+func TestHemisphereBalance(t *testing.T) {
+	planets := shapePlanets(0, 0, 0)
+	for i, house := range []int{1, 10, 5} { // 太阳 10、月亮 10、水星 4
+		planets[i].House = house
+	}
+	planets = append(planets, newPlanetPosition(models.Venus, 0, 0, false)) // 未分配宫位的行星不计
+
+	hemispheres := CalculateHemisphereBalance(planets)
+	want := map[string]float64{"east": 20.0 / 24 * 100, "west": 4.0 / 24 * 100, "north": 14.0 / 24 * 100, "south": 10.0 / 24 * 100}
+	for k, v := range want {
+		if math.Abs(hemispheres[k]-(v)) > 1e-9 {
+			t.Errorf("%s 半球应为 %.2f%%，实际 %.2f%%", k, v, hemispheres[k])
+		}
+	}
+
+	quadrants := CalculateQuadrantBalance(planets)
+	if math.Abs(quadrants["first"]-(10.0/24*100)) > 1e-9 || math.Abs(quadrants["second"]-(4.0/24*100)) > 1e-9 ||
+		quadrants["third"] != 0 || math.Abs(quadrants["fourth"]-(10.0/24*100)) > 1e-9 {
+		t.Errorf("象限分布有误: %v", quadrants)
+	}
+}
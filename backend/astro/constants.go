@@ -41,6 +41,15 @@ var Planets = []PlanetInfo{
 	{models.Pluto, "Pluto", "♇", "#800080", 6},
 	{models.NorthNode, "North Node", "☊", "#9932cc", 3},
 	{models.Chiron, "Chiron", "⚷", "#228b22", 3},
+	{models.SouthNode, "South Node", "☋", "#9932cc", 3},
+	{models.MeanNode, "Mean Node", "☊", "#9932cc", 3},
+	{models.Ceres, "Ceres", "⚳", "#6b8e23", 2},
+	{models.Pallas, "Pallas", "⚴", "#4682b4", 2},
+	{models.Juno, "Juno", "⚵", "#db7093", 2},
+	{models.Vesta, "Vesta", "⚶", "#ff8c00", 2},
+	{models.Lilith, "Lilith", "⚸", "#2f2f4f", 2},
+	{models.OscLilith, "Osculating Lilith", "⚸", "#2f2f4f", 2},
+	{models.Eris, "Eris", "⯰", "#708090", 2},
 }
 
 // PlanetWeights 行星权重
@@ -57,6 +66,15 @@ var PlanetWeights = map[models.PlanetID]float64{
 	models.Pluto:     6,
 	models.NorthNode: 3,
 	models.Chiron:    3,
+	models.SouthNode: 3,
+	models.MeanNode:  3,
+	models.Ceres:     2,
+	models.Pallas:    2,
+	models.Juno:      2,
+	models.Vesta:     2,
+	models.Lilith:    2,
+	models.OscLilith: 2,
+	models.Eris:      2,
 }
 
 // GetPlanetInfo 获取行星信息
@@ -66,6 +84,10 @@ func GetPlanetInfo(id models.PlanetID) *PlanetInfo {
 			return &p
 		}
 	}
+	// 阿拉伯点（含自定义点）取自点目录
+	if lot := lotDefinition(id); lot != nil {
+		return &PlanetInfo{lot.ID, lot.Name, lot.Symbol, lotColor, lot.Weight}
+	}
 	return nil
 }
 
@@ -97,6 +119,22 @@ var ZodiacSigns = []ZodiacInfo{
 	{models.Pisces, "Pisces", "♓", "water", "mutable", models.Neptune},
 }
 
+// TraditionalRulers 星座的古典守护星（七颗可见行星）
+var TraditionalRulers = map[models.ZodiacID]models.PlanetID{
+	models.Aries:       models.Mars,
+	models.Taurus:      models.Venus,
+	models.Gemini:      models.Mercury,
+	models.Cancer:      models.Moon,
+	models.Leo:         models.Sun,
+	models.Virgo:       models.Mercury,
+	models.Libra:       models.Venus,
+	models.Scorpio:     models.Mars,
+	models.Sagittarius: models.Jupiter,
+	models.Capricorn:   models.Saturn,
+	models.Aquarius:    models.Saturn,
+	models.Pisces:      models.Jupiter,
+}
+
 // GetZodiacInfo 获取星座信息
 func GetZodiacInfo(id models.ZodiacID) *ZodiacInfo {
 	for _, z := range ZodiacSigns {
@@ -122,31 +160,42 @@ func GetZodiacByLongitude(longitude float64) *ZodiacInfo {
 
 // AspectDefinition 相位定义
 type AspectDefinition struct {
-	Type     models.AspectType
-	Angle    float64
-	Orb      float64
-	Name     string
-	Weight   float64
-	Nature   string // harmonious, tense, neutral
+	Type   models.AspectType `json:"type"`
+	Angle  float64           `json:"angle"`
+	Orb    float64           `json:"orb"` // 基准容许度（本命情境、非发光体）
+	Name   string            `json:"name"`
+	Weight float64           `json:"weight"`
+	Nature string            `json:"nature"` // harmonious, tense, neutral
+	Minor  bool              `json:"minor"`  // 次要相位
+	Score  float64           `json:"score"`  // 维度相位分基础分值
 }
 
-// AspectDefinitions 相位定义列表
+// AspectDefinitions 默认相位定义列表（相位目录的初始值，运行时以 CurrentAspectCatalogue 为准）
 var AspectDefinitions = []AspectDefinition{
-	{models.Conjunction, 0, 10, "Conjunction", 10, "neutral"},
-	{models.Sextile, 60, 6, "Sextile", 3, "harmonious"},
-	{models.Square, 90, 8, "Square", 7, "tense"},
-	{models.Trine, 120, 8, "Trine", 6, "harmonious"},
-	{models.Opposition, 180, 10, "Opposition", 8, "tense"},
+	{models.Conjunction, 0, 10, "Conjunction", 10, "neutral", false, 3},
+	{models.Sextile, 60, 6, "Sextile", 3, "harmonious", false, 2},
+	{models.Square, 90, 8, "Square", 7, "tense", false, -2.5},
+	{models.Trine, 120, 8, "Trine", 6, "harmonious", false, 3},
+	{models.Opposition, 180, 10, "Opposition", 8, "tense", false, -2},
+
+	// 次要相位：容许度 2-3°，影响较弱
+	{models.Semisextile, 30, 2, "Semisextile", 1, "harmonious", true, 0.5},
+	{models.Semisquare, 45, 2, "Semisquare", 2, "tense", true, -1},
+	{models.Quintile, 72, 2, "Quintile", 1.5, "harmonious", true, 1},
+	{models.Sesquiquadrate, 135, 2, "Sesquiquadrate", 2, "tense", true, -1},
+	{models.Biquintile, 144, 2, "Biquintile", 1.5, "harmonious", true, 1},
+	{models.Quincunx, 150, 3, "Quincunx", 2.5, "tense", true, -1},
 }
 
-// GetAspectDefinition 获取相位定义
+// DeclinationDefinitions 默认赤纬相位定义（Orb 为赤纬差的容许度，Angle 不使用）
+var DeclinationDefinitions = []AspectDefinition{
+	{models.Parallel, 0, 1, "Parallel", 8, "neutral", false, 2.5},
+	{models.Contraparallel, 0, 1, "Contraparallel", 6, "tense", false, -1.5},
+}
+
+// GetAspectDefinition 从当前相位目录获取相位定义
 func GetAspectDefinition(t models.AspectType) *AspectDefinition {
-	for _, a := range AspectDefinitions {
-		if a.Type == t {
-			return &a
-		}
-	}
-	return nil
+	return CurrentAspectCatalogue().Definition(t)
 }
 
 // ==================== 宫位信息 ====================
@@ -228,9 +277,28 @@ var DignityTable = map[models.PlanetID]map[models.Dignity][]models.ZodiacID{
 		models.DignityDetriment:  {models.Cancer, models.Leo},
 		models.DignityFall:       {models.Aries},
 	},
+	// 外行星采用现代守护与旺相，没有三分性、界与面
+	models.Uranus: {
+		models.DignityDomicile:   {models.Aquarius},
+		models.DignityExaltation: {models.Scorpio},
+		models.DignityDetriment:  {models.Leo},
+		models.DignityFall:       {models.Taurus},
+	},
+	models.Neptune: {
+		models.DignityDomicile:   {models.Pisces},
+		models.DignityExaltation: {models.Cancer},
+		models.DignityDetriment:  {models.Virgo},
+		models.DignityFall:       {models.Capricorn},
+	},
+	models.Pluto: {
+		models.DignityDomicile:   {models.Scorpio},
+		models.DignityExaltation: {models.Aries},
+		models.DignityDetriment:  {models.Taurus},
+		models.DignityFall:       {models.Libra},
+	},
 }
 
-// GetDignity 获取行星在星座中的尊贵度
+// GetDignity 获取行星在星座中的尊贵度（星座层面；含三分性、界、面的完整计分见 EssentialDignities）
 func GetDignity(planet models.PlanetID, sign models.ZodiacID) models.Dignity {
 	if table, ok := DignityTable[planet]; ok {
 		for dignity, signs := range table {
@@ -244,7 +312,7 @@ func GetDignity(planet models.PlanetID, sign models.ZodiacID) models.Dignity {
 	return models.DignityPeregrine
 }
 
-// GetDignityScore 获取尊贵度分数
+// GetDignityScore 获取星座层面尊贵度的分数（换座因子使用；行星所在度数的完整计分见 EssentialDignities）
 func GetDignityScore(dignity models.Dignity) float64 {
 	switch dignity {
 	case models.DignityDomicile:
@@ -262,7 +330,7 @@ func GetDignityScore(dignity models.Dignity) float64 {
 
 // ==================== 默认因子权重 ====================
 
-// DefaultFactorWeights 默认因子权重（可通过配置调整）
+// DefaultFactorWeights 默认因子权重（运行时调整见 ScoringConfig）
 var DefaultFactorWeights = models.FactorWeights{
 	Dignity:        1.0,
 	Retrograde:     1.0,
@@ -275,6 +343,12 @@ var DefaultFactorWeights = models.FactorWeights{
 	VoidOfCourse:   0.8,
 	Personal:       1.0,
 	Custom:         1.0,
+	Parallel:       0.5,
+	OutOfBounds:    0.6,
+	Ingress:        0.7,
+	Eclipse:        0.8,
+	FixedStar:      0.6,
+	Accidental:     0.5,
 }
 
 // ==================== 月相名称 ====================
@@ -25,9 +25,6 @@ type CustomFactorDefinition struct {
 	Description string    `json:"description"` // 描述
 }
 
-// CustomFactorStore 自定义因子存储（内存）
-var CustomFactorStore = make(map[string][]CustomFactorDefinition) // userID -> factors
-
 // ParseCustomFactor 解析自定义因子字符串
 // 格式：AddScore=(2*healthScore,2.5,202501171230)
 func ParseCustomFactor(input string) (*CustomFactorDefinition, error) {
@@ -154,15 +151,26 @@ func AddCustomFactor(userID string, factorDef string) (*CustomFactorDefinition,
 		return nil, err
 	}
 
-	CustomFactorStore[userID] = append(CustomFactorStore[userID], *factor)
+	// 自定义因子保存在配置快照中（见 scoring_config.go），写入时复制切片，已发布的快照保持不变
+	updateScoringConfig(func(cfg *ScoringConfig) {
+		existing := cfg.customFactors[userID]
+		factors := make([]CustomFactorDefinition, 0, len(existing)+1)
+		factors = append(factors, existing...)
+		cfg.customFactors[userID] = append(factors, *factor)
+	})
 	return factor, nil
 }
 
 // GetActiveCustomFactors 获取某时刻的活跃自定义因子
 func GetActiveCustomFactors(userID string, t time.Time) []CustomFactorDefinition {
+	return CurrentScoringConfig().ActiveCustomFactors(userID, t)
+}
+
+// ActiveCustomFactors 获取快照中某时刻的活跃自定义因子
+func (cfg *ScoringConfig) ActiveCustomFactors(userID string, t time.Time) []CustomFactorDefinition {
 	var active []CustomFactorDefinition
 
-	factors := CustomFactorStore[userID]
+	factors := cfg.customFactors[userID]
 	for _, f := range factors {
 		if t.After(f.StartTime) && t.Before(f.EndTime) {
 			active = append(active, f)
@@ -174,7 +182,12 @@ func GetActiveCustomFactors(userID string, t time.Time) []CustomFactorDefinition
 
 // ApplyCustomFactors 应用自定义因子到分数
 func ApplyCustomFactors(userID string, scores *models.DimensionScoresV2, overall *float64, t time.Time) {
-	factors := GetActiveCustomFactors(userID, t)
+	CurrentScoringConfig().ApplyCustomFactors(userID, scores, overall, t)
+}
+
+// ApplyCustomFactors 按快照中的自定义因子调整分数
+func (cfg *ScoringConfig) ApplyCustomFactors(userID string, scores *models.DimensionScoresV2, overall *float64, t time.Time) {
+	factors := cfg.ActiveCustomFactors(userID, t)
 
 	for _, f := range factors {
 		// 计算当前强度（正弦曲线）
@@ -241,6 +254,7 @@ func ConvertCustomFactorToInfluenceFactor(cfd CustomFactorDefinition, t time.Tim
 
 	// 计算当前强度
 	strength := CalculateFactorStrength(lifecycle, t)
+	weight := CurrentScoringConfig().FactorWeights.Custom
 
 	// 确定维度影响
 	var impact models.DimensionImpact
@@ -274,9 +288,9 @@ func ConvertCustomFactorToInfluenceFactor(cfd CustomFactorDefinition, t time.Tim
 		TimeLevel:       models.TimeLevelHourly,
 		Lifecycle:       lifecycle,
 		BaseValue:       cfd.Value,
-		Weight:          DefaultFactorWeights.Custom,
+		Weight:          weight,
 		CurrentStrength: strength,
-		Adjustment:      cfd.Value * strength * DefaultFactorWeights.Custom,
+		Adjustment:      cfd.Value * strength * weight,
 		DimensionImpact: impact,
 		IsPositive:      cfd.Value > 0,
 		AstroReason:     "自定义因子：用户手动设置",
@@ -285,11 +299,13 @@ func ConvertCustomFactorToInfluenceFactor(cfd CustomFactorDefinition, t time.Tim
 
 // ClearCustomFactors 清除用户的自定义因子
 func ClearCustomFactors(userID string) {
-	delete(CustomFactorStore, userID)
+	updateScoringConfig(func(cfg *ScoringConfig) {
+		delete(cfg.customFactors, userID)
+	})
 }
 
 // GetAllCustomFactors 获取用户的所有自定义因子
 func GetAllCustomFactors(userID string) []CustomFactorDefinition {
-	return CustomFactorStore[userID]
+	return CurrentScoringConfig().CustomFactors(userID)
 }
 
@@ -0,0 +1,221 @@
+package astro
+
+import (
+	"fmt"
+	"math"
+	"star/models"
+	"time"
+)
+
+// ==================== 赤纬相位 ====================
+// 平行：两颗天体赤纬相同且位于天赤道同侧，效果类似合相
+// 反平行：两颗天体赤纬相同但分居天赤道两侧，效果类似对分相
+
+// CalculateParallels 计算本命盘行星之间的平行/反平行
+func CalculateParallels(planets []models.PlanetPosition) []models.AspectData {
+	var parallels []models.AspectData
+
+	catalogue := CurrentAspectCatalogue()
+	for i := 0; i < len(planets); i++ {
+		for j := i + 1; j < len(planets); j++ {
+			p1, p2 := planets[i], planets[j]
+			if samePointPair(p1.ID, p2.ID) {
+				continue
+			}
+			for _, def := range catalogue.Declinations {
+				aspect, ok := buildParallel(p1, p2, def, catalogue.Orb(def, p1.ID, p2.ID, AspectContextNatal))
+				if !ok {
+					continue
+				}
+				aspect.Interpretation = generateAspectInterpretation(p1, p2, def)
+				parallels = append(parallels, aspect)
+			}
+		}
+	}
+
+	return parallels
+}
+
+// CalculateTransitParallels 计算行运与本命的平行/反平行（本命赤纬固定不动）
+func CalculateTransitParallels(transitPositions, natalPositions []models.PlanetPosition) []models.AspectData {
+	var parallels []models.AspectData
+
+	catalogue := CurrentAspectCatalogue()
+	for _, transit := range transitPositions {
+		for _, natal := range natalPositions {
+			natal.DeclinationSpeed = 0
+			for _, def := range catalogue.Declinations {
+				aspect, ok := buildParallel(transit, natal, def, catalogue.Orb(def, transit.ID, natal.ID, AspectContextTransit))
+				if !ok {
+					continue
+				}
+				aspect.Interpretation = fmt.Sprintf("Transit %s forms %s with natal %s",
+					transit.Name, def.Name, natal.Name)
+				parallels = append(parallels, aspect)
+			}
+		}
+	}
+
+	return parallels
+}
+
+// CalculateSynastryParallels 计算两张本命盘之间的平行/反平行（双方赤纬均视为静止）
+func CalculateSynastryParallels(chartA, chartB *models.NatalChart) []models.AspectData {
+	var parallels []models.AspectData
+
+	catalogue := CurrentAspectCatalogue()
+	for _, p1 := range chartA.Planets {
+		for _, p2 := range chartB.Planets {
+			p1.DeclinationSpeed, p2.DeclinationSpeed = 0, 0
+			for _, def := range catalogue.Declinations {
+				aspect, ok := buildParallel(p1, p2, def, catalogue.Orb(def, p1.ID, p2.ID, AspectContextSynastry))
+				if !ok {
+					continue
+				}
+				aspect.Interpretation = generateAspectInterpretation(p1, p2, def)
+				parallels = append(parallels, aspect)
+			}
+		}
+	}
+
+	return parallels
+}
+
+// buildParallel 两颗天体的赤纬差在容许度内时构造平行/反平行
+// 平行要求同侧、反平行要求异侧，因此天赤道附近不会同时成立
+func buildParallel(p1, p2 models.PlanetPosition, def AspectDefinition, allowedOrb float64) (models.AspectData, bool) {
+	sameSide := (p1.Declination >= 0) == (p2.Declination >= 0)
+	decl2, speed2 := p2.Declination, p2.DeclinationSpeed
+	switch def.Type {
+	case models.Parallel:
+		if !sameSide {
+			return models.AspectData{}, false
+		}
+	case models.Contraparallel:
+		if sameSide {
+			return models.AspectData{}, false
+		}
+		// 反平行等价于与对方镜像赤纬的平行
+		decl2, speed2 = -decl2, -speed2
+	default:
+		return models.AspectData{}, false
+	}
+
+	orb := math.Abs(p1.Declination - decl2)
+	if allowedOrb <= 0 || orb > allowedOrb {
+		return models.AspectData{}, false
+	}
+
+	strength := 1.0 - orb/allowedOrb
+	weight := strength * def.Weight * (PlanetWeights[p1.ID] + PlanetWeights[p2.ID]) / 20.0
+	applying, toExact := aspectMotion(p1.Declination, p1.DeclinationSpeed, decl2, speed2, 0)
+
+	return models.AspectData{
+		Planet1:     p1.ID,
+		Planet2:     p2.ID,
+		AspectType:  def.Type,
+		ExactAngle:  0,
+		ActualAngle: orb,
+		Orb:         orb,
+		AllowedOrb:  allowedOrb,
+		Applying:    applying,
+		TimeToExact: toExact,
+		Strength:    strength,
+		Weight:      weight,
+	}, true
+}
+
+// OutOfBoundsPlanets 出界行星列表
+func OutOfBoundsPlanets(planets []models.PlanetPosition) []models.PlanetID {
+	var ids []models.PlanetID
+	for _, p := range planets {
+		if p.OutOfBounds {
+			ids = append(ids, p.ID)
+		}
+	}
+	return ids
+}
+
+// ==================== 出界区间 ====================
+
+// oobSearchHorizon 出界起止时刻的最大搜索范围（天），外行星出界可持续一年以上
+const oobSearchHorizon = 730.0
+
+// OutOfBoundsPeriod 一次出界的起止时间与赤纬极值
+type OutOfBoundsPeriod struct {
+	Planet         models.PlanetID `json:"planet"`
+	Start          time.Time       `json:"start"`
+	End            time.Time       `json:"end"`
+	Peak           time.Time       `json:"peak"`
+	MaxDeclination float64         `json:"maxDeclination"` // 带符号，南赤纬为负
+}
+
+// providerBodyKey 按星历提供者与天体区分的缓存键
+type providerBodyKey struct {
+	provider string
+	planet   models.PlanetID
+}
+
+// oobPeriods 按星历源与天体缓存的出界区间
+var oobPeriods = newSpanCache[providerBodyKey, OutOfBoundsPeriod](128)
+
+// FindOutOfBoundsPeriod 求 jd 时刻所处的出界区间，未出界时返回 nil
+func FindOutOfBoundsPeriod(planet models.PlanetID, jd float64) *OutOfBoundsPeriod {
+	provider := CurrentEphemerisProvider()
+	key := providerBodyKey{provider.Name(), planet}
+	t := julianDayToTime(jd)
+
+	if period, ok := oobPeriods.get(key, func(p OutOfBoundsPeriod) bool {
+		return !t.Before(p.Start) && !t.After(p.End)
+	}); ok {
+		return &period
+	}
+
+	// 超出量：|δ| - ε，为正即出界；与 OutOfBounds 标记同用真黄赤交角
+	excess := func(x float64) float64 {
+		return math.Abs(planetPositionFrom(provider, planet, x).Declination) - TrueObliquity(x)
+	}
+	if excess(jd) <= 0 {
+		return nil
+	}
+
+	step, ok := transitSearchSteps[planet]
+	if !ok {
+		step = 1
+	}
+	// 赤纬变化远慢于黄经，步长放宽为行运求解的 4 倍
+	step *= 4
+
+	peakJD := jd
+	peakDecl := planetPositionFrom(provider, planet, jd).Declination
+	edge := func(dir float64) float64 {
+		prev := jd
+		for d := step; d <= oobSearchHorizon; d += step {
+			x := jd + dir*d
+			decl := planetPositionFrom(provider, planet, x).Declination
+			if math.Abs(decl)-TrueObliquity(x) <= 0 {
+				if dir < 0 {
+					return bisect(excess, x, prev)
+				}
+				return bisect(excess, prev, x)
+			}
+			if math.Abs(decl) > math.Abs(peakDecl) {
+				peakJD, peakDecl = x, decl
+			}
+			prev = x
+		}
+		return jd + dir*oobSearchHorizon
+	}
+
+	period := OutOfBoundsPeriod{
+		Planet: planet,
+		Start:  julianDayToTime(edge(-1)),
+		End:    julianDayToTime(edge(1)),
+	}
+	period.Peak = julianDayToTime(peakJD)
+	period.MaxDeclination = peakDecl
+
+	oobPeriods.put(key, period)
+
+	return &period
+}
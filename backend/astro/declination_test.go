@@ -0,0 +1,175 @@
+package astro
+
+import (
+	"math"
+	"star/models"
+	"testing"
+	"time"
+)
+
+// declinedPosition 构造指定赤纬与赤纬速度的行星位置
+func declinedPosition(planet models.PlanetID, decl, speed float64) models.PlanetPosition {
+	pos := newPlanetPosition(planet, 0, 0, false)
+	pos.Declination = decl
+	pos.DeclinationSpeed = speed
+	return pos
+}
+
+// TestCalculateParallels 测试平行/反平行的判定与入相/离相
+func TestCalculateParallels(t *testing.T) {
+	planets := []models.PlanetPosition{
+		declinedPosition(models.Sun, 20, 0.1),
+		declinedPosition(models.Venus, 20.5, 0.3),  // 与太阳同侧相差 0.5°：平行，金星追离太阳
+		declinedPosition(models.Mars, -19.6, -0.2), // 与太阳异侧相差 0.4°：反平行
+		declinedPosition(models.Saturn, 0.2, 0),
+		declinedPosition(models.Uranus, -0.3, 0), // 赤道附近异侧：只算反平行
+	}
+
+	parallels := CalculateParallels(planets)
+
+	sunVenus := findAspect(parallels, models.Sun, models.Venus)
+	if sunVenus == nil || sunVenus.AspectType != models.Parallel {
+		t.Fatalf("太阳与金星应成平行: %+v", sunVenus)
+	}
+	if sunVenus.Applying || sunVenus.TimeToExact >= 0 {
+		t.Errorf("金星赤纬远离太阳，应为离相: %+v", sunVenus)
+	}
+
+	sunMars := findAspect(parallels, models.Sun, models.Mars)
+	if sunMars == nil || sunMars.AspectType != models.Contraparallel {
+		t.Fatalf("太阳与火星应成反平行: %+v", sunMars)
+	}
+	if math.Abs(sunMars.Orb-0.4) > 1e-9 {
+		t.Errorf("反平行容许度应为 0.4°，实际 %.4f", sunMars.Orb)
+	}
+	if !sunMars.Applying {
+		t.Errorf("火星向南、太阳向北，镜像赤纬相互靠近，应为入相: %+v", sunMars)
+	}
+
+	count := 0
+	for _, p := range parallels {
+		if (p.Planet1 == models.Saturn && p.Planet2 == models.Uranus) || (p.Planet1 == models.Uranus && p.Planet2 == models.Saturn) {
+			count++
+			if p.AspectType != models.Contraparallel {
+				t.Errorf("赤道两侧的天体只应成反平行: %s", p.AspectType)
+			}
+		}
+	}
+	if count != 1 {
+		t.Errorf("土星与天王星应恰好有一个赤纬相位，实际 %d", count)
+	}
+}
+
+// TestCalculateSynastryParallels 测试合盘平行：A 的行星在前，双方赤纬速度不参与入相/离相
+func TestCalculateSynastryParallels(t *testing.T) {
+	chartA := &models.NatalChart{Planets: []models.PlanetPosition{declinedPosition(models.Sun, 20, 0.3)}}
+	chartB := &models.NatalChart{Planets: []models.PlanetPosition{
+		declinedPosition(models.Moon, 20.4, 5),
+		declinedPosition(models.Mars, -19.8, -0.2),
+		declinedPosition(models.Saturn, 5, 0),
+	}}
+
+	parallels := CalculateSynastryParallels(chartA, chartB)
+	if len(parallels) != 2 {
+		t.Fatalf("应有太阳-月亮平行与太阳-火星反平行: %+v", parallels)
+	}
+	sunMoon := findAspect(parallels, models.Sun, models.Moon)
+	if sunMoon == nil || sunMoon.Planet1 != models.Sun || sunMoon.AspectType != models.Parallel || sunMoon.TimeToExact != 0 {
+		t.Errorf("太阳-月亮应为静止的平行: %+v", sunMoon)
+	}
+	if sunMars := findAspect(parallels, models.Sun, models.Mars); sunMars == nil || sunMars.AspectType != models.Contraparallel {
+		t.Errorf("太阳-火星应为反平行: %+v", sunMars)
+	}
+}
+
+// TestOutOfBoundsPeriod 测试 2025 年月亮大停变期间的出界区间
+func TestOutOfBoundsPeriod(t *testing.T) {
+	useProvider(t, NewPreciseProvider())
+
+	provider := CurrentEphemerisProvider()
+	start := DateToJulianDay(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	jd := 0.0
+	for x := start; x < start+30; x += 0.25 {
+		if planetPositionFrom(provider, models.Moon, x).OutOfBounds {
+			jd = x
+			break
+		}
+	}
+	if jd == 0 {
+		t.Fatal("2025 年 1 月月亮应至少出界一次")
+	}
+
+	// 赤纬速度与数值差分一致
+	moon := planetPositionFrom(provider, models.Moon, jd)
+	h := 0.01
+	numeric := (planetPositionFrom(provider, models.Moon, jd+h).Declination -
+		planetPositionFrom(provider, models.Moon, jd-h).Declination) / (2 * h)
+	if math.Abs(moon.DeclinationSpeed-numeric) > 0.05 {
+		t.Errorf("月亮赤纬速度 %.4f°/天 与差分 %.4f°/天 不符", moon.DeclinationSpeed, numeric)
+	}
+
+	period := FindOutOfBoundsPeriod(models.Moon, jd)
+	if period == nil {
+		t.Fatal("出界时刻应能求出出界区间")
+	}
+	days := period.End.Sub(period.Start).Hours() / 24
+	if days < 1 || days > 8 {
+		t.Errorf("月亮单次出界应持续数天，实际 %.2f 天", days)
+	}
+	if math.Abs(period.MaxDeclination) < 27 {
+		t.Errorf("大停变期间月亮赤纬极值应超过 27°，实际 %.2f", period.MaxDeclination)
+	}
+
+	edgeJD := DateToJulianDay(period.End)
+	edge := planetPositionFrom(provider, models.Moon, edgeJD)
+	if diff := math.Abs(edge.Declination) - TrueObliquity(edgeJD); math.Abs(diff) > 0.01 {
+		t.Errorf("出界结束时赤纬应等于黄赤交角，相差 %.4f°", diff)
+	}
+	t.Logf("月亮出界: %s ~ %s，极值 %.2f° @ %s", period.Start, period.End, period.MaxDeclination, period.Peak)
+
+	factors := calculateOutOfBoundsFactorsV2([]models.PlanetPosition{planetPositionFrom(provider, models.Moon, jd)}, 1, jd)
+	if len(factors) != 1 || factors[0].Type != models.FactorOutOfBounds || factors[0].Lifecycle == nil {
+		t.Fatalf("应生成一个带生命周期的出界因子: %+v", factors)
+	}
+	if CalculateFactorStrength(factors[0].Lifecycle, julianDayToTime(jd)) <= 0 {
+		t.Errorf("出界期间因子强度应大于 0")
+	}
+}
+
+// TestOutOfBoundsBetweenObliquities 月亮赤纬介于平黄赤交角与真黄赤交角之间时，出界标记、出界区间与出界因子一致
+func TestOutOfBoundsBetweenObliquities(t *testing.T) {
+	useProvider(t, NewPreciseProvider())
+
+	provider := CurrentEphemerisProvider()
+	// 2025 年 1 月 9 日月亮北赤纬越过黄赤交角，此时章动使真黄赤交角比平黄赤交角大约 8.6″；取 |δ| 等于两者中值的时刻
+	mid := func(x float64) float64 {
+		return math.Abs(planetPositionFrom(provider, models.Moon, x).Declination) - (MeanObliquity(x)+TrueObliquity(x))/2
+	}
+	start := DateToJulianDay(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	jd := 0.0
+	for x := start; x < start+30; x += 0.25 {
+		if mid(x) <= 0 && mid(x+0.25) > 0 {
+			jd = bisect(mid, x, x+0.25)
+			break
+		}
+	}
+	if jd == 0 {
+		t.Fatal("2025 年 1 月月亮应越过黄赤交角")
+	}
+
+	moon := planetPositionFrom(provider, models.Moon, jd)
+	meanEps, trueEps := MeanObliquity(jd), TrueObliquity(jd)
+	if d := math.Abs(moon.Declination); d <= math.Min(meanEps, trueEps) || d >= math.Max(meanEps, trueEps) {
+		t.Fatalf("赤纬 %.6f° 应介于平黄赤交角 %.6f° 与真黄赤交角 %.6f° 之间", d, meanEps, trueEps)
+	}
+
+	t.Logf("%s |δ|=%.6f° ε=%.6f°/%.6f° 出界=%v", julianDayToTime(jd), moon.Declination, meanEps, trueEps, moon.OutOfBounds)
+	period := FindOutOfBoundsPeriod(models.Moon, jd)
+	if moon.OutOfBounds != (period != nil) {
+		t.Errorf("出界标记 %v 与出界区间 %+v 不一致", moon.OutOfBounds, period)
+	}
+	factors := calculateOutOfBoundsFactorsV2([]models.PlanetPosition{moon}, 1, jd)
+	if moon.OutOfBounds != (len(factors) == 1) {
+		t.Errorf("出界标记 %v 与出界因子 %+v 不一致", moon.OutOfBounds, factors)
+	}
+}
@@ -0,0 +1,53 @@
+package astro
+
+import "math"
+
+// ==================== 时间尺度 ====================
+
+// DeltaT 计算 ΔT = TT - UT（秒）
+// 采用 Espenak & Meeus 多项式拟合，1860-2050 年精度约 1 秒，其余年份使用长期抛物线外推
+func DeltaT(jd float64) float64 {
+	y := 2000 + (jd-J2000)/365.25
+
+	switch {
+	case y >= 2005 && y < 2050:
+		t := y - 2000
+		return 62.92 + 0.32217*t + 0.005589*t*t
+	case y >= 1986 && y < 2005:
+		t := y - 2000
+		return 63.86 + 0.3345*t - 0.060374*t*t + 0.0017275*t*t*t +
+			0.000651814*math.Pow(t, 4) + 0.00002373599*math.Pow(t, 5)
+	case y >= 1961 && y < 1986:
+		t := y - 1975
+		return 45.45 + 1.067*t - t*t/260 - t*t*t/718
+	case y >= 1941 && y < 1961:
+		t := y - 1950
+		return 29.07 + 0.407*t - t*t/233 + t*t*t/2547
+	case y >= 1920 && y < 1941:
+		t := y - 1920
+		return 21.20 + 0.84493*t - 0.076100*t*t + 0.0020936*t*t*t
+	case y >= 1900 && y < 1920:
+		t := y - 1900
+		return -2.79 + 1.494119*t - 0.0598939*t*t + 0.0061966*t*t*t - 0.000197*math.Pow(t, 4)
+	case y >= 1860 && y < 1900:
+		t := y - 1860
+		return 7.62 + 0.5737*t - 0.251754*t*t + 0.01680668*t*t*t -
+			0.0004473624*math.Pow(t, 4) + math.Pow(t, 5)/233174
+	case y >= 2050 && y < 2150:
+		u := (y - 1820) / 100
+		return -20 + 32*u*u - 0.5628*(2150-y)
+	}
+
+	u := (y - 1820) / 100
+	return -20 + 32*u*u
+}
+
+// TerrestrialTime 将世界时儒略日转换为地球时儒略日
+func TerrestrialTime(jdUT float64) float64 {
+	return jdUT + DeltaT(jdUT)/86400
+}
+
+// UniversalTime 将地球时儒略日转换为世界时儒略日
+func UniversalTime(jdTT float64) float64 {
+	return jdTT - DeltaT(jdTT)/86400
+}
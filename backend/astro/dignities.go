@@ -0,0 +1,346 @@
+package astro
+
+import (
+	"fmt"
+	"math"
+	"star/models"
+	"strings"
+)
+
+// ==================== 先天尊贵 ====================
+// 托勒密/埃及体系的五种先天尊贵：入庙、旺相、三分性、界、面，按 Lilly 计分合计：
+// 入庙 +5、旺相 +4、三分性 +3、界 +2、面 +1，落陷 −5、失势 −4，
+// 七颗古典行星没有任何尊贵时为游离（peregrine）−5。
+// 三分性按 Dorotheus 体系区分日间、夜间与协同主星；外行星只有现代守护与旺相
+
+// DignityPoints 各项先天尊贵的分数
+var DignityPoints = map[models.Dignity]float64{
+	models.DignityDomicile:   5,
+	models.DignityExaltation: 4,
+	models.DignityTriplicity: 3,
+	models.DignityTerm:       2,
+	models.DignityFace:       1,
+	models.DignityDetriment:  -5,
+	models.DignityFall:       -4,
+	models.DignityPeregrine:  -5,
+}
+
+// participatingTriplicityPoints 协同三分性主星的分数（日夜主星之外的第三位主星）
+const participatingTriplicityPoints = 1.0
+
+// dignityPointScale 尊贵分换算到因子与基础分的比例（入庙 5 分约合旧版的 2.5）
+const dignityPointScale = 0.5
+
+// ClassicalPlanets 七颗古典行星（按迦勒底序）
+var ClassicalPlanets = []models.PlanetID{
+	models.Saturn, models.Jupiter, models.Mars, models.Sun,
+	models.Venus, models.Mercury, models.Moon,
+}
+
+// TriplicityRuler 元素的三分性主星
+type TriplicityRuler struct {
+	Day           models.PlanetID
+	Night         models.PlanetID
+	Participating models.PlanetID
+}
+
+// TriplicityRulers 元素的三分性主星（Dorotheus）
+var TriplicityRulers = map[string]TriplicityRuler{
+	"fire":  {models.Sun, models.Jupiter, models.Saturn},
+	"earth": {models.Venus, models.Moon, models.Mars},
+	"air":   {models.Saturn, models.Mercury, models.Jupiter},
+	"water": {models.Venus, models.Mars, models.Moon},
+}
+
+// term 界：主星及其终止度数（星座内度数）
+type term struct {
+	Ruler models.PlanetID
+	End   float64
+}
+
+// EgyptianTerms 埃及界（按星座顺序）
+var EgyptianTerms = [12][5]term{
+	{{models.Jupiter, 6}, {models.Venus, 12}, {models.Mercury, 20}, {models.Mars, 25}, {models.Saturn, 30}},
+	{{models.Venus, 8}, {models.Mercury, 14}, {models.Jupiter, 22}, {models.Saturn, 27}, {models.Mars, 30}},
+	{{models.Mercury, 6}, {models.Jupiter, 12}, {models.Venus, 17}, {models.Mars, 24}, {models.Saturn, 30}},
+	{{models.Mars, 7}, {models.Venus, 13}, {models.Mercury, 19}, {models.Jupiter, 26}, {models.Saturn, 30}},
+	{{models.Jupiter, 6}, {models.Venus, 11}, {models.Saturn, 18}, {models.Mercury, 24}, {models.Mars, 30}},
+	{{models.Mercury, 7}, {models.Venus, 17}, {models.Jupiter, 21}, {models.Mars, 28}, {models.Saturn, 30}},
+	{{models.Saturn, 6}, {models.Mercury, 14}, {models.Jupiter, 21}, {models.Venus, 28}, {models.Mars, 30}},
+	{{models.Mars, 7}, {models.Venus, 11}, {models.Mercury, 19}, {models.Jupiter, 24}, {models.Saturn, 30}},
+	{{models.Jupiter, 12}, {models.Venus, 17}, {models.Mercury, 21}, {models.Saturn, 26}, {models.Mars, 30}},
+	{{models.Mercury, 7}, {models.Jupiter, 14}, {models.Venus, 22}, {models.Saturn, 26}, {models.Mars, 30}},
+	{{models.Mercury, 7}, {models.Venus, 13}, {models.Jupiter, 20}, {models.Mars, 25}, {models.Saturn, 30}},
+	{{models.Venus, 12}, {models.Jupiter, 16}, {models.Mercury, 19}, {models.Mars, 28}, {models.Saturn, 30}},
+}
+
+// PtolemaicTerms 托勒密界（Lilly《基督教占星》所列版本）
+var PtolemaicTerms = [12][5]term{
+	{{models.Jupiter, 6}, {models.Venus, 14}, {models.Mercury, 21}, {models.Mars, 26}, {models.Saturn, 30}},
+	{{models.Venus, 8}, {models.Mercury, 15}, {models.Jupiter, 22}, {models.Saturn, 26}, {models.Mars, 30}},
+	{{models.Mercury, 7}, {models.Jupiter, 14}, {models.Venus, 21}, {models.Saturn, 25}, {models.Mars, 30}},
+	{{models.Mars, 6}, {models.Jupiter, 13}, {models.Mercury, 20}, {models.Venus, 27}, {models.Saturn, 30}},
+	{{models.Saturn, 6}, {models.Mercury, 13}, {models.Venus, 19}, {models.Jupiter, 25}, {models.Mars, 30}},
+	{{models.Mercury, 7}, {models.Venus, 13}, {models.Jupiter, 18}, {models.Saturn, 24}, {models.Mars, 30}},
+	{{models.Saturn, 6}, {models.Venus, 11}, {models.Jupiter, 19}, {models.Mercury, 24}, {models.Mars, 30}},
+	{{models.Mars, 6}, {models.Jupiter, 14}, {models.Venus, 21}, {models.Mercury, 27}, {models.Saturn, 30}},
+	{{models.Jupiter, 8}, {models.Venus, 14}, {models.Mercury, 19}, {models.Saturn, 25}, {models.Mars, 30}},
+	{{models.Venus, 6}, {models.Mercury, 12}, {models.Jupiter, 19}, {models.Mars, 25}, {models.Saturn, 30}},
+	{{models.Saturn, 6}, {models.Mercury, 12}, {models.Venus, 20}, {models.Jupiter, 25}, {models.Mars, 30}},
+	{{models.Venus, 8}, {models.Jupiter, 14}, {models.Mercury, 20}, {models.Mars, 26}, {models.Saturn, 30}},
+}
+
+// ParseTermSystem 解析界体系，空值返回埃及界
+func ParseTermSystem(value models.TermSystem) (models.TermSystem, error) {
+	switch strings.ToLower(strings.TrimSpace(string(value))) {
+	case "", string(models.TermsEgyptian):
+		return models.TermsEgyptian, nil
+	case string(models.TermsPtolemaic):
+		return models.TermsPtolemaic, nil
+	}
+	return "", fmt.Errorf("不支持的界体系: %s", value)
+}
+
+// ResolveTermSystem 解析界体系，无法识别时回退到埃及界
+func ResolveTermSystem(value models.TermSystem) models.TermSystem {
+	terms, err := ParseTermSystem(value)
+	if err != nil {
+		return models.TermsEgyptian
+	}
+	return terms
+}
+
+// TermRuler 黄经所在界的主星
+func TermRuler(longitude float64, terms models.TermSystem) models.PlanetID {
+	longitude = NormalizeAngle(longitude)
+	table := &EgyptianTerms
+	if terms == models.TermsPtolemaic {
+		table = &PtolemaicTerms
+	}
+	degree := math.Mod(longitude, 30)
+	for _, t := range table[int(longitude/30)%12] {
+		if degree < t.End {
+			return t.Ruler
+		}
+	}
+	return table[int(longitude/30)%12][4].Ruler
+}
+
+// FaceRuler 黄经所在面（十度区间）的主星，自白羊座第一面火星起按迦勒底序排列
+func FaceRuler(longitude float64) models.PlanetID {
+	face := int(NormalizeAngle(longitude)/10) % 36
+	return ClassicalPlanets[(face+2)%7]
+}
+
+// exaltationRuler 星座的古典旺相主星，没有时返回空
+func exaltationRuler(sign models.ZodiacID) models.PlanetID {
+	for _, planet := range ClassicalPlanets {
+		for _, s := range DignityTable[planet][models.DignityExaltation] {
+			if s == sign {
+				return planet
+			}
+		}
+	}
+	return ""
+}
+
+// triplicityRole 行星在星座元素中的三分性主星身份
+// 日夜区分未知（行运）时，日间主星与夜间主星都按主星计
+func triplicityRole(planet models.PlanetID, sign models.ZodiacID, sect models.Sect) string {
+	zodiac := GetZodiacInfo(sign)
+	if zodiac == nil {
+		return ""
+	}
+	rulers := TriplicityRulers[zodiac.Element]
+	switch {
+	case planet == rulers.Day && sect != models.SectNight:
+		return "day"
+	case planet == rulers.Night && sect != models.SectDay:
+		return "night"
+	case planet == rulers.Participating:
+		return "participating"
+	}
+	return ""
+}
+
+// triplicityPoints 三分性身份的分数
+func triplicityPoints(role string) float64 {
+	switch role {
+	case "":
+		return 0
+	case "participating":
+		return participatingTriplicityPoints
+	}
+	return DignityPoints[models.DignityTriplicity]
+}
+
+// isClassicalPlanet 是否为七颗古典行星
+func isClassicalPlanet(planet models.PlanetID) bool {
+	for _, id := range ClassicalPlanets {
+		if id == planet {
+			return true
+		}
+	}
+	return false
+}
+
+// EssentialDignities 计算行星在黄经处的先天尊贵
+// 日夜区分为空时按行运处理（日夜三分性主星都计分）；非行星的点没有先天尊贵
+func EssentialDignities(planet models.PlanetID, longitude float64, sect models.Sect, terms models.TermSystem) models.EssentialDignity {
+	result := essentialDignities(planet, longitude, sect, terms)
+	result.Almuten = AlmutenOfDegree(longitude, sect, terms)
+	return result
+}
+
+// essentialDignities 计算先天尊贵（不含度数胜利星）
+func essentialDignities(planet models.PlanetID, longitude float64, sect models.Sect, terms models.TermSystem) models.EssentialDignity {
+	longitude = NormalizeAngle(longitude)
+	sign := GetZodiacByLongitude(longitude).ID
+	result := models.EssentialDignity{
+		Planet:    planet,
+		Sign:      sign,
+		Dignities: []models.Dignity{},
+		TermRuler: TermRuler(longitude, terms),
+		FaceRuler: FaceRuler(longitude),
+	}
+
+	// 星座层面：入庙、旺相、落陷、失势
+	for _, dignity := range []models.Dignity{models.DignityDomicile, models.DignityExaltation, models.DignityDetriment, models.DignityFall} {
+		for _, s := range DignityTable[planet][dignity] {
+			if s == sign {
+				result.Dignities = append(result.Dignities, dignity)
+				result.Score += DignityPoints[dignity]
+			}
+		}
+	}
+	if !isClassicalPlanet(planet) {
+		return result
+	}
+
+	// 度数层面：三分性、界、面
+	if role := triplicityRole(planet, sign, sect); role != "" {
+		result.Triplicity = role
+		result.Dignities = append(result.Dignities, models.DignityTriplicity)
+		result.Score += triplicityPoints(role)
+	}
+	if result.TermRuler == planet {
+		result.Dignities = append(result.Dignities, models.DignityTerm)
+		result.Score += DignityPoints[models.DignityTerm]
+	}
+	if result.FaceRuler == planet {
+		result.Dignities = append(result.Dignities, models.DignityFace)
+		result.Score += DignityPoints[models.DignityFace]
+	}
+
+	// 没有任何尊贵即为游离
+	if !hasPositiveDignity(result.Dignities) {
+		result.Dignities = append(result.Dignities, models.DignityPeregrine)
+		result.Score += DignityPoints[models.DignityPeregrine]
+	}
+	return result
+}
+
+// transitDignityKey 行运尊贵分零点的缓存键
+type transitDignityKey struct {
+	planet models.PlanetID
+	terms  models.TermSystem
+}
+
+// transitDignityBaselines 各行星按界体系的行运尊贵分零点
+var transitDignityBaselines = map[transitDignityKey]float64{}
+
+func init() {
+	for planet := range DignityTable {
+		for _, terms := range []models.TermSystem{models.TermsEgyptian, models.TermsPtolemaic} {
+			// 每 0.25° 取样，界与面的边界都落在取样间隔上
+			sum, n := 0.0, 0
+			for lon := 0.125; lon < 360; lon += 0.25 {
+				sum += essentialDignities(planet, lon, "", terms).Score
+				n++
+			}
+			transitDignityBaselines[transitDignityKey{planet, terms}] = sum / float64(n)
+		}
+	}
+}
+
+// TransitDignityScore 行运因子使用的尊贵分：Lilly 计分减去该行星在整个黄道上的平均分，本命盘仍用完整计分
+func TransitDignityScore(d models.EssentialDignity, terms models.TermSystem) float64 {
+	if terms != models.TermsPtolemaic {
+		terms = models.TermsEgyptian
+	}
+	return d.Score - transitDignityBaselines[transitDignityKey{d.Planet, terms}]
+}
+
+// hasPositiveDignity 是否有任一尊贵
+func hasPositiveDignity(dignities []models.Dignity) bool {
+	for _, d := range dignities {
+		if DignityPoints[d] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// EssentialDignityScore 行星在黄经处的尊贵分合计（行运，埃及界）
+func EssentialDignityScore(planet models.PlanetID, longitude float64) float64 {
+	if _, ok := DignityTable[planet]; !ok {
+		return 0
+	}
+	return essentialDignities(planet, longitude, "", models.TermsEgyptian).Score
+}
+
+// AlmutenOfDegree 度数的胜利星：在该度数拥有尊贵分最高的古典行星
+// 同分时取尊贵等级较高者（庙主 > 旺主 > 三分性主 > 界主 > 面主）
+func AlmutenOfDegree(longitude float64, sect models.Sect, terms models.TermSystem) models.PlanetID {
+	longitude = NormalizeAngle(longitude)
+	sign := GetZodiacByLongitude(longitude).ID
+	rulers := TriplicityRulers[GetZodiacInfo(sign).Element]
+
+	points := make(map[models.PlanetID]float64, len(ClassicalPlanets))
+	var order []models.PlanetID
+	add := func(planet models.PlanetID, value float64) {
+		if planet == "" || value == 0 {
+			return
+		}
+		if _, ok := points[planet]; !ok {
+			order = append(order, planet)
+		}
+		points[planet] += value
+	}
+
+	add(TraditionalRulers[sign], DignityPoints[models.DignityDomicile])
+	add(exaltationRuler(sign), DignityPoints[models.DignityExaltation])
+	for _, planet := range []models.PlanetID{rulers.Day, rulers.Night, rulers.Participating} {
+		add(planet, triplicityPoints(triplicityRole(planet, sign, sect)))
+	}
+	add(TermRuler(longitude, terms), DignityPoints[models.DignityTerm])
+	add(FaceRuler(longitude), DignityPoints[models.DignityFace])
+
+	var almuten models.PlanetID
+	for _, planet := range order {
+		if almuten == "" || points[planet] > points[almuten] {
+			almuten = planet
+		}
+	}
+	return almuten
+}
+
+// ApplyEssentialDignities 按星盘的日夜区分与界体系重算行星尊贵分，返回各行星的先天尊贵
+// 只列出有尊贵体系的行星（七颗古典行星与三颗外行星）
+func ApplyEssentialDignities(planets []models.PlanetPosition, sect models.Sect, terms models.TermSystem) []models.EssentialDignity {
+	var dignities []models.EssentialDignity
+	for i := range planets {
+		if _, ok := DignityTable[planets[i].ID]; !ok {
+			continue
+		}
+		dignity := EssentialDignities(planets[i].ID, planets[i].Longitude, sect, terms)
+		planets[i].DignityScore = dignity.Score
+		dignities = append(dignities, dignity)
+	}
+	return dignities
+}
+
+// dignityValue 尊贵分换算为因子基础值
+func dignityValue(score float64) float64 {
+	return score * dignityPointScale
+}
@@ -0,0 +1,114 @@
+package astro
+
+import (
+	"math"
+	"star/models"
+	"testing"
+)
+
+// TestEssentialDignities 测试三分性、界、面与游离的计分
+func TestEssentialDignities(t *testing.T) {
+	cases := []struct {
+		name      string
+		planet    models.PlanetID
+		longitude float64
+		sect      models.Sect
+		terms     models.TermSystem
+		score     float64
+		almuten   models.PlanetID
+	}{
+		// 双鱼 10°：旺相 4 + 日间水象三分性 3 + 埃及界 2；面为木星
+		{"金星双鱼日间", models.Venus, 340, models.SectDay, models.TermsEgyptian, 9, models.Venus},
+		// 夜间三分性主星为火星，金星不再计三分性
+		{"金星双鱼夜间", models.Venus, 340, models.SectNight, models.TermsEgyptian, 6, models.Jupiter},
+		// 狮子 5°：界为木星、面为土星，水星没有任何尊贵
+		{"水星狮子游离", models.Mercury, 125, models.SectDay, models.TermsEgyptian, -5, models.Sun},
+		// 落陷 −5 + 协同三分性 1 + 面 1
+		{"土星狮子落陷", models.Saturn, 125, models.SectNight, models.TermsEgyptian, -3, models.Sun},
+		// 双子 22°：埃及界为火星，托勒密界为土星
+		{"土星双子托勒密界", models.Saturn, 82, models.SectDay, models.TermsPtolemaic, 5, models.Mercury},
+		{"土星双子埃及界", models.Saturn, 82, models.SectDay, models.TermsEgyptian, 3, models.Mercury},
+		// 外行星只有现代守护与旺相，不计游离
+		{"天王星水瓶入庙", models.Uranus, 310, models.SectDay, models.TermsEgyptian, 5, models.Saturn},
+		{"海王星白羊", models.Neptune, 10, models.SectDay, models.TermsEgyptian, 0, models.Sun},
+	}
+
+	for _, tc := range cases {
+		d := EssentialDignities(tc.planet, tc.longitude, tc.sect, tc.terms)
+		if d.Score != tc.score {
+			t.Errorf("%s: 尊贵分应为 %+.0f，实际 %+.0f %v", tc.name, tc.score, d.Score, d.Dignities)
+		}
+		if d.Almuten != tc.almuten {
+			t.Errorf("%s: 度数胜利星应为 %s，实际 %s", tc.name, tc.almuten, d.Almuten)
+		}
+	}
+}
+
+// TestAlmutenOfDegree 测试度数胜利星与同分时的取舍
+func TestAlmutenOfDegree(t *testing.T) {
+	// 白羊 15°，日间：太阳 旺 4 + 三分性 3 + 面 1 = 8，胜过庙主火星 5
+	if got := AlmutenOfDegree(15, models.SectDay, models.TermsEgyptian); got != models.Sun {
+		t.Errorf("白羊 15° 日间胜利星应为太阳，实际 %s", got)
+	}
+	// 夜间：太阳 旺 4 + 面 1 = 5 与火星同分，取等级更高的庙主
+	if got := AlmutenOfDegree(15, models.SectNight, models.TermsEgyptian); got != models.Mars {
+		t.Errorf("白羊 15° 夜间胜利星应为火星，实际 %s", got)
+	}
+	for lon, want := range map[float64]models.PlanetID{5: models.Mars, 15: models.Sun, 25: models.Venus, 355: models.Mars} {
+		if got := FaceRuler(lon); got != want {
+			t.Errorf("%.0f° 的面主星应为 %s，实际 %s", lon, want, got)
+		}
+	}
+}
+
+// TestTransitDignityScore 测试行运尊贵分以行星的平均分为零点
+func TestTransitDignityScore(t *testing.T) {
+	for _, planet := range []models.PlanetID{models.Sun, models.Mercury, models.Saturn} {
+		// 沿黄道均匀取样的行运尊贵分平均为零，游离不再使每日评分整体偏负
+		sum, n := 0.0, 0
+		for lon := 0.5; lon < 360; lon++ {
+			sum += TransitDignityScore(EssentialDignities(planet, lon, "", models.TermsEgyptian), models.TermsEgyptian)
+			n++
+		}
+		if mean := sum / float64(n); math.Abs(mean) > 0.05 {
+			t.Errorf("%s 行运尊贵分的平均值应接近 0，实际 %.3f", planet, mean)
+		}
+	}
+
+	// 水星狮子游离：Lilly −5，高于水星的平均分仍为负，但比 −5 温和
+	peregrine := TransitDignityScore(EssentialDignities(models.Mercury, 125, "", models.TermsEgyptian), models.TermsEgyptian)
+	if peregrine >= 0 || peregrine <= -5 {
+		t.Errorf("游离的水星应略低于平均，实际 %+.2f", peregrine)
+	}
+	if domicile := TransitDignityScore(EssentialDignities(models.Mercury, 65, "", models.TermsEgyptian), models.TermsEgyptian); domicile <= 0 {
+		t.Errorf("入庙的水星应高于平均，实际 %+.2f", domicile)
+	}
+}
+
+// TestNatalChartDignities 测试本命盘按日夜区分与界体系计算尊贵分
+func TestNatalChartDignities(t *testing.T) {
+	useProvider(t, NewPreciseProvider())
+
+	birth := models.BirthData{
+		Year: 1990, Month: 6, Day: 15, Hour: 14, Minute: 30,
+		Latitude: 39.9042, Longitude: 116.4074, Timezone: 8,
+	}
+	chart := CalculateNatalChart(birth)
+	if chart.BirthData.Terms != models.TermsEgyptian {
+		t.Errorf("默认界体系应为埃及界，实际 %s", chart.BirthData.Terms)
+	}
+	if len(chart.Dignities) != 10 {
+		t.Fatalf("应列出十颗行星的先天尊贵，实际 %d", len(chart.Dignities))
+	}
+	for _, d := range chart.Dignities {
+		p := GetPlanetFromChart(chart, d.Planet)
+		if want := EssentialDignities(d.Planet, p.Longitude, chart.Sect, models.TermsEgyptian).Score; p.DignityScore != want || d.Score != want {
+			t.Errorf("%s 尊贵分应为 %+.0f（%s），实际 %+.0f", d.Planet, want, chart.Sect, p.DignityScore)
+		}
+	}
+
+	birth.Terms = "chaldean"
+	if err := ValidateBirthData(birth); err == nil {
+		t.Error("不支持的界体系应被拒绝")
+	}
+}
@@ -128,6 +128,94 @@ var PlanetDimensionMapping = map[models.PlanetID]models.DimensionImpact{
 		Finance:      0.05,
 		Spiritual:    0.35,
 	},
+
+	// 南交点：过往积累、需要释放的习性
+	// 灵性(业力释放)0.45, 关系(旧有关系模式)0.20
+	models.SouthNode: {
+		Career:       0.10,
+		Relationship: 0.20,
+		Health:       0.15,
+		Finance:      0.10,
+		Spiritual:    0.45,
+	},
+
+	// 平交点：与北交点相同
+	models.MeanNode: {
+		Career:       0.15,
+		Relationship: 0.20,
+		Health:       0.10,
+		Finance:      0.10,
+		Spiritual:    0.45,
+	},
+
+	// 谷神星：滋养、照护、丰收
+	// 健康(身体照护)0.35, 关系(养育)0.25, 财务(丰收)0.20
+	models.Ceres: {
+		Career:       0.10,
+		Relationship: 0.25,
+		Health:       0.35,
+		Finance:      0.20,
+		Spiritual:    0.10,
+	},
+
+	// 智神星：智慧、策略、创造性思维
+	// 事业(策略)0.45, 灵性(洞见)0.20
+	models.Pallas: {
+		Career:       0.45,
+		Relationship: 0.10,
+		Health:       0.10,
+		Finance:      0.15,
+		Spiritual:    0.20,
+	},
+
+	// 婚神星：承诺、婚姻与伴侣关系
+	// 关系(婚姻)0.55
+	models.Juno: {
+		Career:       0.10,
+		Relationship: 0.55,
+		Health:       0.05,
+		Finance:      0.15,
+		Spiritual:    0.15,
+	},
+
+	// 灶神星：专注、奉献、神圣的火焰
+	// 事业(专注工作)0.30, 灵性(奉献)0.35
+	models.Vesta: {
+		Career:       0.30,
+		Relationship: 0.10,
+		Health:       0.15,
+		Finance:      0.10,
+		Spiritual:    0.35,
+	},
+
+	// 黑月莉莉丝：被压抑的本能、欲望与反叛
+	// 关系(欲望与禁忌)0.35, 灵性(阴影)0.35
+	models.Lilith: {
+		Career:       0.10,
+		Relationship: 0.35,
+		Health:       0.15,
+		Finance:      0.05,
+		Spiritual:    0.35,
+	},
+
+	// 密切莉莉丝：与平莉莉丝相同
+	models.OscLilith: {
+		Career:       0.10,
+		Relationship: 0.35,
+		Health:       0.15,
+		Finance:      0.05,
+		Spiritual:    0.35,
+	},
+
+	// 阋神星：纷争、被排斥者的觉醒
+	// 事业(竞争)0.30, 关系(冲突)0.25, 灵性(觉醒)0.30
+	models.Eris: {
+		Career:       0.30,
+		Relationship: 0.25,
+		Health:       0.10,
+		Finance:      0.05,
+		Spiritual:    0.30,
+	},
 }
 
 // GetPlanetDimensionImpact 获取行星的维度影响分配
@@ -135,6 +223,9 @@ func GetPlanetDimensionImpact(planet models.PlanetID) models.DimensionImpact {
 	if impact, ok := PlanetDimensionMapping[planet]; ok {
 		return impact
 	}
+	if lot := lotDefinition(planet); lot != nil && lot.Dimensions != nil {
+		return *lot.Dimensions
+	}
 	// 默认平均分配
 	return models.DimensionImpact{
 		Career:       0.20,
@@ -147,7 +238,7 @@ func GetPlanetDimensionImpact(planet models.PlanetID) models.DimensionImpact {
 
 // ==================== 默认维度权重 ====================
 
-// DefaultDimensionWeights 默认维度权重
+// DefaultDimensionWeights 默认维度权重（运行时调整见 ScoringConfig）
 var DefaultDimensionWeights = models.DimensionWeights{
 	Career:       0.25, // 事业权重最高（10宫天顶）
 	Relationship: 0.20, // 关系（7宫对宫）
@@ -171,6 +262,12 @@ var FactorTimeLevelMapping = map[models.InfluenceFactorType]models.FactorTimeLev
 	models.FactorVoidOfCourse:   models.TimeLevelHourly,  // 月亮空亡为小时级
 	models.FactorPersonal:       models.TimeLevelDaily,   // 个人因子默认日级
 	models.FactorCustom:         models.TimeLevelHourly,  // 自定义因子可配置
+	models.FactorParallel:       models.TimeLevelDaily,   // 赤纬平行与相位同为日级
+	models.FactorOutOfBounds:    models.TimeLevelWeekly,  // 出界持续数天到数月（月亮为日级）
+	models.FactorIngress:        models.TimeLevelMonthly, // 外行星换座为月度级（个人行星为日级）
+	models.FactorEclipse:        models.TimeLevelYearly,  // 食相触发四轴、日月或命主星为年度级（其余为月度级）
+	models.FactorFixedStar:      models.TimeLevelYearly,  // 慢行星在恒星 1° 内停留数月至数年
+	models.FactorAccidental:     models.TimeLevelDaily,   // 此刻的宫位、焦伤与速度
 }
 
 // GetFactorTimeLevel 获取因子的时间级别
@@ -224,3 +321,30 @@ func GetDimensionForHouseV2(house int) string {
 	return "spiritual"
 }
 
+// EmphasizeDimension 增强某一维度的影响后重新归一化
+func EmphasizeDimension(impact models.DimensionImpact, dimension string, boost float64) models.DimensionImpact {
+	switch dimension {
+	case "career":
+		impact.Career += boost
+	case "relationship":
+		impact.Relationship += boost
+	case "health":
+		impact.Health += boost
+	case "finance":
+		impact.Finance += boost
+	case "spiritual":
+		impact.Spiritual += boost
+	}
+
+	// 归一化
+	total := impact.Career + impact.Relationship + impact.Health + impact.Finance + impact.Spiritual
+	if total > 0 {
+		impact.Career /= total
+		impact.Relationship /= total
+		impact.Health /= total
+		impact.Finance /= total
+		impact.Spiritual /= total
+	}
+	return impact
+}
+
@@ -0,0 +1,282 @@
+package astro
+
+import (
+	"star/models"
+)
+
+// ==================== 互容与定位星 ====================
+// 定位星为行星所在星座的古典守护星。沿定位星逐级追溯，终止于入庙而自我定位的行星（最终定位星），
+// 或回到已出现的行星形成循环（两星循环即入庙互容）。
+// 互容：两颗古典行星各在对方守护（domicile）或旺相（exaltation）的星座，一方守护、一方旺相为混合互容（mixed）
+
+// 互容类型
+const (
+	ReceptionDomicile   = "domicile"
+	ReceptionExaltation = "exaltation"
+	ReceptionMixed      = "mixed"
+)
+
+// ReceptionPoints 互容对行星状态的加分：互容的行星如同回到自己的星座
+var ReceptionPoints = map[string]float64{
+	ReceptionDomicile:   DignityPoints[models.DignityDomicile],
+	ReceptionExaltation: DignityPoints[models.DignityExaltation],
+	ReceptionMixed:      DignityPoints[models.DignityExaltation],
+}
+
+// receivedBy 行星所在星座由 host 守护或旺相时返回接纳方式（守护优先），否则为空
+func receivedBy(p models.PlanetPosition, host models.PlanetID) string {
+	switch host {
+	case TraditionalRulers[p.Sign]:
+		return ReceptionDomicile
+	case exaltationRuler(p.Sign):
+		return ReceptionExaltation
+	}
+	return ""
+}
+
+// receptionType 两颗行星的互容类型，不互容时为空
+func receptionType(a, b models.PlanetPosition) string {
+	ra, rb := receivedBy(a, b.ID), receivedBy(b, a.ID)
+	switch {
+	case ra == "" || rb == "":
+		return ""
+	case ra == rb:
+		return ra
+	}
+	return ReceptionMixed
+}
+
+// MutualReceptions 找出古典行星之间的互容
+func MutualReceptions(planets []models.PlanetPosition) []models.MutualReception {
+	var receptions []models.MutualReception
+	for i := range planets {
+		if !isClassicalPlanet(planets[i].ID) {
+			continue
+		}
+		for j := i + 1; j < len(planets); j++ {
+			if !isClassicalPlanet(planets[j].ID) || planets[i].ID == planets[j].ID {
+				continue
+			}
+			if t := receptionType(planets[i], planets[j]); t != "" {
+				receptions = append(receptions, models.MutualReception{
+					Planet1: planets[i].ID,
+					Planet2: planets[j].ID,
+					Type:    t,
+				})
+			}
+		}
+	}
+	return receptions
+}
+
+// findReception 查找两颗行星之间的互容
+func findReception(receptions []models.MutualReception, a, b models.PlanetID) string {
+	for _, r := range receptions {
+		if (r.Planet1 == a && r.Planet2 == b) || (r.Planet1 == b && r.Planet2 == a) {
+			return r.Type
+		}
+	}
+	return ""
+}
+
+// CalculateDispositors 构建定位星树：太阳至冥王星各自的定位链、最终定位星、定位循环与互容
+func CalculateDispositors(planets []models.PlanetPosition) *models.DispositorTree {
+	tree := &models.DispositorTree{Receptions: MutualReceptions(planets)}
+
+	dispositor := map[models.PlanetID]models.PlanetID{}
+	var ids []models.PlanetID
+	for _, p := range planets {
+		if _, ok := MeanDailyMotion[p.ID]; !ok {
+			continue
+		}
+		if _, seen := dispositor[p.ID]; seen {
+			continue
+		}
+		dispositor[p.ID] = TraditionalRulers[p.Sign]
+		ids = append(ids, p.ID)
+	}
+
+	finals := map[models.PlanetID]bool{}
+	loops := map[models.PlanetID]bool{}
+	complete := true
+	for _, id := range ids {
+		node := models.DispositorNode{Planet: id, Dispositor: dispositor[id], Chain: []models.PlanetID{}}
+		for _, other := range ids {
+			if other != id && dispositor[other] == id {
+				node.Disposes = append(node.Disposes, other)
+			}
+		}
+
+		// 逐级追溯定位星
+		visited := map[models.PlanetID]bool{id: true}
+		current := id
+		for {
+			next, ok := dispositor[current]
+			if !ok {
+				complete = false // 定位星不在星盘中
+				break
+			}
+			if next == current {
+				node.Final = current
+				break
+			}
+			if visited[next] {
+				break
+			}
+			node.Chain = append(node.Chain, next)
+			visited[next] = true
+			current = next
+		}
+
+		switch {
+		case dispositor[id] == id:
+			tree.SelfDisposited = append(tree.SelfDisposited, id)
+			finals[id] = true
+		case node.Final == "" && onLoop(dispositor, id) && !loops[id]:
+			loop := loopFrom(dispositor, id)
+			for _, member := range loop {
+				loops[member] = true
+			}
+			tree.Loops = append(tree.Loops, loop)
+		}
+		if node.Final == "" {
+			complete = false
+		}
+		tree.Nodes = append(tree.Nodes, node)
+	}
+
+	if complete && len(finals) == 1 {
+		tree.FinalDispositor = tree.SelfDisposited[0]
+	}
+	return tree
+}
+
+// onLoop 行星是否位于长度 ≥ 2 的定位循环上
+func onLoop(dispositor map[models.PlanetID]models.PlanetID, id models.PlanetID) bool {
+	current := id
+	for range dispositor {
+		next, ok := dispositor[current]
+		if !ok || next == current {
+			return false
+		}
+		if next == id {
+			return true
+		}
+		current = next
+	}
+	return false
+}
+
+// loopFrom 从行星出发的定位循环
+func loopFrom(dispositor map[models.PlanetID]models.PlanetID, id models.PlanetID) []models.PlanetID {
+	loop := []models.PlanetID{id}
+	for next := dispositor[id]; next != id; next = dispositor[next] {
+		loop = append(loop, next)
+	}
+	return loop
+}
+
+// dispositorNode 查找行星的定位星节点
+func dispositorNode(tree *models.DispositorTree, id models.PlanetID) *models.DispositorNode {
+	if tree == nil {
+		return nil
+	}
+	for i := range tree.Nodes {
+		if tree.Nodes[i].Planet == id {
+			return &tree.Nodes[i]
+		}
+	}
+	return nil
+}
+
+// ==================== 命主星 ====================
+
+// AnalyzeChartRuler 分析命主星：上升星座的古典守护星，双鱼、水瓶、天蝎另以现代守护星为候选。
+// 状态 = 先天尊贵分 + 后天尊贵分 + 与定位星互容的加分 + 定位星先天尊贵分 × 0.5（入庙时定位星即自身）。
+// 外行星不计三分性、界、面、游离、日光、东出西入、日夜与互容，完整状态与古典行星不可比，
+// 两个候选之间只按双方共有的项（见 sharedRulerStrength）比较，现代守护星严格更强时才取代古典守护星
+func AnalyzeChartRuler(ascendant float64, planets []models.PlanetPosition, tree *models.DispositorTree) *models.ChartRulerInfo {
+	sign := GetZodiacByLongitude(ascendant)
+	if sign == nil {
+		return nil
+	}
+	candidates := []models.PlanetID{TraditionalRulers[sign.ID]}
+	if sign.Ruler != candidates[0] {
+		candidates = append(candidates, sign.Ruler)
+	}
+
+	var best *models.ChartRulerInfo
+	bestShared := 0.0
+	for _, id := range candidates {
+		p := findPosition(planets, id)
+		if p == nil {
+			continue
+		}
+		info := &models.ChartRulerInfo{
+			Planet:       id,
+			Candidates:   candidates,
+			Sign:         p.Sign,
+			House:        p.House,
+			DignityScore: p.DignityScore,
+		}
+		if p.Accidental != nil {
+			info.AccidentalScore = p.Accidental.Score
+		}
+		info.Strength = info.DignityScore + info.AccidentalScore
+		support := 0.0
+
+		if node := dispositorNode(tree, id); node != nil {
+			info.DispositorChain = node.Chain
+			info.FinalDispositor = node.Final
+			info.Reception = findReception(tree.Receptions, id, node.Dispositor)
+			info.Strength += ReceptionPoints[info.Reception]
+			// 入庙的行星以自身为定位星
+			if d := findPosition(planets, node.Dispositor); d != nil {
+				support = dignityValue(d.DignityScore)
+				info.Strength += support
+			}
+		}
+
+		shared := sharedRulerStrength(*p) + support
+		if best == nil || shared > bestShared {
+			best, bestShared = info, shared
+		}
+	}
+	return best
+}
+
+// sharedRulerStrength 古典与现代守护星共有的状态项：星座层面的入庙、旺相、落陷、失势，以及宫位与速度的后天尊贵
+func sharedRulerStrength(p models.PlanetPosition) float64 {
+	strength := 0.0
+	for _, dignity := range []models.Dignity{models.DignityDomicile, models.DignityExaltation, models.DignityDetriment, models.DignityFall} {
+		for _, s := range DignityTable[p.ID][dignity] {
+			if s == p.Sign {
+				strength += DignityPoints[dignity]
+			}
+		}
+	}
+	if p.Accidental != nil {
+		strength += AccidentalPoints[p.Accidental.House] + AccidentalPoints[p.Accidental.Motion]
+	}
+	return strength
+}
+
+// dispositorBonus 主导行星判断中定位星树的加分：最终定位星 +3、每直接定位一颗行星 +0.5、每组互容 +1
+func dispositorBonus(tree *models.DispositorTree, id models.PlanetID) float64 {
+	if tree == nil {
+		return 0
+	}
+	bonus := 0.0
+	if tree.FinalDispositor == id {
+		bonus += 3
+	}
+	if node := dispositorNode(tree, id); node != nil {
+		bonus += 0.5 * float64(len(node.Disposes))
+	}
+	for _, r := range tree.Receptions {
+		if r.Planet1 == id || r.Planet2 == id {
+			bonus++
+		}
+	}
+	return bonus
+}
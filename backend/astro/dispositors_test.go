@@ -0,0 +1,105 @@
+package astro
+
+import (
+	"reflect"
+	"star/models"
+	"testing"
+)
+
+// TestDispositorTree 测试互容类型、定位链、循环与最终定位星
+func TestDispositorTree(t *testing.T) {
+	at := func(id models.PlanetID, lon float64) models.PlanetPosition {
+		return newPlanetPosition(id, lon, 0, false)
+	}
+	planets := []models.PlanetPosition{
+		at(models.Sun, 130),     // 狮子，自我定位
+		at(models.Moon, 40),     // 金牛 → 金星
+		at(models.Mercury, 100), // 巨蟹 → 月亮，木星旺相于巨蟹
+		at(models.Venus, 10),    // 白羊 → 火星
+		at(models.Mars, 190),    // 天秤 → 金星
+		at(models.Jupiter, 160), // 处女 → 水星
+		at(models.Saturn, 140),  // 狮子 → 太阳
+	}
+	tree := CalculateDispositors(planets)
+
+	wantReceptions := []models.MutualReception{
+		{Planet1: models.Mercury, Planet2: models.Jupiter, Type: ReceptionMixed},
+		{Planet1: models.Venus, Planet2: models.Mars, Type: ReceptionDomicile},
+	}
+	if !reflect.DeepEqual(tree.Receptions, wantReceptions) {
+		t.Errorf("互容应为 %+v，实际 %+v", wantReceptions, tree.Receptions)
+	}
+	if !reflect.DeepEqual(tree.Loops, [][]models.PlanetID{{models.Venus, models.Mars}}) {
+		t.Errorf("金星与火星应构成定位循环，实际 %v", tree.Loops)
+	}
+	if tree.FinalDispositor != "" || !reflect.DeepEqual(tree.SelfDisposited, []models.PlanetID{models.Sun}) {
+		t.Errorf("存在循环时不应有最终定位星: %q %v", tree.FinalDispositor, tree.SelfDisposited)
+	}
+	jupiter := dispositorNode(tree, models.Jupiter)
+	if !reflect.DeepEqual(jupiter.Chain, []models.PlanetID{models.Mercury, models.Moon, models.Venus, models.Mars}) || jupiter.Final != "" {
+		t.Errorf("木星的定位链应经水星、月亮进入金火循环: %+v", jupiter)
+	}
+	if saturn := dispositorNode(tree, models.Saturn); saturn.Final != models.Sun {
+		t.Errorf("土星的定位链应终止于太阳: %+v", saturn)
+	}
+
+	// 所有定位链终止于太阳
+	for i := range planets {
+		if planets[i].ID != models.Venus && planets[i].ID != models.Mars {
+			continue
+		}
+		planets[i] = at(planets[i].ID, 125)
+	}
+	planets[1], planets[2], planets[5] = at(models.Moon, 135), at(models.Mercury, 15), at(models.Jupiter, 145)
+	tree = CalculateDispositors(append(planets, at(models.Uranus, 20)))
+	if tree.FinalDispositor != models.Sun || len(tree.Loops) != 0 {
+		t.Errorf("最终定位星应为太阳: %+v", tree)
+	}
+	if uranus := dispositorNode(tree, models.Uranus); !reflect.DeepEqual(uranus.Chain, []models.PlanetID{models.Mars, models.Sun}) {
+		t.Errorf("天王星的定位链应为 火星 → 太阳，实际 %v", uranus.Chain)
+	}
+	if sun := dispositorNode(tree, models.Sun); len(sun.Disposes) != 5 {
+		t.Errorf("太阳应直接定位五颗行星，实际 %v", sun.Disposes)
+	}
+}
+
+// TestChartRuler 测试命主星在古典与现代守护星之间按状态选择
+func TestChartRuler(t *testing.T) {
+	mars := newPlanetPosition(models.Mars, 100, 0, false) // 巨蟹失势
+	pluto := newPlanetPosition(models.Pluto, 215, 0, false)
+	moon := newPlanetPosition(models.Moon, 40, 0, false)
+	for _, p := range []*models.PlanetPosition{&mars, &pluto, &moon} {
+		p.DignityScore = EssentialDignities(p.ID, p.Longitude, models.SectDay, models.TermsEgyptian).Score
+	}
+	planets := []models.PlanetPosition{mars, pluto, moon}
+	tree := CalculateDispositors(planets)
+
+	// 天蝎上升：失势的火星不敌入庙的冥王星
+	info := AnalyzeChartRuler(225, planets, tree)
+	if info == nil || info.Planet != models.Pluto || !reflect.DeepEqual(info.Candidates, []models.PlanetID{models.Mars, models.Pluto}) {
+		t.Fatalf("天蝎上升时命主星应为冥王星: %+v", info)
+	}
+	if GetChartRuler(225, planets, tree) != models.Pluto {
+		t.Error("GetChartRuler 应与 AnalyzeChartRuler 一致")
+	}
+
+	// 火星回到白羊入庙后与冥王星同分，取古典守护星
+	planets[0] = newPlanetPosition(models.Mars, 10, 0, false)
+	planets[0].DignityScore = EssentialDignities(models.Mars, 10, models.SectDay, models.TermsEgyptian).Score
+	if info := AnalyzeChartRuler(225, planets, CalculateDispositors(planets)); info.Planet != models.Mars || info.FinalDispositor != models.Mars {
+		t.Errorf("入庙的火星应为命主星且自我定位: %+v", info)
+	}
+
+	// 双子座的火星游离（−5），冥王星在双子没有尊贵（外行星不计游离）：共有项同分，仍取古典守护星
+	planets[0] = newPlanetPosition(models.Mars, 62, 0, false)
+	planets[0].DignityScore = EssentialDignities(models.Mars, 62, models.SectDay, models.TermsEgyptian).Score
+	planets[1] = newPlanetPosition(models.Pluto, 75, 0, false)
+	if info := AnalyzeChartRuler(225, planets, CalculateDispositors(planets)); info.Planet != models.Mars || info.DignityScore != -5 {
+		t.Errorf("游离不应使现代守护星胜出: %+v", info)
+	}
+
+	// 星盘中没有候选行星时取上升星座的守护星
+	if got := GetChartRuler(15, nil, nil); got != models.Mars {
+		t.Errorf("白羊上升应返回火星，实际 %s", got)
+	}
+}
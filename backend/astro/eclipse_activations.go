@@ -0,0 +1,267 @@
+package astro
+
+import (
+	"fmt"
+	"math"
+	"star/models"
+	"time"
+)
+
+// ==================== 日月食触发本命 ====================
+// 食点（日食为太阳黄经，月食为月亮黄经）与本命行星合相或对冲、或落在四轴上时视为触发，
+// 影响自食前两周开始，食甚时最强，之后约六个月内逐渐消退
+
+// eclipseOrbs 食点触发本命点的容许度
+var eclipseOrbs = map[EclipseKind]float64{
+	SolarEclipse: 5,
+	LunarEclipse: 3,
+}
+
+// eclipseBaseValues 日食与月食的基础值（日食影响更长、更强）
+var eclipseBaseValues = map[EclipseKind]float64{
+	SolarEclipse: 3.0,
+	LunarEclipse: 2.5,
+}
+
+// eclipseTypeStrength 食相类型的强度系数
+var eclipseTypeStrength = map[string]float64{
+	EclipseTotal:     1.0,
+	EclipseAnnular:   1.0,
+	EclipseHybrid:    1.0,
+	EclipsePartial:   0.75,
+	EclipsePenumbral: 0.5,
+}
+
+// eclipseTone 被触发天体的吉凶倾向；未列出的天体与四轴为 -1（食相以动荡与转折为主）
+var eclipseTone = map[models.PlanetID]float64{
+	models.Venus:   0.5,
+	models.Jupiter: 0.5,
+	models.Mars:    -1.5,
+	models.Saturn:  -1.5,
+	models.Pluto:   -1.5,
+}
+
+// 食相影响窗口：食前两周起，食后六个月止
+const (
+	eclipseLeadDays    = 14
+	eclipseDecayMonths = 6
+)
+
+// EclipseActivation 一次日月食触发的本命点
+type EclipseActivation struct {
+	Eclipse          EclipseInfo       `json:"eclipse"`
+	EclipseLongitude float64           `json:"eclipseLongitude"` // 按本命盘黄道模式的食点黄经
+	EclipseSign      models.ZodiacID   `json:"eclipseSign"`
+	House            int               `json:"house"` // 食点落入的本命宫位
+	Point            string            `json:"point"` // 行星 ID 或 ascendant/descendant/midheaven/imumCoeli
+	PointName        string            `json:"pointName"`
+	Planet           models.PlanetID   `json:"planet,omitempty"`
+	Aspect           models.AspectType `json:"aspect"` // conjunction / opposition
+	Orb              float64           `json:"orb"`
+	RulesHouses      []int             `json:"rulesHouses,omitempty"` // 被触发行星守护的本命宫位
+	ChartRuler       bool              `json:"chartRuler,omitempty"`
+	Start            time.Time         `json:"start"`
+	End              time.Time         `json:"end"`
+}
+
+// natalPoint 可被食相触发的本命点
+type natalPoint struct {
+	id        string
+	name      string
+	planet    models.PlanetID
+	longitude float64
+	angle     bool
+}
+
+// natalEclipsePoints 本命行星与四轴
+func natalEclipsePoints(chart *models.NatalChart) []natalPoint {
+	var points []natalPoint
+	for _, p := range chart.Planets {
+		points = append(points, natalPoint{id: string(p.ID), name: "natal " + p.Name, planet: p.ID, longitude: p.Longitude})
+	}
+	points = append(points,
+		natalPoint{id: "ascendant", name: "Ascendant", longitude: chart.Ascendant, angle: true},
+		natalPoint{id: "descendant", name: "Descendant", longitude: NormalizeAngle(chart.Ascendant + 180), angle: true},
+		natalPoint{id: "midheaven", name: "Midheaven", longitude: chart.Midheaven, angle: true},
+		natalPoint{id: "imumCoeli", name: "IC", longitude: NormalizeAngle(chart.Midheaven + 180), angle: true},
+	)
+	return points
+}
+
+// FindEclipseActivations 求 [start, end) 内触发本命点的日月食，按食甚时间排序
+func FindEclipseActivations(chart *models.NatalChart, start, end time.Time) []EclipseActivation {
+	return eclipseActivations(chart, FindEclipses(start, end))
+}
+
+// eclipseActivations 逐个食相匹配本命点
+func eclipseActivations(chart *models.NatalChart, eclipses []EclipseInfo) []EclipseActivation {
+	var activations []EclipseActivation
+	points := natalEclipsePoints(chart)
+
+	for _, eclipse := range eclipses {
+		lon := NormalizeAngle(eclipse.Longitude - ChartZodiacOffset(chart, eclipse.MaximumJD))
+		maxOrb := eclipseOrbs[eclipse.Kind]
+
+		for _, point := range points {
+			aspect := models.Conjunction
+			orb := AngleDifference(lon, point.longitude)
+			// 四轴以对轴点单独列出，仅计合相
+			if !point.angle && 180-orb < orb {
+				aspect = models.Opposition
+				orb = 180 - orb
+			}
+			if orb > maxOrb {
+				continue
+			}
+
+			activation := EclipseActivation{
+				Eclipse:          eclipse,
+				EclipseLongitude: lon,
+				EclipseSign:      GetZodiacByLongitude(lon).ID,
+				House:            GetPlanetHouse(lon, chart.Houses),
+				Point:            point.id,
+				PointName:        point.name,
+				Planet:           point.planet,
+				Aspect:           aspect,
+				Orb:              orb,
+				Start:            eclipse.Maximum.AddDate(0, 0, -eclipseLeadDays),
+				End:              eclipse.Maximum.AddDate(0, eclipseDecayMonths, 0),
+			}
+			if point.planet != "" {
+				activation.RulesHouses = housesRuledBy(chart, point.planet)
+				activation.ChartRuler = point.planet == chart.ChartRuler
+			}
+			activations = append(activations, activation)
+		}
+	}
+
+	return activations
+}
+
+// housesRuledBy 行星守护的本命宫位（按宫头星座的守护星）
+func housesRuledBy(chart *models.NatalChart, planet models.PlanetID) []int {
+	var houses []int
+	for _, h := range chart.Houses {
+		if GetZodiacRuler(h.Sign) == planet {
+			houses = append(houses, h.House)
+		}
+	}
+	return houses
+}
+
+// Major 是否触发重要本命点（四轴、日月或命主星），作为年度级因子
+func (a EclipseActivation) Major() bool {
+	return a.Planet == "" || a.Planet == models.Sun || a.Planet == models.Moon || a.ChartRuler
+}
+
+// ==================== 食相因子 ====================
+
+// eclipseSpan 一次缓存的食相列表覆盖的范围
+type eclipseSpan struct {
+	from, to float64
+	eclipses []EclipseInfo
+}
+
+// eclipseSpans 按星历源缓存的食相列表
+var eclipseSpans = newSpanCache[string, eclipseSpan](8)
+
+// eclipsesAffecting 影响窗口覆盖 jd 的食相（食甚在 jd 前六个月至 jd 后两周之间）
+func eclipsesAffecting(jd float64) []EclipseInfo {
+	provider := CurrentEphemerisProvider()
+	t := julianDayToTime(jd)
+	from := DateToJulianDay(t.AddDate(0, -eclipseDecayMonths, -1))
+	to := jd + eclipseLeadDays + 1
+
+	span, ok := eclipseSpans.get(provider.Name(), func(s eclipseSpan) bool {
+		return from >= s.from && to <= s.to
+	})
+	if !ok {
+		// 一次多覆盖之后一个月，按时间顺序评分时无需重复搜索
+		span = eclipseSpan{from: from, to: to + 30}
+		span.eclipses = FindEclipses(julianDayToTime(span.from), julianDayToTime(span.to))
+		eclipseSpans.put(provider.Name(), span)
+	}
+
+	var eclipses []EclipseInfo
+	for _, eclipse := range span.eclipses {
+		if eclipse.MaximumJD >= from && eclipse.MaximumJD < to {
+			eclipses = append(eclipses, eclipse)
+		}
+	}
+	return eclipses
+}
+
+// calculateEclipseFactorsV2 计算日月食触发本命点的因子
+func calculateEclipseFactorsV2(chart *models.NatalChart, weight float64, date time.Time) []models.InfluenceFactor {
+	var factors []models.InfluenceFactor
+
+	for _, a := range eclipseActivations(chart, eclipsesAffecting(DateToJulianDay(date))) {
+		if date.Before(a.Start) || date.After(a.End) {
+			continue
+		}
+
+		tone, ok := eclipseTone[a.Planet]
+		if !ok {
+			tone = -1
+		}
+		closeness := 1 - 0.5*a.Orb/eclipseOrbs[a.Eclipse.Kind]
+		value := eclipseBaseValues[a.Eclipse.Kind] * eclipseTypeStrength[a.Eclipse.Type] * closeness * tone
+		if a.Aspect == models.Opposition {
+			value *= 0.8
+		}
+
+		kindName := "Solar Eclipse"
+		source := models.Sun
+		if a.Eclipse.Kind == LunarEclipse {
+			kindName = "Lunar Eclipse"
+			source = models.Moon
+		}
+		signName := GetZodiacByLongitude(a.EclipseLongitude).Name
+
+		name := fmt.Sprintf("%s in %s on %s", kindName, signName, a.PointName)
+		if a.Aspect == models.Opposition {
+			name = fmt.Sprintf("%s in %s opposite %s", kindName, signName, a.PointName)
+		}
+
+		// 维度影响：被触发天体（四轴取对应发光体）偏向食点所在宫位及其守护的宫位
+		impactPlanet := a.Planet
+		if impactPlanet == "" {
+			impactPlanet = source
+		}
+		impact := EmphasizeDimension(GetPlanetDimensionImpact(impactPlanet), GetDimensionForHouseV2(a.House), 0.2)
+		for _, house := range a.RulesHouses {
+			impact = EmphasizeDimension(impact, GetDimensionForHouseV2(house), 0.1)
+		}
+
+		description := fmt.Sprintf("%s %s eclipse on %s at %.1f° %s (orb %.1f°), falling in natal house %d",
+			a.Eclipse.Type, a.Eclipse.Kind, a.Eclipse.Maximum.Format("2006-01-02"),
+			math.Mod(a.EclipseLongitude, 30), signName, a.Orb, a.House)
+		if len(a.RulesHouses) > 0 {
+			description += fmt.Sprintf("; the planet rules houses %v", a.RulesHouses)
+		}
+		if a.ChartRuler {
+			description += "; it is the chart ruler"
+		}
+
+		timeLevel := models.TimeLevelMonthly
+		if a.Major() {
+			timeLevel = models.TimeLevelYearly
+		}
+
+		factors = append(factors, models.InfluenceFactor{
+			Type:            models.FactorEclipse,
+			Name:            name,
+			Description:     description,
+			TimeLevel:       timeLevel,
+			Lifecycle:       CreateLifecycleWithPeak(a.Start, a.Eclipse.Maximum, a.End),
+			BaseValue:       value,
+			Weight:          weight,
+			DimensionImpact: impact,
+			SourcePlanet:    source,
+			IsPositive:      value > 0,
+			AstroReason:     "An eclipse on a natal point marks a turning point for the matters it signifies, unfolding over the following months",
+		})
+	}
+
+	return factors
+}
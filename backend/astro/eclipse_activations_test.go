@@ -0,0 +1,68 @@
+package astro
+
+import (
+	"star/models"
+	"testing"
+	"time"
+)
+
+// TestEclipseActivations 测试日月食触发本命点与六个月衰减的食相因子
+// 参考：2025-03-29 日偏食于白羊座 9°，2025-09-07 月全食于双鱼座 15°
+func TestEclipseActivations(t *testing.T) {
+	useProvider(t, NewPreciseProvider())
+
+	chart := CalculateNatalChart(models.BirthData{
+		Year: 1990, Month: 6, Day: 15, Hour: 14, Minute: 30,
+		Latitude: 39.9042, Longitude: 116.4074, Timezone: 8,
+	})
+
+	activations := FindEclipseActivations(chart, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	var mars, moon *EclipseActivation
+	for i, a := range activations {
+		t.Logf("%s %s %s %s 容许度 %.2f°", a.Eclipse.Maximum.Format("2006-01-02"), a.Eclipse.Kind, a.Aspect, a.Point, a.Orb)
+		switch a.Point {
+		case string(models.Mars):
+			mars = &activations[i]
+		case string(models.Moon):
+			moon = &activations[i]
+		}
+	}
+	if mars == nil || mars.Eclipse.Kind != SolarEclipse || mars.Aspect != models.Conjunction || mars.Major() {
+		t.Fatalf("3 月日食应合本命火星（月度级）: %+v", mars)
+	}
+	if moon == nil || moon.Eclipse.Kind != LunarEclipse || moon.Eclipse.Type != EclipseTotal || !moon.Major() {
+		t.Fatalf("9 月月全食应合本命月亮（年度级）: %+v", moon)
+	}
+	if moon.End.Sub(moon.Eclipse.Maximum) < 180*24*time.Hour {
+		t.Errorf("食相影响应持续约六个月: %s → %s", moon.Eclipse.Maximum, moon.End)
+	}
+
+	find := func(at time.Time) *models.InfluenceFactor {
+		result := buildFactorResult(calculateEclipseFactorsV2(chart, 1, at), at)
+		for i, f := range result.Factors {
+			if f.Name == "Lunar Eclipse in Pisces on natal Moon" {
+				return &result.Factors[i]
+			}
+		}
+		return nil
+	}
+
+	peak := find(moon.Eclipse.Maximum)
+	if peak == nil {
+		t.Fatal("月全食食甚时应有食相因子")
+	}
+	if peak.Type != models.FactorEclipse || peak.TimeLevel != models.TimeLevelYearly || peak.IsPositive {
+		t.Errorf("月全食合本命月亮应为年度级负面因子: %+v", peak)
+	}
+	if peak.CurrentStrength < 0.99 {
+		t.Errorf("食甚时因子强度应接近 1，实际 %.3f", peak.CurrentStrength)
+	}
+
+	later := find(moon.Eclipse.Maximum.AddDate(0, 3, 0))
+	if later == nil || later.CurrentStrength >= peak.CurrentStrength {
+		t.Errorf("食后三个月因子应仍在但减弱: %+v", later)
+	}
+	if find(moon.Eclipse.Maximum.AddDate(0, 7, 0)) != nil {
+		t.Error("食后七个月不应再有该食相因子")
+	}
+}
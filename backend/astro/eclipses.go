@@ -0,0 +1,161 @@
+package astro
+
+import (
+	"fmt"
+	"math"
+)
+
+// ==================== 日月食（解析算法） ====================
+
+// eclipseSearchLunations 向前/向后搜索食相的最大朔望月数
+const eclipseSearchLunations = 60
+
+// syzygyEclipse 单次朔望的食相计算结果
+type syzygyEclipse struct {
+	jde       float64 // 食甚（地球时儒略日）
+	eclipse   bool
+	kind      string
+	magnitude float64
+}
+
+// meeusEclipse 按 Meeus《天文算法》第 54 章计算第 k 次朔望是否发生食相
+// k 为整数时为新月（日食），k 为半整数时为满月（月食）；食甚时刻精度约数分钟
+func meeusEclipse(k float64) syzygyEclipse {
+	T := k / 1236.85
+	T2, T3, T4 := T*T, T*T*T, T*T*T*T
+
+	jde := 2451550.09766 + 29.530588861*k + 0.00015437*T2 - 0.000000150*T3 + 0.00000000073*T4
+	M := (2.5534 + 29.10535670*k - 0.0000014*T2 - 0.00000011*T3) * DEG_TO_RAD
+	Mp := (201.5643 + 385.81693528*k + 0.0107582*T2 + 0.00001238*T3 - 0.000000058*T4) * DEG_TO_RAD
+	F := (160.7108 + 390.67050284*k - 0.0016118*T2 - 0.00000227*T3 + 0.000000011*T4) * DEG_TO_RAD
+	omega := (124.7746 - 1.56375588*k + 0.0020672*T2 + 0.00000215*T3) * DEG_TO_RAD
+	E := 1 - 0.002516*T - 0.0000074*T2
+
+	// 月亮纬度幅角远离交点时不可能发生食相
+	if math.Abs(math.Sin(F)) > 0.36 {
+		return syzygyEclipse{jde: jde}
+	}
+
+	F1 := F - 0.02665*math.Sin(omega)*DEG_TO_RAD
+	A1 := (299.77 + 0.107408*k - 0.009173*T2) * DEG_TO_RAD
+
+	solar := k == math.Floor(k)
+	if solar {
+		jde += -0.4075*math.Sin(Mp) + 0.1721*E*math.Sin(M)
+	} else {
+		jde += -0.4065*math.Sin(Mp) + 0.1727*E*math.Sin(M)
+	}
+	jde += 0.0161*math.Sin(2*Mp) - 0.0097*math.Sin(2*F1) +
+		0.0073*E*math.Sin(Mp-M) - 0.0050*E*math.Sin(Mp+M) -
+		0.0023*math.Sin(Mp-2*F1) + 0.0021*E*math.Sin(2*M) +
+		0.0012*math.Sin(Mp+2*F1) + 0.0006*E*math.Sin(2*Mp+M) -
+		0.0004*math.Sin(3*Mp) - 0.0003*E*math.Sin(M+2*F1) +
+		0.0003*math.Sin(A1) - 0.0002*E*math.Sin(M-2*F1) -
+		0.0002*E*math.Sin(2*Mp-M) - 0.0002*math.Sin(omega)
+
+	P := 0.2070*E*math.Sin(M) + 0.0024*E*math.Sin(2*M) - 0.0392*math.Sin(Mp) +
+		0.0116*math.Sin(2*Mp) - 0.0073*E*math.Sin(Mp+M) + 0.0067*E*math.Sin(Mp-M) +
+		0.0118*math.Sin(2*F1)
+	Q := 5.2207 - 0.0048*E*math.Cos(M) + 0.0020*E*math.Cos(2*M) - 0.3299*math.Cos(Mp) -
+		0.0060*E*math.Cos(Mp+M) + 0.0041*E*math.Cos(Mp-M)
+	W := math.Abs(math.Cos(F1))
+	gamma := (P*math.Cos(F1) + Q*math.Sin(F1)) * (1 - 0.0048*W)
+	u := 0.0059 + 0.0046*E*math.Cos(M) - 0.0182*math.Cos(Mp) + 0.0004*math.Cos(2*Mp) -
+		0.0005*math.Cos(M+Mp)
+	absGamma := math.Abs(gamma)
+
+	result := syzygyEclipse{jde: jde}
+	if solar {
+		if absGamma > 1.5433+u {
+			return result
+		}
+		result.eclipse = true
+		if absGamma < 0.9972 {
+			// 中心食：按视半径比给出食分
+			result.magnitude = moonSunDiameterRatio(M, Mp, E, 0)
+			switch {
+			case u < 0:
+				result.kind = EclipseTotal
+			case u > 0.0047:
+				result.kind = EclipseAnnular
+			case u < 0.00464*math.Sqrt(1-gamma*gamma):
+				result.kind = EclipseHybrid
+			default:
+				result.kind = EclipseAnnular
+			}
+			return result
+		}
+		result.kind = EclipsePartial
+		result.magnitude = (1.5433 + u - absGamma) / (0.5461 + 2*u)
+		return result
+	}
+
+	penumbral := (1.5573 + u - absGamma) / 0.5450
+	umbral := (1.0128 - u - absGamma) / 0.5450
+	switch {
+	case penumbral <= 0:
+		return result
+	case umbral >= 1:
+		result.kind = EclipseTotal
+		result.magnitude = umbral
+	case umbral > 0:
+		result.kind = EclipsePartial
+		result.magnitude = umbral
+	default:
+		result.kind = EclipsePenumbral
+		result.magnitude = penumbral
+	}
+	result.eclipse = true
+	return result
+}
+
+// moonSunDiameterRatio 朔望时月亮与太阳的视直径之比
+// 月地距离取 Meeus 第 47 章的主要项，日地距离按地球轨道偏心率计算
+func moonSunDiameterRatio(M, Mp, E, D float64) float64 {
+	moonDistance := 385000.56 - 20905.355*math.Cos(Mp) - 3699.111*math.Cos(2*D-Mp) -
+		2955.968*math.Cos(2*D) - 569.925*math.Cos(2*Mp) + 48.888*E*math.Cos(M) +
+		246.158*math.Cos(2*D-2*Mp) - 152.138*E*math.Cos(2*D-M-Mp) -
+		170.733*math.Cos(2*D+Mp) - 204.586*E*math.Cos(2*D-M) -
+		129.620*E*math.Cos(M-Mp) + 108.743*math.Cos(D) + 104.755*E*math.Cos(M+Mp)
+
+	e := 0.016709
+	nu := M + (2*e-e*e*e/4)*math.Sin(M) + 1.25*e*e*math.Sin(2*M)
+	sunDistance := 1.000001018 * (1 - e*e) / (1 + e*math.Cos(nu))
+
+	moonSemidiameter := 358473400 / moonDistance // 角秒
+	sunSemidiameter := 959.63 / sunDistance
+	return moonSemidiameter / sunSemidiameter
+}
+
+// searchEclipse 从 jd 起逐个朔望月搜索下一次（或上一次）食相
+func searchEclipse(jd float64, kind EclipseKind, backward bool) (jdUT float64, eclipseType string, magnitude float64, err error) {
+	offset := 0.0
+	if kind == LunarEclipse {
+		offset = 0.5
+	}
+
+	base := math.Floor((TerrestrialTime(jd)-2451550.09766)/29.530588861) + offset
+	step := 1.0
+	if backward {
+		base++
+		step = -1
+	} else {
+		base--
+	}
+
+	for i := 0; i < eclipseSearchLunations; i++ {
+		e := meeusEclipse(base + float64(i)*step)
+		if !e.eclipse {
+			continue
+		}
+		ut := UniversalTime(e.jde)
+		if (!backward && ut > jd) || (backward && ut < jd) {
+			return ut, e.kind, e.magnitude, nil
+		}
+	}
+	name := "日食"
+	if kind == LunarEclipse {
+		name = "月食"
+	}
+	return 0, "", 0, fmt.Errorf("%d 个朔望月内未找到%s", eclipseSearchLunations, name)
+}
@@ -119,8 +119,7 @@ func CalculateSunPosition(jd float64) models.PlanetPosition {
 	signDegree := math.Mod(sunLong, 30)
 
 	// 计算尊贵度
-	dignity := GetDignity(models.Sun, zodiac.ID)
-	dignityScore := GetDignityScore(dignity)
+	dignityScore := EssentialDignityScore(models.Sun, sunLong)
 
 	planetInfo := GetPlanetInfo(models.Sun)
 
@@ -194,8 +193,7 @@ func CalculateMoonPosition(jd float64) models.PlanetPosition {
 	signDegree := math.Mod(moonLong, 30)
 
 	// 计算尊贵度
-	dignity := GetDignity(models.Moon, zodiac.ID)
-	dignityScore := GetDignityScore(dignity)
+	dignityScore := EssentialDignityScore(models.Moon, moonLong)
 
 	planetInfo := GetPlanetInfo(models.Moon)
 
@@ -289,8 +287,10 @@ func CalculatePlanetPosition(planet models.PlanetID, jd float64) models.PlanetPo
 		return CalculateMoonPosition(jd)
 	case models.NorthNode:
 		return CalculateNorthNodePosition(jd)
-	case models.Chiron:
-		return CalculateChironPosition(jd)
+	case models.MeanNode, models.Lilith, models.OscLilith, models.Chiron, models.Ceres, models.Pallas, models.Juno, models.Vesta, models.Eris:
+		// 简化算法未覆盖的点借用纯 Go 星历的月球理论，小天体按二体轨道根数计算（逆行由调用方按速度判断）
+		lon, lat, _, _ := apparentGeocentric(planet, TerrestrialTime(jd))
+		return newPlanetPosition(planet, lon, lat, false)
 	default:
 		longitude, retrograde = calculatePlanetLongitude(planet, T, jd)
 	}
@@ -300,8 +300,7 @@ func CalculatePlanetPosition(planet models.PlanetID, jd float64) models.PlanetPo
 	signDegree := math.Mod(longitude, 30)
 
 	// 计算尊贵度
-	dignity := GetDignity(planet, zodiac.ID)
-	dignityScore := GetDignityScore(dignity)
+	dignityScore := EssentialDignityScore(planet, longitude)
 
 	planetInfo := GetPlanetInfo(planet)
 
@@ -481,71 +480,6 @@ func CalculateNorthNodePosition(jd float64) models.PlanetPosition {
 	}
 }
 
-// ==================== 凯龙星计算 ====================
-
-// CalculateChironPosition 计算凯龙星位置（简化）
-func CalculateChironPosition(jd float64) models.PlanetPosition {
-	T := (jd - J2000) / 36525.0
-	daysSinceJ2000 := jd - J2000
-
-	// 凯龙星轨道元素（简化）
-	a := 13.6481 // 半长轴 (AU)
-	e := 0.3832  // 离心率
-
-	// 平近点角
-	M := NormalizeAngle(92.43 + 0.01958*daysSinceJ2000)
-	Mrad := M * DEG_TO_RAD
-
-	// 求解开普勒方程
-	E := solveKepler(Mrad, e)
-
-	// 计算真近点角
-	v := 2 * math.Atan2(
-		math.Sqrt(1+e)*math.Sin(E/2),
-		math.Sqrt(1-e)*math.Cos(E/2),
-	)
-
-	// 计算黄经
-	w := 339.5 // 近日点幅角
-	longitude := NormalizeAngle((v * RAD_TO_DEG) + w)
-
-	// 简化地心转换
-	earthLon := CalculateSunPosition(jd).Longitude + 180
-	geoLon := longitude + (180/a)*math.Sin((longitude-earthLon)*DEG_TO_RAD)
-	geoLon = NormalizeAngle(geoLon)
-
-	zodiac := GetZodiacByLongitude(geoLon)
-	signDegree := math.Mod(geoLon, 30)
-	planetInfo := GetPlanetInfo(models.Chiron)
-
-	// 判断逆行
-	prevLon := NormalizeAngle(92.43 + 0.01958*(daysSinceJ2000-1))
-	nextLon := NormalizeAngle(92.43 + 0.01958*(daysSinceJ2000+1))
-	motion := nextLon - prevLon
-	if motion < -180 {
-		motion += 360
-	}
-	if motion > 180 {
-		motion -= 360
-	}
-	retrograde := motion < 0
-
-	_ = T // 避免未使用警告
-
-	return models.PlanetPosition{
-		ID:         models.Chiron,
-		Name:       planetInfo.Name,
-		Symbol:     planetInfo.Symbol,
-		Longitude:  geoLon,
-		Latitude:   0,
-		Sign:       zodiac.ID,
-		SignName:   zodiac.Name,
-		SignSymbol: zodiac.Symbol,
-		SignDegree: signDegree,
-		Retrograde: retrograde,
-	}
-}
-
 // ==================== 获取所有行星位置 ====================
 
 // GetAllPlanetPositions 获取所有行星位置
@@ -564,9 +498,16 @@ func GetAllPlanetPositions(jd float64) []models.PlanetPosition {
 }
 
 // GetTransitPositions 获取当前行运行星位置
-// 使用 Swiss Ephemeris 作为唯一数据源
+// 经由进程级星历缓存读取当前提供者的数据
 func GetTransitPositions(date time.Time) []models.PlanetPosition {
 	jd := DateToJulianDay(date)
 	return GetPlanetPositionsUnified(jd)
 }
 
+// GetTransitPositionsForChart 获取行运行星位置，并转换到本命盘所用的黄道（回归/恒星）与交点类型
+// 行运天体固定为 DefaultBodies，扩展天体只作为本命点参与计算
+func GetTransitPositionsForChart(chart *models.NatalChart, date time.Time) []models.PlanetPosition {
+	jd := DateToJulianDay(date)
+	return ApplyZodiacToPositions(GetBodyPositionsUnified(jd, DefaultBodies, chart.BirthData.NodeType), ChartZodiacOffset(chart, jd))
+}
+
@@ -0,0 +1,394 @@
+package astro
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"star/models"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ==================== 星历缓存（切比雪夫插值） ====================
+// 天体位置与用户无关，按固定时间段用切比雪夫多项式拟合后在进程内共享。
+// 每段 8 天、16 个节点，插值误差远小于 0.01″；段按需惰性生成，也可预计算并持久化到磁盘
+
+const (
+	cacheSegmentDays   = 8.0  // 每段跨度（天）
+	cacheChebyshevN    = 16   // 切比雪夫节点数
+	cacheMaxSegments   = 8192 // 每个提供者最多缓存的段数（约 180 年）
+	cacheEpoch         = J2000
+	cacheFormatVersion = 2
+)
+
+// cacheableProvider 可选接口：计算开销较大、适合缓存的提供者
+type cacheableProvider interface {
+	Cacheable() bool
+}
+
+// chebyshevSeries 单个天体在一段时间内的切比雪夫系数
+type chebyshevSeries struct {
+	Lon  []float64 // 展开（连续化）后的黄经
+	Lat  []float64
+	Dist []float64 // 地心距离（AU）
+}
+
+// cacheSegment 一个时间段内各天体的拟合结果
+type cacheSegment struct {
+	Bodies map[models.PlanetID]*chebyshevSeries
+	used   atomic.Int64 // 最近一次访问的时钟值，不写入缓存文件
+}
+
+// EphemerisCache 进程级星历缓存
+type EphemerisCache struct {
+	mu        sync.RWMutex
+	enabled   bool
+	limit     int                                // 每个提供者最多缓存的段数
+	providers map[string]map[int64]*cacheSegment // 提供者 -> 段序号 -> 段
+	clock     atomic.Int64                       // 访问时钟，用于淘汰最久未使用的段
+	hits      atomic.Int64
+	misses    atomic.Int64
+}
+
+// EphemerisCacheStats 缓存统计
+type EphemerisCacheStats struct {
+	Enabled  bool           `json:"enabled"`
+	Segments map[string]int `json:"segments"` // 各提供者已缓存段数
+	Hits     int64          `json:"hits"`
+	Misses   int64          `json:"misses"`
+}
+
+// ephemerisCache 全局缓存实例
+var ephemerisCache = &EphemerisCache{
+	enabled:   true,
+	limit:     cacheMaxSegments,
+	providers: make(map[string]map[int64]*cacheSegment),
+}
+
+// SetEphemerisCacheEnabled 启用或关闭星历缓存
+func SetEphemerisCacheEnabled(enabled bool) {
+	ephemerisCache.mu.Lock()
+	defer ephemerisCache.mu.Unlock()
+	ephemerisCache.enabled = enabled
+}
+
+// ClearEphemerisCache 清空星历缓存
+func ClearEphemerisCache() {
+	ephemerisCache.mu.Lock()
+	defer ephemerisCache.mu.Unlock()
+	ephemerisCache.providers = make(map[string]map[int64]*cacheSegment)
+	ephemerisCache.hits.Store(0)
+	ephemerisCache.misses.Store(0)
+}
+
+// GetEphemerisCacheStats 返回缓存统计
+func GetEphemerisCacheStats() EphemerisCacheStats {
+	c := ephemerisCache
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	segments := make(map[string]int, len(c.providers))
+	for name, segs := range c.providers {
+		segments[name] = len(segs)
+	}
+	return EphemerisCacheStats{
+		Enabled:  c.enabled,
+		Segments: segments,
+		Hits:     c.hits.Load(),
+		Misses:   c.misses.Load(),
+	}
+}
+
+// usesCache 判断提供者是否经由缓存读取
+func (c *EphemerisCache) usesCache(provider EphemerisProvider) bool {
+	cacheable, ok := provider.(cacheableProvider)
+	if !ok || !cacheable.Cacheable() {
+		return false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.enabled
+}
+
+// segmentIndex 返回 jd 所在段的序号及段内归一化时间 x ∈ [-1, 1]
+func segmentIndex(jd float64) (int64, float64) {
+	offset := (jd - cacheEpoch) / cacheSegmentDays
+	index := math.Floor(offset)
+	return int64(index), 2*(offset-index) - 1
+}
+
+// segmentStart 段起始儒略日
+func segmentStart(index int64) float64 {
+	return cacheEpoch + float64(index)*cacheSegmentDays
+}
+
+// position 从缓存读取天体位置，缺失时用提供者拟合该段
+func (c *EphemerisCache) position(provider EphemerisProvider, planet models.PlanetID, jd float64) (models.PlanetPosition, error) {
+	index, x := segmentIndex(jd)
+	name := provider.Name()
+
+	c.mu.RLock()
+	var series *chebyshevSeries
+	if seg := c.providers[name][index]; seg != nil {
+		series = seg.Bodies[planet]
+		seg.used.Store(c.clock.Add(1))
+	}
+	c.mu.RUnlock()
+
+	if series != nil {
+		c.hits.Add(1)
+	} else {
+		fitted, err := fitChebyshev(provider, planet, segmentStart(index))
+		if err != nil {
+			return models.PlanetPosition{}, err
+		}
+		c.misses.Add(1)
+		series = fitted
+		c.store(name, index, planet, series)
+	}
+
+	// 速度取自拟合多项式的导数
+	return newMovingPosition(planet, jd, chebyshevValue(series.Lon, x), chebyshevValue(series.Lat, x), planetMotion{
+		Distance:      chebyshevValue(series.Dist, x),
+		Speed:         chebyshevDerivative(series.Lon, x),
+		LatitudeSpeed: chebyshevDerivative(series.Lat, x),
+	}), nil
+}
+
+// store 写入拟合结果，超出容量时淘汰最久未使用的段
+// 按时间顺序批量评分时，最久未使用的段即已扫过的最早时段，即将读取的段不会被淘汰
+func (c *EphemerisCache) store(name string, index int64, planet models.PlanetID, series *chebyshevSeries) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	segs := c.providers[name]
+	if segs == nil {
+		segs = make(map[int64]*cacheSegment)
+		c.providers[name] = segs
+	}
+	seg := segs[index]
+	if seg == nil {
+		if len(segs) >= c.limit {
+			oldest, oldestUsed := int64(0), int64(math.MaxInt64)
+			for k, candidate := range segs {
+				if used := candidate.used.Load(); used < oldestUsed {
+					oldest, oldestUsed = k, used
+				}
+			}
+			delete(segs, oldest)
+		}
+		seg = &cacheSegment{Bodies: make(map[models.PlanetID]*chebyshevSeries)}
+		segs[index] = seg
+	}
+	seg.Bodies[planet] = series
+	seg.used.Store(c.clock.Add(1))
+}
+
+// ==================== 切比雪夫拟合 ====================
+
+// fitChebyshev 在切比雪夫节点上采样提供者并计算系数
+func fitChebyshev(provider EphemerisProvider, planet models.PlanetID, start float64) (*chebyshevSeries, error) {
+	const n = cacheChebyshevN
+	half := cacheSegmentDays / 2
+
+	lon := make([]float64, n)
+	lat := make([]float64, n)
+	dist := make([]float64, n)
+	// 节点按 x 从 1 到 -1 排列，倒序采样使时间递增，便于展开黄经
+	for k := n - 1; k >= 0; k-- {
+		x := math.Cos(math.Pi * (float64(k) + 0.5) / n)
+		pos, err := provider.PlanetPosition(planet, start+half*(x+1))
+		if err != nil {
+			return nil, err
+		}
+		lon[k], lat[k], dist[k] = pos.Longitude, pos.Latitude, pos.Distance
+		if k < n-1 {
+			lon[k] = lon[k+1] + normalizeSigned(lon[k]-lon[k+1])
+		}
+	}
+
+	series := &chebyshevSeries{Lon: make([]float64, n), Lat: make([]float64, n), Dist: make([]float64, n)}
+	for j := 0; j < n; j++ {
+		var sumLon, sumLat, sumDist float64
+		for k := 0; k < n; k++ {
+			w := math.Cos(math.Pi * float64(j) * (float64(k) + 0.5) / n)
+			sumLon += lon[k] * w
+			sumLat += lat[k] * w
+			sumDist += dist[k] * w
+		}
+		series.Lon[j] = 2 * sumLon / n
+		series.Lat[j] = 2 * sumLat / n
+		series.Dist[j] = 2 * sumDist / n
+	}
+	return series, nil
+}
+
+// normalizeSigned 将角度差归一化到 (-180, 180]
+func normalizeSigned(angle float64) float64 {
+	angle = NormalizeAngle(angle)
+	if angle > 180 {
+		angle -= 360
+	}
+	return angle
+}
+
+// chebyshevValue Clenshaw 算法求切比雪夫级数的值
+func chebyshevValue(coef []float64, x float64) float64 {
+	var b1, b2 float64
+	for j := len(coef) - 1; j >= 1; j-- {
+		b1, b2 = 2*x*b1-b2+coef[j], b1
+	}
+	return x*b1 - b2 + coef[0]/2
+}
+
+// chebyshevDerivative 切比雪夫级数对时间的导数（度/天）
+func chebyshevDerivative(coef []float64, x float64) float64 {
+	n := len(coef)
+	if n < 2 {
+		return 0
+	}
+	d := make([]float64, n)
+	for j := n - 1; j >= 1; j-- {
+		next := 0.0
+		if j+1 < n {
+			next = d[j+1]
+		}
+		d[j-1] = next + 2*float64(j)*coef[j]
+	}
+	return chebyshevValue(d, x) / (cacheSegmentDays / 2)
+}
+
+// PrecomputeEphemeris 为当前提供者预计算 [startJD, endJD] 范围内默认天体的缓存段
+func PrecomputeEphemeris(startJD, endJD float64) error {
+	provider := CurrentEphemerisProvider()
+	if !ephemerisCache.usesCache(provider) {
+		return fmt.Errorf("星历提供者 %s 未启用缓存", provider.Name())
+	}
+	for jd := startJD; jd <= endJD+cacheSegmentDays; jd += cacheSegmentDays {
+		for _, planet := range DefaultBodies {
+			_, err := ephemerisCache.position(provider, planet, jd)
+			if errors.Is(err, ErrBodyNotProvided) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ==================== 持久化 ====================
+
+// cacheFile 缓存文件格式
+type cacheFile struct {
+	Version   int
+	Days      float64
+	Nodes     int
+	Providers map[string]map[int64]*cacheSegment
+}
+
+// SaveEphemerisCache 将缓存写入磁盘
+func SaveEphemerisCache(path string) error {
+	c := ephemerisCache
+	c.mu.RLock()
+	data := cacheFile{
+		Version:   cacheFormatVersion,
+		Days:      cacheSegmentDays,
+		Nodes:     cacheChebyshevN,
+		Providers: c.providers,
+	}
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		c.mu.RUnlock()
+		return err
+	}
+	err = gob.NewEncoder(f).Encode(data)
+	c.mu.RUnlock()
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// LoadEphemerisCache 从磁盘加载缓存，与现有缓存合并；文件不存在时不报错
+func LoadEphemerisCache(path string) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var data cacheFile
+	if err := gob.NewDecoder(f).Decode(&data); err != nil {
+		return fmt.Errorf("星历缓存文件损坏: %v", err)
+	}
+	if data.Version != cacheFormatVersion || data.Days != cacheSegmentDays || data.Nodes != cacheChebyshevN {
+		return fmt.Errorf("星历缓存文件格式不兼容（版本 %d）", data.Version)
+	}
+
+	// 文件中的段视为刚访问过，离当前时刻越近越新；超过容量时与 store 一样淘汰最久未使用的段
+	now, _ := segmentIndex(DateToJulianDay(time.Now()))
+	c := ephemerisCache
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for name, loaded := range data.Providers {
+		indices := make([]int64, 0, len(loaded))
+		for index := range loaded {
+			indices = append(indices, index)
+		}
+		sort.Slice(indices, func(i, j int) bool {
+			return absInt64(indices[i]-now) > absInt64(indices[j]-now)
+		})
+
+		segs := c.providers[name]
+		if segs == nil {
+			segs = make(map[int64]*cacheSegment)
+			c.providers[name] = segs
+		}
+		for _, index := range indices {
+			seg := loaded[index]
+			seg.used.Store(c.clock.Add(1))
+			segs[index] = seg
+		}
+		c.trim(segs)
+	}
+	return nil
+}
+
+// trim 段数超过容量时按最近访问时间淘汰多出的段
+func (c *EphemerisCache) trim(segs map[int64]*cacheSegment) {
+	if len(segs) <= c.limit {
+		return
+	}
+	indices := make([]int64, 0, len(segs))
+	for index := range segs {
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		return segs[indices[i]].used.Load() < segs[indices[j]].used.Load()
+	})
+	for _, index := range indices[:len(segs)-c.limit] {
+		delete(segs, index)
+	}
+}
+
+// absInt64 整数绝对值
+func absInt64(x int64) int64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
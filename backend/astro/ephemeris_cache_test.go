@@ -0,0 +1,140 @@
+package astro
+
+import (
+	"errors"
+	"math"
+	"path/filepath"
+	"star/models"
+	"testing"
+	"time"
+)
+
+// TestEphemerisCacheAccuracy 测试切比雪夫缓存与直接计算的一致性
+func TestEphemerisCacheAccuracy(t *testing.T) {
+	provider := NewPreciseProvider()
+	useProvider(t, provider)
+	ClearEphemerisCache()
+	t.Cleanup(ClearEphemerisCache)
+
+	start := DateToJulianDay(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	maxLon, maxLat := 0.0, 0.0
+	retroMismatch := 0
+	// 37 小时步长，覆盖各段内的不同位置
+	for jd := start; jd < start+120; jd += 37.0 / 24 {
+		cached := GetPlanetPositionsUnified(jd)
+		for i, planet := range DefaultBodies {
+			direct, err := provider.PlanetPosition(planet, jd)
+			if errors.Is(err, ErrBodyNotProvided) {
+				continue
+			}
+			if err != nil {
+				t.Fatalf("%s 直接计算失败: %v", planet, err)
+			}
+			maxLon = math.Max(maxLon, math.Abs(normalizeSigned(cached[i].Longitude-direct.Longitude)))
+			maxLat = math.Max(maxLat, math.Abs(cached[i].Latitude-direct.Latitude))
+			if cached[i].Retrograde != direct.Retrograde {
+				retroMismatch++
+			}
+		}
+	}
+
+	t.Logf("最大误差：黄经 %.5f″，黄纬 %.5f″，逆行判断不一致 %d 次", maxLon*3600, maxLat*3600, retroMismatch)
+	if maxLon*3600 > 0.1 || maxLat*3600 > 0.1 {
+		t.Errorf("缓存插值误差过大")
+	}
+	if retroMismatch > 0 {
+		t.Errorf("缓存的逆行判断与直接计算不一致")
+	}
+
+	stats := GetEphemerisCacheStats()
+	if stats.Hits == 0 || stats.Segments[ProviderPrecise] == 0 {
+		t.Errorf("缓存未生效: %+v", stats)
+	}
+}
+
+// TestEphemerisCachePersistence 测试缓存的保存与加载
+func TestEphemerisCachePersistence(t *testing.T) {
+	useProvider(t, NewPreciseProvider())
+	ClearEphemerisCache()
+	t.Cleanup(ClearEphemerisCache)
+
+	start := DateToJulianDay(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))
+	if err := PrecomputeEphemeris(start, start+30); err != nil {
+		t.Fatalf("预计算失败: %v", err)
+	}
+	want := CalculatePlanetPositionUnified(models.Moon, start+10.3)
+
+	path := filepath.Join(t.TempDir(), "ephemeris.cache")
+	if err := SaveEphemerisCache(path); err != nil {
+		t.Fatalf("保存失败: %v", err)
+	}
+	segments := GetEphemerisCacheStats().Segments[ProviderPrecise]
+
+	ClearEphemerisCache()
+	if err := LoadEphemerisCache(path); err != nil {
+		t.Fatalf("加载失败: %v", err)
+	}
+	stats := GetEphemerisCacheStats()
+	if stats.Segments[ProviderPrecise] != segments {
+		t.Errorf("加载后段数 %d，期望 %d", stats.Segments[ProviderPrecise], segments)
+	}
+
+	got := CalculatePlanetPositionUnified(models.Moon, start+10.3)
+	if got.Longitude != want.Longitude || GetEphemerisCacheStats().Misses != 0 {
+		t.Errorf("加载后应直接命中缓存: %v vs %v", got.Longitude, want.Longitude)
+	}
+
+	if err := LoadEphemerisCache(filepath.Join(t.TempDir(), "missing")); err != nil {
+		t.Errorf("缓存文件不存在时不应报错: %v", err)
+	}
+}
+
+// TestEphemerisCacheEviction 测试缓存满时淘汰最久未使用的段，加载缓存文件时同样受容量限制
+func TestEphemerisCacheEviction(t *testing.T) {
+	useProvider(t, NewPreciseProvider())
+	ClearEphemerisCache()
+	limit := ephemerisCache.limit
+	ephemerisCache.limit = 3
+	t.Cleanup(func() {
+		ephemerisCache.limit = limit
+		ClearEphemerisCache()
+	})
+
+	now, _ := segmentIndex(DateToJulianDay(time.Now()))
+	read := func(index int64) {
+		CalculatePlanetPositionUnified(models.Sun, segmentStart(index)+1)
+	}
+	cached := func(index int64) bool {
+		return ephemerisCache.providers[ProviderPrecise][index] != nil
+	}
+
+	// 依次读取 4 段，其间回读第一段：淘汰的应是最久未使用的第二段
+	read(now - 2)
+	read(now - 1)
+	read(now)
+	read(now - 2)
+	read(now + 1)
+	if !cached(now-2) || cached(now-1) || !cached(now) || !cached(now+1) {
+		t.Errorf("应淘汰最久未使用的段: %v", ephemerisCache.providers[ProviderPrecise])
+	}
+
+	// 保存 5 段后在容量 3 下加载：保留离当前时刻最近的 3 段
+	ephemerisCache.limit = 5
+	read(now - 1)
+	read(now + 2)
+	path := filepath.Join(t.TempDir(), "ephemeris.cache")
+	if err := SaveEphemerisCache(path); err != nil {
+		t.Fatalf("保存失败: %v", err)
+	}
+	ClearEphemerisCache()
+	ephemerisCache.limit = 3
+	if err := LoadEphemerisCache(path); err != nil {
+		t.Fatalf("加载失败: %v", err)
+	}
+	if n := GetEphemerisCacheStats().Segments[ProviderPrecise]; n != 3 {
+		t.Fatalf("加载后段数应受容量限制为 3，实际 %d", n)
+	}
+	if !cached(now-1) || !cached(now) || !cached(now+1) {
+		t.Errorf("加载时应保留离当前时刻最近的段: %v", ephemerisCache.providers[ProviderPrecise])
+	}
+}
@@ -0,0 +1,250 @@
+package astro
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"star/models"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ==================== 星历提供者接口 ====================
+
+// EphemerisProvider 星历数据提供者
+// 所有天体位置、宫位、升落与食相数据都经由当前提供者获取，时间参数均为世界时儒略日
+type EphemerisProvider interface {
+	// Name 提供者标识（如 "swiss"、"builtin"、"table"）
+	Name() string
+	// Description 数据源描述，用于 /health 与日志
+	Description() string
+	// PlanetPosition 计算单个天体的回归黄道位置
+	PlanetPosition(planet models.PlanetID, jd float64) (models.PlanetPosition, error)
+	// Houses 计算宫位，返回12宫宫头、上升点与中天
+	Houses(jd, lat, lon float64, system models.HouseSystem) ([]models.HouseCusp, float64, float64, error)
+	// RiseSet 查找 jd 之后天体的下一次升起或落下时刻
+	RiseSet(body models.PlanetID, jd, lat, lon float64, event RiseSetEvent) (float64, error)
+	// NextEclipse 查找 jd 之后（backward 为 true 时为之前）最近的一次日食或月食
+	NextEclipse(jd float64, kind EclipseKind, backward bool) (EclipseInfo, error)
+}
+
+// RiseSetEvent 升落事件类型
+type RiseSetEvent int
+
+const (
+	EventRise RiseSetEvent = iota // 升起（上边缘，含大气折射）
+	EventSet                      // 落下
+)
+
+// EclipseKind 食相种类
+type EclipseKind string
+
+const (
+	SolarEclipse EclipseKind = "solar"
+	LunarEclipse EclipseKind = "lunar"
+)
+
+// 食相类型
+const (
+	EclipseTotal     = "total"
+	EclipseAnnular   = "annular"
+	EclipseHybrid    = "hybrid"
+	EclipsePartial   = "partial"
+	EclipsePenumbral = "penumbral"
+)
+
+// EclipseInfo 食相信息
+type EclipseInfo struct {
+	Kind      EclipseKind `json:"kind"`
+	Type      string      `json:"type"`      // total / annular / hybrid / partial / penumbral
+	MaximumJD float64     `json:"maximumJd"` // 食甚（世界时儒略日）
+	Maximum   time.Time   `json:"maximum"`
+	Magnitude float64     `json:"magnitude"` // 日食为食分，月食为本影食分（半影月食为半影食分）
+	Longitude float64     `json:"longitude"` // 食甚时被食天体的回归黄经
+}
+
+// ErrNoRiseSet 天体在搜索窗口内不升不落（极昼、极夜或拱极）
+var ErrNoRiseSet = fmt.Errorf("天体在该地点不升不落")
+
+// ErrBodyNotProvided 提供者的精度不足以提供该天体，统一星历层回退到内置算法
+var ErrBodyNotProvided = fmt.Errorf("提供者不提供该天体")
+
+// ==================== 提供者注册与选择 ====================
+
+// 提供者标识
+const (
+	ProviderSwiss   = "swiss"
+	ProviderPrecise = "precise"
+	ProviderBuiltin = "builtin"
+	ProviderTable   = "table"
+)
+
+var (
+	providerMu        sync.RWMutex
+	providerFactories = map[string]func() (EphemerisProvider, error){
+		ProviderPrecise: func() (EphemerisProvider, error) { return NewPreciseProvider(), nil },
+		ProviderBuiltin: func() (EphemerisProvider, error) { return NewBuiltinProvider(), nil },
+		ProviderTable:   func() (EphemerisProvider, error) { return NewTableProvider(), nil },
+	}
+	currentProvider EphemerisProvider
+)
+
+// RegisterEphemerisProvider 注册星历提供者工厂
+func RegisterEphemerisProvider(name string, factory func() (EphemerisProvider, error)) {
+	providerMu.Lock()
+	defer providerMu.Unlock()
+	providerFactories[strings.ToLower(name)] = factory
+}
+
+// AvailableEphemerisProviders 返回当前构建中可用的提供者标识
+func AvailableEphemerisProviders() []string {
+	providerMu.RLock()
+	defer providerMu.RUnlock()
+
+	names := make([]string, 0, len(providerFactories))
+	for name := range providerFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DefaultEphemerisProviderName 默认提供者：可用时为 Swiss Ephemeris，否则为纯 Go 高精度星历
+func DefaultEphemerisProviderName() string {
+	providerMu.RLock()
+	defer providerMu.RUnlock()
+
+	if _, ok := providerFactories[ProviderSwiss]; ok {
+		return ProviderSwiss
+	}
+	return ProviderPrecise
+}
+
+// NewEphemerisProvider 按标识创建提供者实例（不改变当前提供者，可用于并排比较）
+// 空标识返回默认提供者
+func NewEphemerisProvider(name string) (EphemerisProvider, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = DefaultEphemerisProviderName()
+	}
+
+	providerMu.RLock()
+	factory, ok := providerFactories[name]
+	providerMu.RUnlock()
+	if !ok {
+		if name == ProviderSwiss {
+			return nil, fmt.Errorf("当前构建不包含 Swiss Ephemeris，请使用 CGO_ENABLED=1 go build -tags swe 重新编译")
+		}
+		return nil, fmt.Errorf("不支持的星历提供者: %s（可用: %s）", name, strings.Join(AvailableEphemerisProviders(), ", "))
+	}
+	return factory()
+}
+
+// SelectEphemerisProvider 选择当前使用的星历提供者
+func SelectEphemerisProvider(name string) (EphemerisProvider, error) {
+	provider, err := NewEphemerisProvider(name)
+	if err != nil {
+		return nil, err
+	}
+	SetEphemerisProvider(provider)
+	return provider, nil
+}
+
+// SetEphemerisProvider 直接设置当前提供者（测试中可注入自定义实现）
+func SetEphemerisProvider(provider EphemerisProvider) {
+	providerMu.Lock()
+	defer providerMu.Unlock()
+	currentProvider = provider
+}
+
+// CurrentEphemerisProvider 返回当前提供者，未选择时惰性创建默认提供者
+func CurrentEphemerisProvider() EphemerisProvider {
+	providerMu.RLock()
+	provider := currentProvider
+	providerMu.RUnlock()
+	if provider != nil {
+		return provider
+	}
+
+	provider, err := NewEphemerisProvider("")
+	if err != nil {
+		provider = NewPreciseProvider()
+	}
+
+	providerMu.Lock()
+	defer providerMu.Unlock()
+	if currentProvider == nil {
+		currentProvider = provider
+	}
+	return currentProvider
+}
+
+// ==================== 通用辅助 ====================
+
+// newPlanetPosition 根据黄经构造行星位置（星座、尊贵度等派生字段一并填充）
+func newPlanetPosition(planet models.PlanetID, longitude, latitude float64, retrograde bool) models.PlanetPosition {
+	pos := models.PlanetPosition{
+		ID:         planet,
+		Latitude:   latitude,
+		Retrograde: retrograde,
+	}
+	if info := GetPlanetInfo(planet); info != nil {
+		pos.Name = info.Name
+		pos.Symbol = info.Symbol
+	}
+	setPositionLongitude(&pos, longitude)
+	return pos
+}
+
+// planetMotion 天体的地心距离与速度
+type planetMotion struct {
+	Distance      float64 // AU
+	Speed         float64 // 黄经速度（度/天）
+	LatitudeSpeed float64 // 黄纬速度（度/天）
+}
+
+// newMovingPosition 构造带速度、距离与赤纬的行星位置，逆行由黄经速度判断
+func newMovingPosition(planet models.PlanetID, jd, longitude, latitude float64, motion planetMotion) models.PlanetPosition {
+	pos := newPlanetPosition(planet, longitude, latitude, motion.Speed < 0)
+	pos.Speed = motion.Speed
+	pos.LatitudeSpeed = motion.LatitudeSpeed
+	pos.Distance = motion.Distance
+	// 提供者给出的黄经参考真春分点（含章动），赤纬须用真黄赤交角
+	eps := TrueObliquity(jd)
+	_, pos.Declination = EquatorialCoordinates(longitude, latitude, eps)
+	pos.DeclinationSpeed = declinationRate(longitude, latitude, pos.Declination, eps, motion)
+	pos.OutOfBounds = math.Abs(pos.Declination) > eps
+	return pos
+}
+
+// declinationRate 由黄经/黄纬速度求赤纬速度（度/天）
+// sinδ = sinβ·cosε + cosβ·sinε·sinλ 对时间求导
+func declinationRate(longitude, latitude, declination, eps float64, motion planetMotion) float64 {
+	lon, lat, decl, e := longitude*DEG_TO_RAD, latitude*DEG_TO_RAD, declination*DEG_TO_RAD, eps*DEG_TO_RAD
+	cosDecl := math.Cos(decl)
+	if cosDecl < 1e-9 {
+		return 0
+	}
+	return ((math.Cos(lat)*math.Cos(e)-math.Sin(lat)*math.Sin(e)*math.Sin(lon))*motion.LatitudeSpeed +
+		math.Cos(lat)*math.Sin(e)*math.Cos(lon)*motion.Speed) / cosDecl
+}
+
+// newEclipseInfo 构造食相信息，被食天体黄经由提供者在食甚时刻计算
+func newEclipseInfo(provider EphemerisProvider, kind EclipseKind, eclipseType string, jd, magnitude float64) EclipseInfo {
+	longitude := 0.0
+	if sun, err := provider.PlanetPosition(models.Sun, jd); err == nil {
+		longitude = sun.Longitude
+		if kind == LunarEclipse {
+			longitude = NormalizeAngle(longitude + 180)
+		}
+	}
+	return EclipseInfo{
+		Kind:      kind,
+		Type:      eclipseType,
+		MaximumJD: jd,
+		Maximum:   JulianDayToDate(jd),
+		Magnitude: magnitude,
+		Longitude: longitude,
+	}
+}
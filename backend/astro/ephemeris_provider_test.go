@@ -0,0 +1,187 @@
+package astro
+
+import (
+	"errors"
+	"math"
+	"star/models"
+	"testing"
+	"time"
+)
+
+// useProvider 在测试期间切换星历提供者，结束后恢复
+func useProvider(t *testing.T, provider EphemerisProvider) {
+	previous := CurrentEphemerisProvider()
+	SetEphemerisProvider(provider)
+	t.Cleanup(func() { SetEphemerisProvider(previous) })
+}
+
+// TestEphemerisProviderSelection 测试提供者选择
+func TestEphemerisProviderSelection(t *testing.T) {
+	for _, name := range []string{"precise", "builtin", "TABLE", " table "} {
+		provider, err := NewEphemerisProvider(name)
+		if err != nil {
+			t.Fatalf("创建提供者 %q 失败: %v", name, err)
+		}
+		t.Logf("%q -> %s (%s)", name, provider.Name(), provider.Description())
+	}
+
+	if _, err := NewEphemerisProvider("vsop"); err == nil {
+		t.Errorf("未知提供者应返回错误")
+	}
+
+	if !IsSweAvailable() {
+		if DefaultEphemerisProviderName() != ProviderPrecise {
+			t.Errorf("无 Swiss Ephemeris 时默认应为纯 Go 高精度星历")
+		}
+		if _, err := NewEphemerisProvider(ProviderSwiss); err == nil {
+			t.Errorf("Swiss Ephemeris 不可用时选择 swiss 应返回错误")
+		}
+	}
+}
+
+// TestTableProviderPipeline 使用表驱动星历在无 CGO 环境下跑通星盘与评分流水线
+func TestTableProviderPipeline(t *testing.T) {
+	useProvider(t, NewTableProvider())
+
+	sun := CalculatePlanetPositionUnified(models.Sun, J2000)
+	if math.Abs(sun.Longitude-280.46457) > 1e-9 {
+		t.Errorf("J2000 太阳黄经应为表值 280.46457°，实际 %.5f°", sun.Longitude)
+	}
+
+	// 圆轨道几何仍应产生火星逆行（2020 年 9-11 月）
+	mars := CalculatePlanetPositionUnified(models.Mars, DateToJulianDay(time.Date(2020, 10, 13, 0, 0, 0, 0, time.UTC)))
+	if !mars.Retrograde {
+		t.Errorf("表驱动星历中火星在冲日附近应逆行")
+	}
+
+	birthData := models.BirthData{
+		Name: "Table", Year: 1990, Month: 6, Day: 15, Hour: 14, Minute: 30,
+		Latitude: 39.9042, Longitude: 116.4074, Timezone: 8,
+	}
+	date := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	first := CalculateScoresV2(CalculateNatalChart(birthData), date)
+	second := CalculateScoresV2(CalculateNatalChart(birthData), date)
+	if first.Overall != second.Overall {
+		t.Errorf("表驱动星历结果应完全确定: %.6f vs %.6f", first.Overall, second.Overall)
+	}
+	t.Logf("表驱动星历综合分: %.2f", first.Overall)
+}
+
+// knownEclipse 已知日月食
+type knownEclipse struct {
+	Kind      EclipseKind
+	Type      string
+	Maximum   time.Time
+	Magnitude float64
+}
+
+// knownEclipses 2023-2026 年日月食（食甚时刻精确到分钟，取自 NASA 食相表）
+var knownEclipses = []knownEclipse{
+	{SolarEclipse, EclipseHybrid, time.Date(2023, 4, 20, 4, 17, 0, 0, time.UTC), 1.0132},
+	{LunarEclipse, EclipsePenumbral, time.Date(2023, 5, 5, 17, 23, 0, 0, time.UTC), 0.9641},
+	{SolarEclipse, EclipseAnnular, time.Date(2023, 10, 14, 18, 0, 0, 0, time.UTC), 0.9520},
+	{LunarEclipse, EclipsePartial, time.Date(2023, 10, 28, 20, 14, 0, 0, time.UTC), 0.1218},
+	{LunarEclipse, EclipsePenumbral, time.Date(2024, 3, 25, 7, 13, 0, 0, time.UTC), 0.9577},
+	{SolarEclipse, EclipseTotal, time.Date(2024, 4, 8, 18, 17, 0, 0, time.UTC), 1.0566},
+	{LunarEclipse, EclipsePartial, time.Date(2024, 9, 18, 2, 44, 0, 0, time.UTC), 0.0848},
+	{SolarEclipse, EclipseAnnular, time.Date(2024, 10, 2, 18, 45, 0, 0, time.UTC), 0.9326},
+	{LunarEclipse, EclipseTotal, time.Date(2025, 3, 14, 6, 58, 0, 0, time.UTC), 1.1781},
+	{SolarEclipse, EclipsePartial, time.Date(2025, 3, 29, 10, 47, 0, 0, time.UTC), 0.9376},
+	{LunarEclipse, EclipseTotal, time.Date(2025, 9, 7, 18, 11, 0, 0, time.UTC), 1.3638},
+	{SolarEclipse, EclipsePartial, time.Date(2025, 9, 21, 19, 41, 0, 0, time.UTC), 0.8550},
+	{SolarEclipse, EclipseAnnular, time.Date(2026, 2, 17, 12, 12, 0, 0, time.UTC), 0.9630},
+	{LunarEclipse, EclipseTotal, time.Date(2026, 3, 3, 11, 33, 0, 0, time.UTC), 1.1507},
+	{SolarEclipse, EclipseTotal, time.Date(2026, 8, 12, 17, 46, 0, 0, time.UTC), 1.0386},
+	{LunarEclipse, EclipsePartial, time.Date(2026, 8, 28, 4, 12, 0, 0, time.UTC), 0.9299},
+}
+
+// TestBuiltinEclipses 内置 Meeus 食相算法与已知日月食比对
+func TestBuiltinEclipses(t *testing.T) {
+	builtin := NewBuiltinProvider()
+
+	for _, expected := range knownEclipses {
+		name := expected.Maximum.Format("2006-01-02") + " " + string(expected.Kind)
+		t.Run(name, func(t *testing.T) {
+			start := DateToJulianDay(expected.Maximum.AddDate(0, 0, -10))
+			got, err := builtin.NextEclipse(start, expected.Kind, false)
+			if err != nil {
+				t.Fatalf("查找失败: %v", err)
+			}
+
+			diff := math.Abs(got.MaximumJD-DateToJulianDay(expected.Maximum)) * 1440
+			t.Logf("%s %s 食分 %.4f，食甚偏差 %.1f 分钟", got.Type, got.Maximum.Format("2006-01-02 15:04"), got.Magnitude, diff)
+			if diff > 10 {
+				t.Errorf("食甚时刻偏差过大: %.1f 分钟", diff)
+			}
+			if got.Type != expected.Type {
+				t.Errorf("食相类型错误: 期望 %s, 实际 %s", expected.Type, got.Type)
+			}
+			if math.Abs(got.Magnitude-expected.Magnitude) > 0.03 {
+				t.Errorf("食分偏差过大: 期望 %.4f, 实际 %.4f", expected.Magnitude, got.Magnitude)
+			}
+		})
+	}
+
+	// 向后搜索
+	got, err := builtin.NextEclipse(DateToJulianDay(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)), SolarEclipse, true)
+	if err != nil || got.Maximum.Format("2006-01-02") != "2024-04-08" {
+		t.Errorf("向后搜索应找到 2024-04-08 日全食，实际 %v (%v)", got.Maximum, err)
+	}
+}
+
+// TestBuiltinRiseSet 测试日出日落与极昼
+func TestBuiltinRiseSet(t *testing.T) {
+	builtin := NewBuiltinProvider()
+	jd := DateToJulianDay(time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC))
+
+	// 伦敦夏至：日出约 03:43 UT，日落约 20:21 UT
+	tests := []struct {
+		event    RiseSetEvent
+		expected time.Time
+	}{
+		{EventRise, time.Date(2024, 6, 21, 3, 43, 0, 0, time.UTC)},
+		{EventSet, time.Date(2024, 6, 21, 20, 21, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := builtin.RiseSet(models.Sun, jd, 51.5074, -0.1278, tt.event)
+		if err != nil {
+			t.Fatalf("计算失败: %v", err)
+		}
+		diff := math.Abs(got-DateToJulianDay(tt.expected)) * 1440
+		t.Logf("事件 %d: %s（偏差 %.1f 分钟）", tt.event, JulianDayToDate(got).Format("15:04:05"), diff)
+		if diff > 2 {
+			t.Errorf("偏差过大: %.1f 分钟", diff)
+		}
+	}
+
+	// 特罗姆瑟夏至为极昼
+	if _, err := builtin.RiseSet(models.Sun, jd, 69.6492, 18.9553, EventSet); !errors.Is(err, ErrNoRiseSet) {
+		t.Errorf("极昼应返回 ErrNoRiseSet，实际 %v", err)
+	}
+}
+
+// failingProvider 总是计算失败的提供者
+type failingProvider struct{ BuiltinProvider }
+
+func (p *failingProvider) Name() string { return "failing" }
+
+func (p *failingProvider) PlanetPosition(models.PlanetID, float64) (models.PlanetPosition, error) {
+	return models.PlanetPosition{}, errors.New("星历文件缺失")
+}
+
+// TestProviderFailureReported 提供者失败时回退到内置算法并计数，而不是静默吞掉错误
+func TestProviderFailureReported(t *testing.T) {
+	jd := DateToJulianDay(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	before := GetEphemerisFailures()["failing/mars"]
+
+	pos := planetPositionFrom(&failingProvider{}, models.Mars, jd)
+	planetPositionFrom(&failingProvider{}, models.Mars, jd)
+
+	if pos.Longitude != builtinPlanetPosition(models.Mars, jd).Longitude {
+		t.Errorf("失败时应回退到内置算法: %+v", pos)
+	}
+	if got := GetEphemerisFailures()["failing/mars"] - before; got != 2 {
+		t.Errorf("两次失败都应计数，实际 %d", got)
+	}
+}
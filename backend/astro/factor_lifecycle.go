@@ -103,17 +103,23 @@ var FactorDurations = map[models.InfluenceFactorType]float64{
 	// 年度级
 	models.FactorProfectionLord: 365 * 24, // 年主星：1年
 	models.FactorOuterPlanet:    180 * 24, // 外行星相位：约6个月
+	models.FactorEclipse:        196 * 24, // 日月食：食前两周至食后六个月
+	models.FactorFixedStar:      240 * 24, // 恒星接触：木星约1个月至冥王星约2年，取典型约8个月
 
 	// 月度级
 	models.FactorDignity: 30 * 24, // 行星换座：约30天（太阳周期）
+	models.FactorIngress: 37 * 24, // 外行星换座：换座前一周至换座后一个月
 
 	// 周度级
 	models.FactorRetrograde: 21 * 24, // 水星逆行：约21天
+	models.FactorOutOfBounds: 14 * 24, // 出界：数天（月亮）到数月（内行星）
 
 	// 日度级
 	models.FactorAspectPhase: 3 * 24,  // 相位影响：约3天（1°容许度/天）
 	models.FactorAspectOrb:   3 * 24,  // 相位容许度
 	models.FactorLunarPhase:  3.5 * 24, // 月相阶段：约3.5天
+	models.FactorParallel:    3 * 24,   // 赤纬平行：约3天
+	models.FactorAccidental:  2 * 24,   // 后天尊贵：宫位随地球自转变化，与太阳的距离和速度逐日变化
 
 	// 小时级
 	models.FactorPlanetaryHour: 1.5,   // 行星时：约1-1.5小时
@@ -156,33 +162,19 @@ func CreateLifecycleWithPeak(startTime, peakTime, endTime time.Time) *models.Fac
 
 // ==================== 相位生命周期计算 ====================
 
-// CalculateAspectLifecycle 根据相位容许度计算生命周期
-// 假设行星平均每天移动约1°
-func CalculateAspectLifecycle(orb float64, exactTime time.Time, isApplying bool) *models.FactorLifecycle {
-	// 容许度决定持续天数（每天约1°）
-	durationDays := orb * 2 // 入相+离相
-	durationHours := durationDays * 24
-
-	// 计算开始和结束时间
-	halfDuration := time.Duration(durationHours / 2 * float64(time.Hour))
-
-	startTime := exactTime.Add(-halfDuration)
-	endTime := exactTime.Add(halfDuration)
-
-	// 如果正在入相，峰值在后面
-	// 如果正在离相，峰值已过
-	var peakTime time.Time
-	if isApplying {
-		peakTime = exactTime // 精确时刻即将到来
-	} else {
-		peakTime = exactTime // 精确时刻已过
-	}
+// CalculateAspectLifecycle 根据容许度与相对速度计算相位生命周期
+// 峰值为精确成相时刻，开始/结束为按当前相对速度进入/离开容许度的时刻
+func CalculateAspectLifecycle(orbLimit, relativeSpeed float64, exactTime time.Time) *models.FactorLifecycle {
+	// 相对静止（行星停滞）时按每天 0.01° 估算，避免窗口无限长
+	speed := math.Max(math.Abs(relativeSpeed), 0.01)
+	halfHours := orbLimit / speed * 24
+	halfDuration := time.Duration(halfHours * float64(time.Hour))
 
 	return &models.FactorLifecycle{
-		StartTime: startTime,
-		PeakTime:  peakTime,
-		EndTime:   endTime,
-		Duration:  durationHours,
+		StartTime: exactTime.Add(-halfDuration),
+		PeakTime:  exactTime,
+		EndTime:   exactTime.Add(halfDuration),
+		Duration:  halfHours * 2,
 	}
 }
 
@@ -198,6 +190,7 @@ var RetrogradeDurations = map[models.PlanetID]float64{
 	models.Uranus:  155, // 天王星逆行：约5个月
 	models.Neptune: 160, // 海王星逆行：约5个月
 	models.Pluto:   165, // 冥王星逆行：约5.5个月
+	models.Chiron:  150, // 凯龙逆行：约5个月
 }
 
 // GetRetrogradeDuration 获取行星逆行持续时间（天）
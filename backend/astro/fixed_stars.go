@@ -0,0 +1,474 @@
+package astro
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"star/models"
+	"time"
+)
+
+// ==================== 恒星 ====================
+// 内置主要恒星的 J2000（ICRS）赤经赤纬与自行，不依赖外部 sefstars.txt。
+// 任意历元的位置：按自行线性外推 → 转换到 J2000 黄道 → 黄道岁差（与行星共用 precessEclipticFromJ2000）
+// → 加黄经章动得到真春分点黄经。未计周年光行差（最大约 20″），对 1° 以内的容许度可忽略
+
+// FixedStar 恒星星表条目
+type FixedStar struct {
+	ID         string                 `json:"id"`
+	Name       string                 `json:"name"`
+	RA         float64                `json:"ra"`         // J2000 赤经（度）
+	Dec        float64                `json:"dec"`        // J2000 赤纬（度）
+	PMRA       float64                `json:"pmRa"`       // 赤经自行 μα·cosδ（毫角秒/年）
+	PMDec      float64                `json:"pmDec"`      // 赤纬自行（毫角秒/年）
+	Magnitude  float64                `json:"magnitude"`  // 视星等
+	Nature     string                 `json:"nature"`     // benefic / malefic
+	Planets    string                 `json:"planets"`    // 托勒密的行星性质，如 "Mars/Jupiter"
+	Dimensions models.DimensionImpact `json:"dimensions"` // 维度影响分配
+}
+
+// hms 时分秒转换为度
+func hms(h, m, s float64) float64 {
+	return (h + m/60 + s/3600) * 15
+}
+
+// dms 度分秒转换为度（负号写在度上）
+func dms(d, m, s float64) float64 {
+	if d < 0 {
+		return d - m/60 - s/3600
+	}
+	return d + m/60 + s/3600
+}
+
+// dims 构造维度影响分配（事业、关系、健康、财务、灵性）
+func dims(career, relationship, health, finance, spiritual float64) models.DimensionImpact {
+	return models.DimensionImpact{Career: career, Relationship: relationship, Health: health, Finance: finance, Spiritual: spiritual}
+}
+
+// FixedStars 内置恒星星表（Hipparcos 新归算位置与自行）
+var FixedStars = []FixedStar{
+	{"aldebaran", "Aldebaran", hms(4, 35, 55.239), dms(16, 30, 33.49), 63.45, -188.94, 0.86, "benefic", "Mars", dims(0.50, 0.10, 0.15, 0.15, 0.10)},
+	{"regulus", "Regulus", hms(10, 8, 22.311), dms(11, 58, 1.95), -248.73, 5.59, 1.40, "benefic", "Mars/Jupiter", dims(0.60, 0.05, 0.10, 0.15, 0.10)},
+	{"antares", "Antares", hms(16, 29, 24.460), dms(-26, 25, 55.21), -12.11, -23.30, 1.06, "malefic", "Mars/Jupiter", dims(0.30, 0.15, 0.35, 0.10, 0.10)},
+	{"fomalhaut", "Fomalhaut", hms(22, 57, 39.046), dms(-29, 37, 20.05), 328.95, -164.67, 1.16, "benefic", "Venus/Mercury", dims(0.20, 0.15, 0.10, 0.10, 0.45)},
+	{"spica", "Spica", hms(13, 25, 11.579), dms(-11, 9, 40.75), -42.35, -30.67, 0.97, "benefic", "Venus/Mars", dims(0.30, 0.10, 0.10, 0.35, 0.15)},
+	{"algol", "Algol", hms(3, 8, 10.132), dms(40, 57, 20.33), 2.99, -1.66, 2.12, "malefic", "Saturn/Jupiter", dims(0.15, 0.20, 0.45, 0.10, 0.10)},
+	{"sirius", "Sirius", hms(6, 45, 8.917), dms(-16, 42, 58.02), -546.01, -1223.07, -1.46, "benefic", "Jupiter/Mars", dims(0.45, 0.05, 0.10, 0.25, 0.15)},
+	{"arcturus", "Arcturus", hms(14, 15, 39.672), dms(19, 10, 56.67), -1093.39, -2000.06, -0.05, "benefic", "Mars/Jupiter", dims(0.35, 0.05, 0.10, 0.30, 0.20)},
+	{"vega", "Vega", hms(18, 36, 56.336), dms(38, 47, 1.28), 200.94, 286.23, 0.03, "benefic", "Venus/Mercury", dims(0.30, 0.30, 0.10, 0.10, 0.20)},
+	{"pollux", "Pollux", hms(7, 45, 18.950), dms(28, 1, 34.32), -626.55, -45.80, 1.14, "malefic", "Mars", dims(0.30, 0.20, 0.35, 0.05, 0.10)},
+	{"betelgeuse", "Betelgeuse", hms(5, 55, 10.305), dms(7, 24, 25.43), 27.54, 11.30, 0.50, "benefic", "Mars/Mercury", dims(0.45, 0.05, 0.15, 0.25, 0.10)},
+	{"rigel", "Rigel", hms(5, 14, 32.272), dms(-8, 12, 5.90), 1.31, 0.50, 0.13, "benefic", "Jupiter/Saturn", dims(0.35, 0.10, 0.10, 0.20, 0.25)},
+	{"procyon", "Procyon", hms(7, 39, 18.119), dms(5, 13, 29.96), -714.59, -1036.80, 0.34, "malefic", "Mercury/Mars", dims(0.35, 0.10, 0.15, 0.35, 0.05)},
+	{"alcyone", "Alcyone", hms(3, 47, 29.077), dms(24, 6, 18.49), 19.34, -43.67, 2.87, "malefic", "Moon/Mars", dims(0.10, 0.20, 0.40, 0.05, 0.25)},
+	{"capella", "Capella", hms(5, 16, 41.359), dms(45, 59, 52.77), 75.52, -427.11, 0.08, "benefic", "Mars/Mercury", dims(0.35, 0.10, 0.10, 0.20, 0.25)},
+	{"vindemiatrix", "Vindemiatrix", hms(13, 2, 10.598), dms(10, 57, 32.94), -275.05, 19.96, 2.79, "malefic", "Saturn/Mercury", dims(0.10, 0.45, 0.20, 0.15, 0.10)},
+	{"algorab", "Algorab", hms(12, 29, 51.855), dms(-16, 30, 55.56), -210.57, -138.87, 2.94, "malefic", "Mars/Saturn", dims(0.30, 0.25, 0.25, 0.10, 0.10)},
+	{"zubenElgenubi", "Zuben Elgenubi", hms(14, 50, 52.713), dms(-16, 2, 30.40), -105.68, -68.40, 2.75, "malefic", "Saturn/Mars", dims(0.25, 0.20, 0.35, 0.10, 0.10)},
+	{"zubenEschamali", "Zuben Eschamali", hms(15, 17, 0.414), dms(-9, 22, 58.49), -98.10, -19.65, 2.61, "benefic", "Jupiter/Mercury", dims(0.35, 0.10, 0.10, 0.35, 0.10)},
+	{"alphecca", "Alphecca", hms(15, 34, 41.268), dms(26, 42, 52.90), 120.27, -89.58, 2.22, "benefic", "Venus/Mercury", dims(0.25, 0.40, 0.10, 0.10, 0.15)},
+	{"markab", "Markab", hms(23, 4, 45.653), dms(15, 12, 18.96), 60.40, -41.30, 2.48, "malefic", "Mars/Mercury", dims(0.25, 0.10, 0.40, 0.15, 0.10)},
+	{"scheat", "Scheat", hms(23, 3, 46.458), dms(28, 4, 58.03), 187.65, 136.93, 2.42, "malefic", "Mars/Mercury", dims(0.20, 0.10, 0.40, 0.10, 0.20)},
+	{"rasAlhague", "Ras Alhague", hms(17, 34, 56.069), dms(12, 33, 36.13), 108.07, -221.57, 2.07, "malefic", "Saturn/Venus", dims(0.10, 0.15, 0.40, 0.05, 0.30)},
+	{"denebola", "Denebola", hms(11, 49, 3.578), dms(14, 34, 19.41), -497.68, -114.67, 2.14, "malefic", "Saturn/Venus", dims(0.40, 0.25, 0.15, 0.10, 0.10)},
+	{"achernar", "Achernar", hms(1, 37, 42.845), dms(-57, 14, 12.31), 87.00, -38.24, 0.46, "benefic", "Jupiter", dims(0.30, 0.10, 0.10, 0.10, 0.40)},
+	{"canopus", "Canopus", hms(6, 23, 57.110), dms(-52, 41, 44.38), 19.93, 23.24, -0.74, "benefic", "Saturn/Jupiter", dims(0.30, 0.10, 0.10, 0.10, 0.40)},
+	{"alphard", "Alphard", hms(9, 27, 35.243), dms(-8, 39, 30.96), -15.23, 34.37, 1.99, "malefic", "Saturn/Venus", dims(0.10, 0.35, 0.35, 0.10, 0.10)},
+}
+
+// J2000 平黄赤交角（度）
+const obliquityJ2000 = 23.4392911
+
+// 恒星接触的容许度（度）：黄经合相按星等放宽，赤纬平行固定
+const (
+	fixedStarConjunctionOrb = 1.0
+	fixedStarParallelOrb    = 0.5
+	fixedStarFaintMagnitude = 1.5 // 暗于此星等的恒星合相容许度 ×0.7
+)
+
+// 恒星接触的类型
+const (
+	FixedStarConjunction = "conjunction"
+	FixedStarParallel    = "parallel"
+)
+
+// FixedStarPosition 恒星在某历元的位置
+type FixedStarPosition struct {
+	Star        string          `json:"star"`
+	Name        string          `json:"name"`
+	Longitude   float64         `json:"longitude"`
+	Latitude    float64         `json:"latitude"`
+	Declination float64         `json:"declination"`
+	Sign        models.ZodiacID `json:"sign"`
+	SignDegree  float64         `json:"signDegree"`
+	Magnitude   float64         `json:"magnitude"`
+	Nature      string          `json:"nature"`
+}
+
+// GetFixedStar 按标识查找恒星
+func GetFixedStar(id string) *FixedStar {
+	for _, s := range FixedStars {
+		if s.ID == id {
+			return &s
+		}
+	}
+	return nil
+}
+
+// eclipticOfDate 恒星在 jd（世界时）的地心视黄经、黄纬（度，真春分点）与赤纬
+func (s FixedStar) eclipticOfDate(jd float64) (lon, lat, decl float64) {
+	jdTT := TerrestrialTime(jd)
+	years := (jdTT - J2000) / 365.25
+
+	// 自行外推（毫角秒 → 度）
+	dec := s.Dec + s.PMDec*years/3.6e6
+	ra := s.RA + s.PMRA*years/3.6e6/math.Cos(s.Dec*DEG_TO_RAD)
+
+	// J2000 赤道 → J2000 黄道
+	a, d, e := ra*DEG_TO_RAD, dec*DEG_TO_RAD, obliquityJ2000*DEG_TO_RAD
+	l := math.Atan2(math.Sin(a)*math.Cos(e)+math.Tan(d)*math.Sin(e), math.Cos(a))
+	b := math.Asin(math.Sin(d)*math.Cos(e) - math.Cos(d)*math.Sin(e)*math.Sin(a))
+
+	// 黄道岁差与章动
+	l, b = precessEclipticFromJ2000(l, b, jdTT)
+	dpsi, deps := Nutation(jdTT)
+	lon = NormalizeAngle(l*RAD_TO_DEG + dpsi)
+	lat = b * RAD_TO_DEG
+	// 黄经含章动，赤纬须用真黄赤交角
+	_, decl = EquatorialCoordinates(lon, lat, MeanObliquity(jdTT)+deps)
+	return lon, lat, decl
+}
+
+// Position 恒星在 jd 的位置，offset 为 ZodiacOffset（回归黄道为 0）
+func (s FixedStar) Position(jd, offset float64) FixedStarPosition {
+	lon, lat, decl := s.eclipticOfDate(jd)
+	lon = NormalizeAngle(lon - offset)
+	return FixedStarPosition{
+		Star:        s.ID,
+		Name:        s.Name,
+		Longitude:   lon,
+		Latitude:    lat,
+		Declination: decl,
+		Sign:        GetZodiacByLongitude(lon).ID,
+		SignDegree:  math.Mod(lon, 30),
+		Magnitude:   s.Magnitude,
+		Nature:      s.Nature,
+	}
+}
+
+// CalculateFixedStarPositions 全部恒星在 jd 的位置
+func CalculateFixedStarPositions(jd, offset float64) []FixedStarPosition {
+	positions := make([]FixedStarPosition, len(FixedStars))
+	for i, s := range FixedStars {
+		positions[i] = s.Position(jd, offset)
+	}
+	return positions
+}
+
+// orb 恒星接触的容许度
+func (s FixedStar) orb(kind string) float64 {
+	if kind == FixedStarParallel {
+		return fixedStarParallelOrb
+	}
+	if s.Magnitude > fixedStarFaintMagnitude {
+		return fixedStarConjunctionOrb * 0.7
+	}
+	return fixedStarConjunctionOrb
+}
+
+// brightness 星等决定的强度系数（最亮的恒星为 1.2，三等星约 0.6）
+func (s FixedStar) brightness() float64 {
+	return math.Max(0.6, math.Min(1.2, 1.2-0.2*s.Magnitude))
+}
+
+// ==================== 本命接触 ====================
+
+// CalculateNatalFixedStars 本命天体与四轴的恒星接触（planets 为星盘黄道下的位置）
+// 天体计合相与赤纬平行，上升点与天顶只计合相
+func CalculateNatalFixedStars(jd, offset float64, planets []models.PlanetPosition, asc, mc float64) []models.FixedStarContact {
+	var contacts []models.FixedStarContact
+	for _, s := range FixedStars {
+		star := s.Position(jd, offset)
+		add := func(point, name, kind string, orb float64) {
+			if orb <= s.orb(kind) {
+				contacts = append(contacts, models.FixedStarContact{
+					Star: s.ID, StarName: s.Name, Point: point, Name: name,
+					Kind: kind, Orb: orb, Nature: s.Nature,
+				})
+			}
+		}
+
+		for _, p := range planets {
+			add(string(p.ID), p.Name, FixedStarConjunction, AngleDifference(p.Longitude, star.Longitude))
+			add(string(p.ID), p.Name, FixedStarParallel, math.Abs(p.Declination-star.Declination))
+		}
+		add("ascendant", "Ascendant", FixedStarConjunction, AngleDifference(asc, star.Longitude))
+		add("midheaven", "Midheaven", FixedStarConjunction, AngleDifference(mc, star.Longitude))
+	}
+	return contacts
+}
+
+// ==================== 行运接触 ====================
+
+// FixedStarTransitBodies 计算恒星行运的慢行星（快行星一年内多次掠过，不作为年度级因子）
+var FixedStarTransitBodies = []models.PlanetID{
+	models.Jupiter, models.Saturn, models.Uranus, models.Neptune, models.Pluto,
+}
+
+// fixedStarHorizon 恒星接触窗口向两侧搜索的最大天数（冥王星可在 1° 内停留两年以上）
+const fixedStarHorizon = 1500.0
+
+// FixedStarTransit 一次慢行星与恒星的接触窗口
+type FixedStarTransit struct {
+	Star    string          `json:"star"`
+	Name    string          `json:"name"`
+	Planet  models.PlanetID `json:"planet"`
+	Kind    string          `json:"kind"` // conjunction / parallel
+	Orb     float64         `json:"orb"`  // 生效容许度
+	Start   time.Time       `json:"start"`
+	End     time.Time       `json:"end"`
+	Exact   []time.Time     `json:"exact,omitempty"` // 精确时刻，逆行往返时可有三次；掠过未精确时为空
+	Closest time.Time       `json:"closest"`         // 最接近的时刻（有精确时刻时为第一次精确）
+	Nature  string          `json:"nature"`
+}
+
+// fixedStarOffset 行运天体与恒星的偏离：合相为黄经差（±180°），平行为赤纬差
+func fixedStarOffset(provider EphemerisProvider, body models.PlanetID, s FixedStar, kind string) func(float64) float64 {
+	return func(jd float64) float64 {
+		pos := planetPositionFrom(provider, body, jd)
+		lon, _, decl := s.eclipticOfDate(jd)
+		if kind == FixedStarParallel {
+			return pos.Declination - decl
+		}
+		return normalizeSigned(pos.Longitude - lon)
+	}
+}
+
+// findFixedStarTransit 求 jd 时刻处于容许度内的接触窗口
+func findFixedStarTransit(provider EphemerisProvider, body models.PlanetID, s FixedStar, kind string, jd float64) FixedStarTransit {
+	offset := fixedStarOffset(provider, body, s, kind)
+	orb := s.orb(kind)
+	outside := func(x float64) float64 { return math.Abs(offset(x)) - orb }
+
+	step, ok := transitSearchSteps[body]
+	if !ok {
+		step = 1
+	}
+
+	// 从以 J2000 为原点的固定网格出发搜索，使同一窗口内任一时刻求得的窗口完全一致（评分可复用缓存）
+	cell := J2000 + math.Floor((jd-J2000)/step)*step
+	anchor := jd
+	switch {
+	case outside(cell) <= 0:
+		anchor = cell
+	case outside(cell+step) <= 0:
+		anchor = cell + step
+	default:
+		// 窗口短于一个步长：取网格细分点中最接近的一点
+		best := 0.0
+		for i := 1; i < 16; i++ {
+			x := cell + float64(i)*step/16
+			if v := outside(x); v < best {
+				anchor, best = x, v
+			}
+		}
+	}
+
+	edge := func(dir float64) float64 {
+		prev := anchor
+		for d := step; d <= fixedStarHorizon; d += step {
+			x := anchor + dir*d
+			if outside(x) > 0 {
+				if dir < 0 {
+					return bisect(outside, x, prev)
+				}
+				return bisect(outside, prev, x)
+			}
+			prev = x
+		}
+		return anchor + dir*fixedStarHorizon
+	}
+	start, end := edge(-1), edge(1)
+
+	w := FixedStarTransit{
+		Star: s.ID, Name: s.Name, Planet: body, Kind: kind, Orb: orb,
+		Start: julianDayToTime(start), End: julianDayToTime(end), Nature: s.Nature,
+	}
+
+	// 窗口内采样找精确时刻，同时记录偏离最小的采样点
+	closest, closestOffset := start, math.Abs(offset(start))
+	prevX, prev := start, offset(start)
+	for prevX < end {
+		x := math.Min(prevX+step, end)
+		cur := offset(x)
+		if crossesZero(prev, cur) {
+			w.Exact = append(w.Exact, julianDayToTime(bisect(offset, prevX, x)))
+		}
+		if math.Abs(cur) < closestOffset {
+			closest, closestOffset = x, math.Abs(cur)
+		}
+		prevX, prev = x, cur
+	}
+	w.Closest = julianDayToTime(closest)
+	if len(w.Exact) > 0 {
+		w.Closest = w.Exact[0]
+	}
+	return w
+}
+
+// fixedStarKey 恒星接触窗口缓存键
+type fixedStarKey struct {
+	provider string
+	planet   models.PlanetID
+	star     string
+	kind     string
+}
+
+// fixedStarWindows 按星历源、天体、恒星与接触方式缓存的接触窗口
+var fixedStarWindows = newSpanCache[fixedStarKey, FixedStarTransit](256)
+
+// FixedStarTransitAt 求 jd 时刻行运天体与恒星处于容许度内的接触窗口，不在容许度内时返回 nil
+func FixedStarTransitAt(body models.PlanetID, s FixedStar, kind string, jd float64) *FixedStarTransit {
+	provider := CurrentEphemerisProvider()
+	if math.Abs(fixedStarOffset(provider, body, s, kind)(jd)) > s.orb(kind) {
+		return nil
+	}
+
+	key := fixedStarKey{provider.Name(), body, s.ID, kind}
+	t := julianDayToTime(jd)
+
+	if w, ok := fixedStarWindows.get(key, func(w FixedStarTransit) bool {
+		return !t.Before(w.Start) && !t.After(w.End)
+	}); ok {
+		return &w
+	}
+
+	w := findFixedStarTransit(provider, body, s, kind, jd)
+
+	fixedStarWindows.put(key, w)
+
+	return &w
+}
+
+// FindFixedStarTransits 求与 [start, end) 有交集的慢行星恒星接触窗口，按最接近时刻排序
+func FindFixedStarTransits(start, end time.Time) []FixedStarTransit {
+	from, to := DateToJulianDay(start.UTC()), DateToJulianDay(end.UTC())
+
+	var transits []FixedStarTransit
+	for _, body := range FixedStarTransitBodies {
+		step, ok := transitSearchSteps[body]
+		if !ok {
+			step = 1
+		}
+		for _, s := range FixedStars {
+			for _, kind := range []string{FixedStarConjunction, FixedStarParallel} {
+				for jd := from; jd < to; jd += step {
+					w := FixedStarTransitAt(body, s, kind, jd)
+					if w == nil {
+						continue
+					}
+					transits = append(transits, *w)
+					// 越过本窗口继续搜索
+					jd = math.Max(jd, DateToJulianDay(w.End))
+				}
+			}
+		}
+	}
+
+	sort.Slice(transits, func(i, j int) bool {
+		return transits[i].Closest.Before(transits[j].Closest)
+	})
+	return transits
+}
+
+// ==================== 恒星因子 ====================
+
+// fixedStarBaseValue 恒星因子的基础值
+const fixedStarBaseValue = 2.5
+
+// calculateFixedStarFactorsV2 计算慢行星合相/平行恒星的年度级因子
+// 基础值 = 2.5 × 星等强度 × 接近程度 × 吉凶（吉星为正、凶星为负），平行 ×0.8；
+// 该恒星同时与本命天体或四轴接触时 ×1.5（本命恒星主题被引动）
+func calculateFixedStarFactorsV2(chart *models.NatalChart, weight float64, date time.Time) []models.InfluenceFactor {
+	var factors []models.InfluenceFactor
+	jd := DateToJulianDay(date)
+
+	// 行运天体与恒星位置在本时刻只各算一次
+	bodies := make([]models.PlanetPosition, len(FixedStarTransitBodies))
+	for i, body := range FixedStarTransitBodies {
+		bodies[i] = CalculatePlanetPositionUnified(body, jd)
+	}
+
+	for _, s := range FixedStars {
+		lon, _, decl := s.eclipticOfDate(jd)
+		for _, pos := range bodies {
+			body := pos.ID
+			for _, kind := range []string{FixedStarConjunction, FixedStarParallel} {
+				offset := AngleDifference(pos.Longitude, lon)
+				if kind == FixedStarParallel {
+					offset = math.Abs(pos.Declination - decl)
+				}
+				if offset > s.orb(kind) {
+					continue
+				}
+				w := FixedStarTransitAt(body, s, kind, jd)
+				if w == nil {
+					continue
+				}
+
+				value := fixedStarBaseValue * s.brightness() * (1 - 0.5*offset/w.Orb)
+				if s.Nature == "malefic" {
+					value = -value
+				}
+				if kind == FixedStarParallel {
+					value *= 0.8
+				}
+
+				info := GetPlanetInfo(body)
+				description := fmt.Sprintf("Transit %s %s %s (%s star of %s nature, orb %.2f°), closest on %s",
+					info.Name, fixedStarKindVerb(kind), s.Name, s.Nature, s.Planets, offset, w.Closest.Format("2006-01-02"))
+				for _, c := range chart.FixedStars {
+					if c.Star == s.ID {
+						value *= 1.5
+						description += "; the star is prominent in the natal chart on " + c.Name
+						break
+					}
+				}
+
+				// 维度影响：恒星主题为主，行运天体为辅
+				planetImpact := GetPlanetDimensionImpact(body)
+				impact := models.DimensionImpact{
+					Career:       0.7*s.Dimensions.Career + 0.3*planetImpact.Career,
+					Relationship: 0.7*s.Dimensions.Relationship + 0.3*planetImpact.Relationship,
+					Health:       0.7*s.Dimensions.Health + 0.3*planetImpact.Health,
+					Finance:      0.7*s.Dimensions.Finance + 0.3*planetImpact.Finance,
+					Spiritual:    0.7*s.Dimensions.Spiritual + 0.3*planetImpact.Spiritual,
+				}
+
+				factors = append(factors, models.InfluenceFactor{
+					Type:            models.FactorFixedStar,
+					Name:            info.Name + " " + fixedStarKindVerb(kind) + " " + s.Name,
+					Description:     description,
+					TimeLevel:       models.TimeLevelYearly,
+					Lifecycle:       CreateLifecycleWithPeak(w.Start, w.Closest, w.End),
+					BaseValue:       value,
+					Weight:          weight,
+					DimensionImpact: impact,
+					SourcePlanet:    body,
+					IsPositive:      value > 0,
+					AstroReason:     "A slow planet joining a bright fixed star lends it the star's reputation for the length of the contact",
+				})
+			}
+		}
+	}
+
+	return factors
+}
+
+// fixedStarKindVerb 接触类型的描述
+func fixedStarKindVerb(kind string) string {
+	if kind == FixedStarParallel {
+		return "parallel"
+	}
+	return "conjunct"
+}
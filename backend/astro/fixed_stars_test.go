@@ -0,0 +1,86 @@
+package astro
+
+import (
+	"math"
+	"star/models"
+	"testing"
+	"time"
+)
+
+// TestFixedStarPositions 测试恒星 2000 年初的回归黄经与岁差
+func TestFixedStarPositions(t *testing.T) {
+	jd := DateToJulianDay(time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC))
+	wants := map[string]float64{
+		"regulus":   149.83,
+		"spica":     203.84,
+		"algol":     56.17,
+		"aldebaran": 69.79,
+		"antares":   249.76,
+		"fomalhaut": 333.87,
+		"sirius":    104.08,
+	}
+	for id, want := range wants {
+		pos := GetFixedStar(id).Position(jd, 0)
+		if AngleDifference(pos.Longitude, want) > 0.05 {
+			t.Errorf("%s 黄经应约为 %.2f°，实际 %.4f°", id, want, pos.Longitude)
+		}
+	}
+
+	// 岁差约每 72 年 1°
+	regulus := GetFixedStar("regulus")
+	later := DateToJulianDay(time.Date(2072, 1, 1, 12, 0, 0, 0, time.UTC))
+	if drift := regulus.Position(later, 0).Longitude - regulus.Position(jd, 0).Longitude; math.Abs(drift-1.0) > 0.02 {
+		t.Errorf("轩辕十四 72 年岁差应约为 1°，实际 %.4f°", drift)
+	}
+}
+
+// TestFixedStarContactsAndFactor 测试本命恒星接触与慢行星恒星因子
+func TestFixedStarContactsAndFactor(t *testing.T) {
+	useProvider(t, NewPreciseProvider())
+
+	// 本命太阳放在轩辕十四上
+	jd := DateToJulianDay(time.Date(1990, 8, 23, 0, 0, 0, 0, time.UTC))
+	regulus := GetFixedStar("regulus").Position(jd, 0)
+	planets := []models.PlanetPosition{newPlanetPosition(models.Sun, regulus.Longitude+0.3, 0, false)}
+	contacts := CalculateNatalFixedStars(jd, 0, planets, 0, 90)
+	found := false
+	for _, c := range contacts {
+		if c.Star == "regulus" && c.Kind == FixedStarConjunction && c.Point == string(models.Sun) {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("太阳应合相轩辕十四: %+v", contacts)
+	}
+
+	// 木星 2003-2004 年经过轩辕十四（约 150°）
+	start := time.Date(2003, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2004, 12, 1, 0, 0, 0, 0, time.UTC)
+	var window *FixedStarTransit
+	for _, w := range FindFixedStarTransits(start, end) {
+		if w.Star == "regulus" && w.Planet == models.Jupiter && w.Kind == FixedStarConjunction {
+			window = &w
+			break
+		}
+	}
+	if window == nil || len(window.Exact) == 0 {
+		t.Fatalf("应找到木星合相轩辕十四的精确时刻: %+v", window)
+	}
+	t.Logf("木星合相轩辕十四 %s ~ %s，精确 %v", window.Start.Format("2006-01-02"), window.End.Format("2006-01-02"), window.Exact)
+
+	chart := &models.NatalChart{FixedStars: []models.FixedStarContact{{Star: "regulus", Name: "Sun"}}}
+	for _, f := range calculateFixedStarFactorsV2(chart, 1, window.Exact[0]) {
+		if f.SourcePlanet != models.Jupiter || f.Name != "Jupiter conjunct Regulus" {
+			continue
+		}
+		// 吉星、亮星、精确合相且本命被引动：2.5 × 0.92 × 1 × 1.5
+		if !f.IsPositive || math.Abs(f.BaseValue-2.5*0.92*1.5) > 0.01 {
+			t.Errorf("木星合相轩辕十四的基础值应约为 %.2f，实际 %.4f", 2.5*0.92*1.5, f.BaseValue)
+		}
+		if f.TimeLevel != models.TimeLevelYearly || f.Lifecycle == nil {
+			t.Errorf("恒星因子应为年度级并带生命周期: %+v", f)
+		}
+		return
+	}
+	t.Error("精确合相时应产生木星合相轩辕十四的因子")
+}
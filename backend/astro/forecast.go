@@ -17,14 +17,19 @@ import (
 
 // CalculateDailyForecast 计算每日预测
 func CalculateDailyForecast(chart *models.NatalChart, date time.Time, withFactors bool) *models.DailyForecast {
+	return dailyForecast(CurrentScoringConfig(), chart, date, withFactors)
+}
+
+// dailyForecast 按配置快照计算每日预测
+func dailyForecast(cfg *ScoringConfig, chart *models.NatalChart, date time.Time, withFactors bool) *models.DailyForecast {
 	// 使用统一计算逻辑获取日分数（24小时平均）
-	dailyScore := CalculateDailyScore(chart, date)
+	dailyScore := dailyUnifiedScore(cfg, chart, date)
 
 	// 获取当前行星位置（用于月亮信息和相位）
-	transitPositions := GetTransitPositions(date)
+	transitPositions := GetTransitPositionsForChart(chart, date)
 
 	// 计算行运相位
-	activeAspects := CalculateTransitToNatalAspects(transitPositions, chart.Planets)
+	activeAspects := CalculateTransitToNatalAspects(transitPositions, NatalPoints(chart))
 
 	// 获取月亮信息
 	var moonPos models.PlanetPosition
@@ -52,7 +57,7 @@ func CalculateDailyForecast(chart *models.NatalChart, date time.Time, withFactor
 	var factors *models.FactorResult
 	var topFactors []models.InfluenceFactor
 	if withFactors {
-		factors = CalculateInfluenceFactors(chart, date, transitPositions)
+		factors = cfg.InfluenceFactors(chart, date, transitPositions)
 		topFactors = getTopFactors(factors, 5)
 	}
 
@@ -66,7 +71,7 @@ func CalculateDailyForecast(chart *models.NatalChart, date time.Time, withFactor
 	}
 
 	// 计算小时预测（使用统一计算逻辑）
-	hourlyBreakdown := calculateUnifiedHourlyBreakdown(chart, date)
+	hourlyBreakdown := calculateUnifiedHourlyBreakdown(cfg, chart, date)
 
 	// 生成主题
 	overallTheme := generateDailyTheme(moonSign, activeAspects)
@@ -98,22 +103,23 @@ func CalculateWeeklyForecast(chart *models.NatalChart, startDate time.Time, with
 	}
 	startDate = startDate.AddDate(0, 0, -(weekday - 1))
 	endDate := startDate.AddDate(0, 0, 6)
+	cfg := CurrentScoringConfig()
 
 	// 使用统一计算逻辑获取周分数（7天平均）
-	weeklyScore := CalculateWeeklyScore(chart, startDate)
+	weeklyScore := weeklyUnifiedScore(cfg, chart, startDate)
 
 	// 计算每日摘要
 	dailySummaries := make([]models.DailySummary, 7)
 	for i := 0; i < 7; i++ {
 		date := startDate.AddDate(0, 0, i)
-		dailyForecast := CalculateDailyForecast(chart, date, false)
+		forecast := dailyForecast(cfg, chart, date, false)
 
 		dailySummaries[i] = models.DailySummary{
 			Date:         date.Format("2006-01-02"),
-			DayOfWeek:    dailyForecast.DayOfWeek,
-			OverallScore: dailyForecast.OverallScore,
-			MoonSign:     dailyForecast.MoonSign.Sign,
-			KeyTheme:     dailyForecast.OverallTheme,
+			DayOfWeek:    forecast.DayOfWeek,
+			OverallScore: forecast.OverallScore,
+			MoonSign:     forecast.MoonSign.Sign,
+			KeyTheme:     forecast.OverallTheme,
 		}
 	}
 
@@ -128,7 +134,7 @@ func CalculateWeeklyForecast(chart *models.NatalChart, startDate time.Time, with
 
 	// 找出关键日期和最佳日期
 	keyDates := findKeyDates(dailySummaries)
-	bestDaysFor := findBestDaysFor(chart, startDate)
+	bestDaysFor := findBestDaysFor(cfg, chart, startDate)
 
 	// 获取周度行运
 	weeklyTransits := getWeeklyTransits(chart, startDate, endDate)
@@ -142,7 +148,7 @@ func CalculateWeeklyForecast(chart *models.NatalChart, startDate time.Time, with
 	// 周度因子
 	var weeklyFactors *models.FactorResult
 	if withFactors {
-		weeklyFactors = CalculateInfluenceFactors(chart, startDate, GetTransitPositions(startDate))
+		weeklyFactors = cfg.InfluenceFactors(chart, startDate, GetTransitPositionsForChart(chart, startDate))
 	}
 
 	return &models.WeeklyForecast{
@@ -161,14 +167,15 @@ func CalculateWeeklyForecast(chart *models.NatalChart, startDate time.Time, with
 }
 
 // calculateUnifiedHourlyBreakdown 使用统一计算逻辑计算小时预测
-func calculateUnifiedHourlyBreakdown(chart *models.NatalChart, date time.Time) []models.HourlyForecast {
+func calculateUnifiedHourlyBreakdown(cfg *ScoringConfig, chart *models.NatalChart, date time.Time) []models.HourlyForecast {
 	breakdown := make([]models.HourlyForecast, 24)
 
 	for hour := 0; hour < 24; hour++ {
 		t := time.Date(date.Year(), date.Month(), date.Day(), hour, 0, 0, 0, date.Location())
-		hourlyScore := CalculateUnifiedHourlyScore(chart, t)
+		hourlyScore := unifiedHourlyScore(cfg, chart, t, "")
 
-		planetaryHour := CalculatePlanetaryHour(date, hour)
+		lat, lon := ObserverLocation(chart)
+		planetaryHour := CalculatePlanetaryHourEnhanced(t, lat, lon).Ruler
 		bestFor := getBestActivitiesForHour(planetaryHour)
 
 		breakdown[hour] = models.HourlyForecast{
@@ -265,7 +272,7 @@ func findKeyDates(summaries []models.DailySummary) []models.KeyDate {
 }
 
 // findBestDaysFor 找出各活动最佳日期
-func findBestDaysFor(chart *models.NatalChart, startDate time.Time) map[string][]string {
+func findBestDaysFor(cfg *ScoringConfig, chart *models.NatalChart, startDate time.Time) map[string][]string {
 	bestDays := map[string][]string{
 		"career":       {},
 		"relationship": {},
@@ -274,7 +281,7 @@ func findBestDaysFor(chart *models.NatalChart, startDate time.Time) map[string][
 
 	for i := 0; i < 7; i++ {
 		date := startDate.AddDate(0, 0, i)
-		forecast := CalculateDailyForecast(chart, date, false)
+		forecast := dailyForecast(cfg, chart, date, false)
 		dateStr := date.Format("2006-01-02")
 
 		if forecast.Dimensions.Career >= 65 {
@@ -297,8 +304,8 @@ func getWeeklyTransits(chart *models.NatalChart, startDate, _ time.Time) []model
 
 	// 简化实现：检查一周内的重要行运
 	midWeek := startDate.AddDate(0, 0, 3)
-	transitPositions := GetTransitPositions(midWeek)
-	aspects := CalculateTransitToNatalAspects(transitPositions, chart.Planets)
+	transitPositions := GetTransitPositionsForChart(chart, midWeek)
+	aspects := CalculateTransitToNatalAspects(transitPositions, NatalPoints(chart))
 
 	for _, asp := range aspects {
 		if asp.Strength > 0.7 {
@@ -0,0 +1,260 @@
+package astro
+
+import (
+	"fmt"
+	"math"
+	"star/models"
+	"strings"
+)
+
+// ==================== 分宫制定义 ====================
+
+// HouseSystemInfo 分宫制信息
+type HouseSystemInfo struct {
+	ID       models.HouseSystem
+	Code     byte // Swiss Ephemeris 分宫制代码
+	Name     string
+	Quadrant bool // 是否为象限制（宫头依赖 MC/ASC 之间的划分）
+}
+
+// HouseSystems 支持的分宫制列表
+var HouseSystems = []HouseSystemInfo{
+	{models.HousePlacidus, 'P', "Placidus", true},
+	{models.HouseWholeSign, 'W', "Whole Sign", false},
+	{models.HouseEqual, 'E', "Equal", false},
+	{models.HouseKoch, 'K', "Koch", true},
+	{models.HousePorphyry, 'O', "Porphyry", true},
+	{models.HouseRegiomontanus, 'R', "Regiomontanus", true},
+	{models.HouseCampanus, 'C', "Campanus", true},
+	{models.HouseAlcabitius, 'B', "Alcabitius", true},
+}
+
+// DefaultHouseSystem 默认分宫制
+const DefaultHouseSystem = models.HousePlacidus
+
+// GetHouseSystemInfo 获取分宫制信息
+func GetHouseSystemInfo(id models.HouseSystem) *HouseSystemInfo {
+	for _, h := range HouseSystems {
+		if h.ID == id {
+			return &h
+		}
+	}
+	return nil
+}
+
+// ParseHouseSystem 解析分宫制
+// 同时接受名称（如 "wholeSign"）和 Swiss Ephemeris 单字母代码（如 "W"），空值返回默认 Placidus
+func ParseHouseSystem(value models.HouseSystem) (models.HouseSystem, error) {
+	s := strings.TrimSpace(string(value))
+	if s == "" {
+		return DefaultHouseSystem, nil
+	}
+	for _, h := range HouseSystems {
+		if strings.EqualFold(s, string(h.ID)) || (len(s) == 1 && strings.ToUpper(s)[0] == h.Code) {
+			return h.ID, nil
+		}
+	}
+	return "", fmt.Errorf("不支持的分宫制: %s", s)
+}
+
+// ResolveHouseSystem 解析分宫制，无法识别时回退到默认 Placidus
+func ResolveHouseSystem(value models.HouseSystem) models.HouseSystem {
+	system, err := ParseHouseSystem(value)
+	if err != nil {
+		return DefaultHouseSystem
+	}
+	return system
+}
+
+// ==================== 纯 Go 宫头计算 ====================
+
+// calculateHouseCusps 按分宫制计算12宫宫头（纯 Go 实现）
+// ramc 为赤经中天（本地恒星时，度），eps 为黄赤交角（度）
+func calculateHouseCusps(system models.HouseSystem, ramc, latitude, eps, asc, mc float64) []float64 {
+	switch system {
+	case models.HouseWholeSign:
+		start := math.Floor(asc/30) * 30
+		return equalCusps(start)
+	case models.HouseEqual:
+		return equalCusps(asc)
+	case models.HousePorphyry:
+		return porphyryCusps(asc, mc)
+	case models.HouseRegiomontanus:
+		return regiomontanusCusps(ramc, latitude, eps, asc, mc)
+	case models.HouseCampanus:
+		return campanusCusps(ramc, latitude, eps, asc, mc)
+	case models.HouseAlcabitius:
+		return alcabitiusCusps(ramc, latitude, eps, asc, mc)
+	case models.HouseKoch:
+		return kochCusps(ramc, latitude, eps, asc, mc)
+	default:
+		return placidusCusps(ramc, latitude, eps, asc, mc)
+	}
+}
+
+// houseSystemForLatitude 返回在给定纬度下实际可用的分宫制
+// Placidus 与 Koch 在极圈内（|φ| ≥ 90° - ε）无定义，与 Swiss Ephemeris 一样回退到 Porphyry
+func houseSystemForLatitude(system models.HouseSystem, latitude, eps float64) models.HouseSystem {
+	if system != models.HousePlacidus && system != models.HouseKoch {
+		return system
+	}
+	if math.Abs(latitude) >= 90-eps {
+		return models.HousePorphyry
+	}
+	return system
+}
+
+// EffectiveHouseSystem 返回指定时刻与纬度下实际使用的分宫制
+func EffectiveHouseSystem(system models.HouseSystem, jd, latitude float64) models.HouseSystem {
+	return houseSystemForLatitude(ResolveHouseSystem(system), latitude, TrueObliquity(jd))
+}
+
+// equalCusps 从起点开始每30°一个宫头
+func equalCusps(start float64) []float64 {
+	cusps := make([]float64, 12)
+	for i := range cusps {
+		cusps[i] = NormalizeAngle(start + float64(i)*30)
+	}
+	return cusps
+}
+
+// quadrantCusps 由四个中间宫头组装象限制的12宫
+func quadrantCusps(asc, mc, c11, c12, c2, c3 float64) []float64 {
+	return []float64{
+		NormalizeAngle(asc),
+		NormalizeAngle(c2),
+		NormalizeAngle(c3),
+		NormalizeAngle(mc + 180),
+		NormalizeAngle(c11 + 180),
+		NormalizeAngle(c12 + 180),
+		NormalizeAngle(asc + 180),
+		NormalizeAngle(c2 + 180),
+		NormalizeAngle(c3 + 180),
+		NormalizeAngle(mc),
+		NormalizeAngle(c11),
+		NormalizeAngle(c12),
+	}
+}
+
+// porphyryCusps Porphyry：三等分 MC-ASC 与 ASC-IC 之间的黄道弧
+func porphyryCusps(asc, mc float64) []float64 {
+	upper := NormalizeAngle(asc - mc)
+	lower := 180 - upper
+	return quadrantCusps(asc, mc,
+		mc+upper/3, mc+2*upper/3,
+		asc+lower/3, asc+2*lower/3)
+}
+
+// regiomontanusCusps Regiomontanus：等分天赤道，宫圈经过地平南北点
+func regiomontanusCusps(ramc, latitude, eps, asc, mc float64) []float64 {
+	tanPhi := math.Tan(latitude * DEG_TO_RAD)
+	pole1 := math.Atan(tanPhi*0.5) * RAD_TO_DEG            // sin 30°
+	pole2 := math.Atan(tanPhi*math.Sqrt(3)/2) * RAD_TO_DEG // sin 60°
+	return quadrantCusps(asc, mc,
+		ascendantForPole(ramc-60, pole1, eps),
+		ascendantForPole(ramc-30, pole2, eps),
+		ascendantForPole(ramc+30, pole2, eps),
+		ascendantForPole(ramc+60, pole1, eps))
+}
+
+// campanusCusps Campanus：等分卯酉圈
+func campanusCusps(ramc, latitude, eps, asc, mc float64) []float64 {
+	phi := latitude * DEG_TO_RAD
+	cosPhi := math.Cos(phi)
+	pole1 := math.Asin(math.Sin(phi)/2) * RAD_TO_DEG
+	pole2 := math.Asin(math.Sqrt(3)/2*math.Sin(phi)) * RAD_TO_DEG
+	offset1 := math.Atan2(math.Sqrt(3), cosPhi) * RAD_TO_DEG
+	offset2 := math.Atan2(1/math.Sqrt(3), cosPhi) * RAD_TO_DEG
+	return quadrantCusps(asc, mc,
+		ascendantForPole(ramc-offset1, pole1, eps),
+		ascendantForPole(ramc-offset2, pole2, eps),
+		ascendantForPole(ramc+offset2, pole2, eps),
+		ascendantForPole(ramc+offset1, pole1, eps))
+}
+
+// alcabitiusCusps Alcabitius：三等分上升点的昼夜半弧，再沿赤纬圈投影到黄道
+func alcabitiusCusps(ramc, latitude, eps, asc, mc float64) []float64 {
+	dek := math.Asin(math.Sin(asc*DEG_TO_RAD) * math.Sin(eps*DEG_TO_RAD))
+	r := -math.Tan(latitude*DEG_TO_RAD) * math.Tan(dek)
+	r = math.Max(-1, math.Min(1, r))
+	sda := math.Acos(r) * RAD_TO_DEG // 昼半弧
+	sna := 180 - sda                 // 夜半弧
+	return quadrantCusps(asc, mc,
+		ascendantForPole(ramc+sda/3-90, 0, eps),
+		ascendantForPole(ramc+2*sda/3-90, 0, eps),
+		ascendantForPole(ramc+90-2*sna/3, 0, eps),
+		ascendantForPole(ramc+90-sna/3, 0, eps))
+}
+
+// placidusMaxIterations Placidus 迭代最大次数
+const placidusMaxIterations = 100
+
+// placidusCusps Placidus：三等分每个黄道点自身的昼半弧与夜半弧
+func placidusCusps(ramc, latitude, eps, asc, mc float64) []float64 {
+	tanPhi := math.Tan(latitude * DEG_TO_RAD)
+	return quadrantCusps(asc, mc,
+		placidusCusp(ramc+30, 1.0/3, tanPhi, eps),
+		placidusCusp(ramc+60, 2.0/3, tanPhi, eps),
+		placidusCusp(ramc+120, 2.0/3, tanPhi, eps),
+		placidusCusp(ramc+150, 1.0/3, tanPhi, eps))
+}
+
+// placidusCusp 迭代求解单个 Placidus 宫头
+// oa 为宫头所在宫圈的斜升，fraction 为半弧的分割比例；
+// 每次迭代由当前宫头赤纬求出使其时角恰为半弧 fraction 倍的极高，直至收敛
+func placidusCusp(oa, fraction, tanPhi, eps float64) float64 {
+	e := eps * DEG_TO_RAD
+	sinEps := math.Sin(e)
+	tanEps := math.Tan(e)
+
+	// 初值：以黄赤交角处的赤纬估算极高
+	a := math.Asin(tanPhi * tanEps)
+	pole := math.Atan(math.Sin(a*fraction)/tanEps) * RAD_TO_DEG
+	cusp := ascendantForPole(oa-90, pole, eps)
+
+	for i := 0; i < placidusMaxIterations; i++ {
+		tanDecl := math.Tan(math.Asin(sinEps * math.Sin(cusp*DEG_TO_RAD)))
+		if math.Abs(tanDecl) < 1e-10 {
+			// 宫头位于分点附近，赤纬为零时极高无关
+			return ascendantForPole(oa-90, 0, eps)
+		}
+
+		pole = math.Atan(math.Sin(math.Asin(tanPhi*tanDecl)*fraction)/tanDecl) * RAD_TO_DEG
+		next := ascendantForPole(oa-90, pole, eps)
+		if AngleDifference(next, cusp) < 1e-10 {
+			return next
+		}
+		cusp = next
+	}
+
+	return cusp
+}
+
+// kochCusps Koch（出生地宫制）：三等分中天度数在出生地极高下的斜升弧
+func kochCusps(ramc, latitude, eps, asc, mc float64) []float64 {
+	phi := latitude * DEG_TO_RAD
+	e := eps * DEG_TO_RAD
+
+	// 中天赤纬在出生地纬度下的上升差
+	sinA := math.Sin(mc*DEG_TO_RAD) * math.Sin(e) / math.Cos(phi)
+	sinA = math.Max(-1, math.Min(1, sinA))
+	cosA := math.Sqrt(1 - sinA*sinA)
+	c := math.Atan(math.Tan(phi) / cosA)
+	ad3 := math.Asin(math.Sin(c)*sinA) * RAD_TO_DEG / 3
+
+	return quadrantCusps(asc, mc,
+		ascendantForPole(ramc-60-2*ad3, latitude, eps),
+		ascendantForPole(ramc-30-ad3, latitude, eps),
+		ascendantForPole(ramc+30+ad3, latitude, eps),
+		ascendantForPole(ramc+60+2*ad3, latitude, eps))
+}
+
+// ascendantForPole 计算给定赤经中天与极高下的"上升点"
+// pole 等于地理纬度时即为真正的上升点；pole 为 0 时得到赤经为 ramc+90° 的黄道点
+func ascendantForPole(ramc, pole, eps float64) float64 {
+	r := ramc * DEG_TO_RAD
+	e := eps * DEG_TO_RAD
+	y := math.Cos(r)
+	x := -math.Sin(r)*math.Cos(e) - math.Tan(pole*DEG_TO_RAD)*math.Sin(e)
+	return NormalizeAngle(math.Atan2(y, x) * RAD_TO_DEG)
+}
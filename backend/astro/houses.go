@@ -6,102 +6,49 @@ import (
 	"time"
 )
 
-// CalculateHouses 计算宫位 (Placidus 分宫制)
-func CalculateHouses(jd float64, latitude, longitude float64) ([]models.HouseCusp, float64, float64) {
-	// 计算本地恒星时
+// CalculateHouses 计算宫位（纯 Go 实现，支持多种分宫制）
+func CalculateHouses(jd float64, latitude, longitude float64, system models.HouseSystem) ([]models.HouseCusp, float64, float64) {
+	// 计算本地恒星时（赤经中天）与真黄赤交角
 	lst := calculateLocalSiderealTime(jd, longitude)
+	eps := TrueObliquity(jd)
 
 	// 计算上升点和中天
-	ascendant := calculateAscendant(lst, latitude)
-	midheaven := calculateMidheaven(lst)
+	ascendant := calculateAscendant(lst, latitude, eps)
+	midheaven := calculateMidheaven(lst, eps)
 
-	// 计算12宫位
+	// 按分宫制计算12宫位（极圈内无定义的分宫制回退到 Porphyry）
+	effective := houseSystemForLatitude(ResolveHouseSystem(system), latitude, eps)
+	cusps := calculateHouseCusps(effective, lst, latitude, eps, ascendant, midheaven)
 	houses := make([]models.HouseCusp, 12)
-	houses[0] = createHouseCusp(1, ascendant)
-	houses[9] = createHouseCusp(10, midheaven)
-
-	// 计算其他宫位 (Placidus)
-	houses[1] = createHouseCusp(2, calculatePlacidusHouse(2, ascendant, midheaven, latitude))
-	houses[2] = createHouseCusp(3, calculatePlacidusHouse(3, ascendant, midheaven, latitude))
-	houses[3] = createHouseCusp(4, NormalizeAngle(midheaven+180)) // IC
-	houses[4] = createHouseCusp(5, calculatePlacidusHouse(5, ascendant, midheaven, latitude))
-	houses[5] = createHouseCusp(6, calculatePlacidusHouse(6, ascendant, midheaven, latitude))
-	houses[6] = createHouseCusp(7, NormalizeAngle(ascendant+180)) // DC
-	houses[7] = createHouseCusp(8, calculatePlacidusHouse(8, ascendant, midheaven, latitude))
-	houses[8] = createHouseCusp(9, calculatePlacidusHouse(9, ascendant, midheaven, latitude))
-	houses[10] = createHouseCusp(11, calculatePlacidusHouse(11, ascendant, midheaven, latitude))
-	houses[11] = createHouseCusp(12, calculatePlacidusHouse(12, ascendant, midheaven, latitude))
+	for i, cusp := range cusps {
+		houses[i] = createHouseCusp(i+1, cusp)
+	}
 
 	return houses, ascendant, midheaven
 }
 
-// calculateLocalSiderealTime 计算本地恒星时
+// calculateLocalSiderealTime 计算本地视恒星时
 func calculateLocalSiderealTime(jd float64, longitude float64) float64 {
-	T := (jd - J2000) / 36525.0
-
-	// 格林威治平恒星时
-	GMST := 280.46061837 + 360.98564736629*(jd-J2000) +
-		0.000387933*T*T - T*T*T/38710000
-
-	// 本地恒星时
-	LST := NormalizeAngle(GMST + longitude)
-
-	return LST
+	return NormalizeAngle(ApparentSiderealTime(jd) + longitude)
 }
 
 // calculateAscendant 计算上升点
-func calculateAscendant(lst float64, latitude float64) float64 {
-	RAMC := lst * DEG_TO_RAD
-	phi := latitude * DEG_TO_RAD
-	eps := OBLIQUITY * DEG_TO_RAD
-
+func calculateAscendant(lst float64, latitude float64, eps float64) float64 {
 	// ASC = atan2(cos(RAMC), -sin(RAMC)*cos(ε) - tan(φ)*sin(ε))
-	y := math.Cos(RAMC)
-	x := -math.Sin(RAMC)*math.Cos(eps) - math.Tan(phi)*math.Sin(eps)
-
-	asc := math.Atan2(y, x) * RAD_TO_DEG
-
-	return NormalizeAngle(asc)
+	return ascendantForPole(lst, latitude, eps)
 }
 
 // calculateMidheaven 计算中天
-func calculateMidheaven(lst float64) float64 {
+func calculateMidheaven(lst float64, eps float64) float64 {
 	RAMC := lst * DEG_TO_RAD
-	eps := OBLIQUITY * DEG_TO_RAD
+	e := eps * DEG_TO_RAD
 
 	// MC = atan2(sin(RAMC), cos(RAMC)*cos(ε))
-	mc := math.Atan2(math.Sin(RAMC), math.Cos(RAMC)*math.Cos(eps)) * RAD_TO_DEG
+	mc := math.Atan2(math.Sin(RAMC), math.Cos(RAMC)*math.Cos(e)) * RAD_TO_DEG
 
 	return NormalizeAngle(mc)
 }
 
-// calculatePlacidusHouse 计算 Placidus 宫位
-func calculatePlacidusHouse(house int, asc, mc float64, _ float64) float64 {
-	// 简化实现：使用插值法
-	// 完整实现需要迭代求解
-
-	switch house {
-	case 2:
-		return NormalizeAngle(asc + 30)
-	case 3:
-		return NormalizeAngle(asc + 60)
-	case 5:
-		return NormalizeAngle(mc + 210)
-	case 6:
-		return NormalizeAngle(mc + 240)
-	case 8:
-		return NormalizeAngle(asc + 210)
-	case 9:
-		return NormalizeAngle(asc + 240)
-	case 11:
-		return NormalizeAngle(mc + 30)
-	case 12:
-		return NormalizeAngle(mc + 60)
-	default:
-		return 0
-	}
-}
-
 // createHouseCusp 创建宫位对象
 func createHouseCusp(house int, cusp float64) models.HouseCusp {
 	zodiac := GetZodiacByLongitude(cusp)
@@ -209,7 +156,7 @@ func CalculateModalityBalance(planets []models.PlanetPosition) map[string]float6
 }
 
 // FindDominantPlanets 找出主导行星
-func FindDominantPlanets(planets []models.PlanetPosition, aspects []models.AspectData) []models.PlanetID {
+func FindDominantPlanets(planets []models.PlanetPosition, aspects []models.AspectData, dispositors *models.DispositorTree) []models.PlanetID {
 	// 计算每颗行星的综合权重
 	scores := make(map[models.PlanetID]float64)
 
@@ -218,7 +165,13 @@ func FindDominantPlanets(planets []models.PlanetPosition, aspects []models.Aspec
 		scores[p.ID] = PlanetWeights[p.ID]
 
 		// 尊贵度加成
-		scores[p.ID] += p.DignityScore
+		scores[p.ID] += dignityValue(p.DignityScore)
+
+		// 后天尊贵加成
+		scores[p.ID] += accidentalValue(p)
+
+		// 定位星加成：最终定位星、定位的行星数与互容
+		scores[p.ID] += dispositorBonus(dispositors, p.ID)
 	}
 
 	// 相位加成
@@ -261,7 +214,16 @@ func FindDominantPlanets(planets []models.PlanetPosition, aspects []models.Aspec
 }
 
 // GetChartRuler 获取命主星
-func GetChartRuler(ascendant float64) models.PlanetID {
+// 按命主星候选的状态与定位链选择（见 AnalyzeChartRuler），星盘中没有候选行星时取上升星座的守护星
+func GetChartRuler(ascendant float64, planets []models.PlanetPosition, dispositors *models.DispositorTree) models.PlanetID {
+	return chartRulerOf(AnalyzeChartRuler(ascendant, planets, dispositors), ascendant)
+}
+
+// chartRulerOf 由命主星分析结果取命主星，没有结果时取上升星座的守护星
+func chartRulerOf(info *models.ChartRulerInfo, ascendant float64) models.PlanetID {
+	if info != nil {
+		return info.Planet
+	}
 	zodiac := GetZodiacByLongitude(ascendant)
 	if zodiac != nil {
 		return zodiac.Ruler
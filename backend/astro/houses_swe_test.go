@@ -0,0 +1,92 @@
+//go:build swe
+// +build swe
+
+package astro
+
+import (
+	"math"
+	"star/models"
+	"testing"
+	"time"
+
+	"github.com/mshafiee/swephgo"
+)
+
+// TestPlacidusKochAgainstSwiss 纯 Go 的 Placidus 与 Koch 宫头与 Swiss Ephemeris swe_houses_armc 比对（容差 1 角秒）
+// 以相同的 RAMC 与黄赤交角输入，只比较分宫几何，覆盖接近 ±66° 的极圈边缘；分宫不需要星历文件
+func TestPlacidusKochAgainstSwiss(t *testing.T) {
+	const tolerance = 1.0 / 3600 // 1 角秒
+	eps := 23.4392911
+
+	tests := []struct {
+		name     string
+		ramc     float64
+		latitude float64
+	}{
+		{"赤道", 15, 0},
+		{"北京", 123.4, 39.9042},
+		{"伦敦", 301.7, 51.5074},
+		{"南半球悉尼", 75.2, -33.8688},
+		{"赫尔辛基", 260, 60.1699},
+		{"北纬66", 45, 66},
+		{"南纬66", 225, -66},
+		{"极圈边缘内侧", 0.5, 66.4},
+		{"南极圈边缘内侧", 179.5, -66.4},
+	}
+
+	systems := []struct {
+		name  string
+		code  byte
+		cusps func(ramc, latitude, eps, asc, mc float64) []float64
+	}{
+		{"Placidus", 'P', placidusCusps},
+		{"Koch", 'K', kochCusps},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asc := ascendantForPole(tt.ramc, tt.latitude, eps)
+			mc := NormalizeAngle(math.Atan2(math.Sin(tt.ramc*DEG_TO_RAD), math.Cos(tt.ramc*DEG_TO_RAD)*math.Cos(eps*DEG_TO_RAD)) * RAD_TO_DEG)
+
+			for _, system := range systems {
+				reference := make([]float64, 13)
+				ascmc := make([]float64, 10)
+				sweMu.Lock()
+				ret := swephgo.HousesArmc(tt.ramc, tt.latitude, eps, int(system.code), reference, ascmc)
+				sweMu.Unlock()
+				if ret < 0 {
+					t.Fatalf("%s: swe_houses_armc 失败", system.name)
+				}
+				if AngleDifference(asc, ascmc[0]) > tolerance || AngleDifference(mc, ascmc[1]) > tolerance {
+					t.Errorf("%s: 上升/中天与 Swiss Ephemeris 不一致: %.6f/%.6f vs %.6f/%.6f", system.name, asc, mc, ascmc[0], ascmc[1])
+				}
+
+				cusps := system.cusps(tt.ramc, tt.latitude, eps, asc, mc)
+				for i := range cusps {
+					if diff := AngleDifference(cusps[i], reference[i+1]); diff > tolerance {
+						t.Errorf("%s 第%d宫与 Swiss Ephemeris 相差 %.2f″（%.6f vs %.6f）", system.name, i+1, diff*3600, cusps[i], reference[i+1])
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestSwissHousesFailure 极圈内的 Placidus 宫位由 swe_houses_ex2 报错，统一入口记录失败并回退到纯 Go 分宫
+func TestSwissHousesFailure(t *testing.T) {
+	useProvider(t, &SwissProvider{})
+	jd := DateToJulianDay(time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC))
+
+	if _, _, _, err := (&SwissProvider{}).Houses(jd, 80, 15, models.HousePlacidus); err == nil {
+		t.Fatal("极圈内的 Placidus 宫位应返回错误")
+	}
+
+	before := GetEphemerisFailures()[ProviderSwiss+"/houses"]
+	houses, _, _ := CalculateHousesUnified(jd, 80, 15, models.HousePlacidus)
+	if len(houses) != 12 {
+		t.Fatalf("回退后应返回 12 个宫位，实际 %d", len(houses))
+	}
+	if after := GetEphemerisFailures()[ProviderSwiss+"/houses"]; after != before+1 {
+		t.Errorf("宫位失败应计入 ephemeris.failures: %d -> %d", before, after)
+	}
+}
@@ -0,0 +1,263 @@
+package astro
+
+import (
+	"math"
+	"star/models"
+	"testing"
+	"time"
+)
+
+// TestParseHouseSystem 测试分宫制解析
+func TestParseHouseSystem(t *testing.T) {
+	tests := []struct {
+		input    models.HouseSystem
+		expected models.HouseSystem
+		wantErr  bool
+	}{
+		{"", models.HousePlacidus, false},
+		{"W", models.HouseWholeSign, false},
+		{"w", models.HouseWholeSign, false},
+		{"wholeSign", models.HouseWholeSign, false},
+		{"Koch", models.HouseKoch, false},
+		{"B", models.HouseAlcabitius, false},
+		{"X", "", true},
+		{"topocentric", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.input), func(t *testing.T) {
+			got, err := ParseHouseSystem(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("错误不符合预期: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("期望 %s, 实际 %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestHouseSystemsStructure 测试各分宫制宫头的基本结构
+func TestHouseSystemsStructure(t *testing.T) {
+	jd := DateToJulianDay(time.Date(1990, 6, 15, 6, 30, 0, 0, time.UTC))
+	lat, lon := 39.9042, 116.4074
+
+	for _, info := range HouseSystems {
+		t.Run(info.Name, func(t *testing.T) {
+			houses, asc, mc := CalculateHouses(jd, lat, lon, info.ID)
+			if len(houses) != 12 {
+				t.Fatalf("宫位数量错误: %d", len(houses))
+			}
+
+			// 对宫宫头相差180°
+			for i := 0; i < 6; i++ {
+				diff := math.Abs(AngleDifference(houses[i].Cusp, houses[i+6].Cusp))
+				if math.Abs(diff-180) > 1e-6 {
+					t.Errorf("第%d宫与第%d宫不对冲: %.4f", i+1, i+7, diff)
+				}
+			}
+
+			// 宫头沿黄道依次递增
+			total := 0.0
+			for i := 0; i < 12; i++ {
+				total += NormalizeAngle(houses[(i+1)%12].Cusp - houses[i].Cusp)
+			}
+			if math.Abs(total-360) > 1e-6 {
+				t.Errorf("宫头顺序错误，总跨度 %.4f", total)
+			}
+
+			// 上升点必须落在第1宫
+			if GetPlanetHouse(asc, houses) != 1 {
+				t.Errorf("上升点 %.2f 不在第1宫", asc)
+			}
+
+			if info.Quadrant {
+				if math.Abs(AngleDifference(houses[0].Cusp, asc)) > 1e-6 {
+					t.Errorf("象限制第1宫宫头应为上升点")
+				}
+				if math.Abs(AngleDifference(houses[9].Cusp, mc)) > 1e-6 {
+					t.Errorf("象限制第10宫宫头应为中天")
+				}
+			}
+		})
+	}
+}
+
+// TestWholeSignAndEqualHouses 测试整宫制与等宫制
+func TestWholeSignAndEqualHouses(t *testing.T) {
+	jd := DateToJulianDay(time.Date(2000, 3, 20, 12, 0, 0, 0, time.UTC))
+
+	whole, asc, _ := CalculateHouses(jd, 51.5, -0.12, models.HouseWholeSign)
+	for i, h := range whole {
+		if math.Mod(h.Cusp, 30) > 1e-9 {
+			t.Errorf("整宫制第%d宫宫头不在星座起点: %.4f", i+1, h.Cusp)
+		}
+	}
+	if whole[0].Sign != GetZodiacByLongitude(asc).ID {
+		t.Errorf("整宫制第1宫星座应与上升星座一致")
+	}
+
+	equal, asc, _ := CalculateHouses(jd, 51.5, -0.12, models.HouseEqual)
+	for i, h := range equal {
+		expected := NormalizeAngle(asc + float64(i)*30)
+		if math.Abs(AngleDifference(h.Cusp, expected)) > 1e-9 {
+			t.Errorf("等宫制第%d宫宫头错误: %.4f, 期望 %.4f", i+1, h.Cusp, expected)
+		}
+	}
+}
+
+// TestQuadrantSystemsAtEquator 赤道上各象限制的中间宫头应一致（均为赤经 RAMC+30°/60° 等）
+func TestQuadrantSystemsAtEquator(t *testing.T) {
+	ramc := 123.4
+	asc := ascendantForPole(ramc, 0, OBLIQUITY)
+	mc := NormalizeAngle(math.Atan2(math.Sin(ramc*DEG_TO_RAD), math.Cos(ramc*DEG_TO_RAD)*math.Cos(OBLIQUITY*DEG_TO_RAD)) * RAD_TO_DEG)
+
+	reference := regiomontanusCusps(ramc, 0, OBLIQUITY, asc, mc)
+	for name, cusps := range map[string][]float64{
+		"campanus":   campanusCusps(ramc, 0, OBLIQUITY, asc, mc),
+		"alcabitius": alcabitiusCusps(ramc, 0, OBLIQUITY, asc, mc),
+		"placidus":   placidusCusps(ramc, 0, OBLIQUITY, asc, mc),
+		"koch":       kochCusps(ramc, 0, OBLIQUITY, asc, mc),
+	} {
+		for i := range cusps {
+			if math.Abs(AngleDifference(cusps[i], reference[i])) > 1e-9 {
+				t.Errorf("%s 第%d宫与 Regiomontanus 不一致: %.6f vs %.6f", name, i+1, cusps[i], reference[i])
+			}
+		}
+	}
+}
+
+// eclipticToEquatorial 黄道点（黄纬为0）转换为赤经赤纬（度）
+func eclipticToEquatorial(lon, eps float64) (ra, decl float64) {
+	l := lon * DEG_TO_RAD
+	e := eps * DEG_TO_RAD
+	ra = NormalizeAngle(math.Atan2(math.Sin(l)*math.Cos(e), math.Cos(l)) * RAD_TO_DEG)
+	decl = math.Asin(math.Sin(e)*math.Sin(l)) * RAD_TO_DEG
+	return ra, decl
+}
+
+// obliqueAscension 黄道点在给定极高下的斜升（度）
+func obliqueAscension(lon, pole, eps float64) float64 {
+	ra, decl := eclipticToEquatorial(lon, eps)
+	ad := math.Asin(math.Tan(pole*DEG_TO_RAD)*math.Tan(decl*DEG_TO_RAD)) * RAD_TO_DEG
+	return NormalizeAngle(ra - ad)
+}
+
+// TestPlacidusKochValidation Placidus 与 Koch 宫头验证表（含极圈边界）
+// 用各自的定义条件独立检验宫头：
+// Placidus 宫头的时角恰为其自身昼/夜半弧的 1/3 或 2/3；
+// Koch 宫头在出生地极高下的斜升恰为中天斜升弧的三等分点
+// 与 Swiss Ephemeris 的逐宫比对见 houses_swe_test.go（-tags swe）
+func TestPlacidusKochValidation(t *testing.T) {
+	const tolerance = 1.0 / 3600 // 1 角秒
+	eps := 23.4392911
+
+	tests := []struct {
+		name     string
+		ramc     float64
+		latitude float64
+		polar    bool // 是否位于极圈内（应回退到 Porphyry）
+	}{
+		{"赤道", 15, 0, false},
+		{"北回归线", 200, 23.44, false},
+		{"北京", 123.4, 39.9042, false},
+		{"伦敦", 301.7, 51.5074, false},
+		{"南半球悉尼", 75.2, -33.8688, false},
+		{"赫尔辛基", 260, 60.1699, false},
+		{"极圈边缘内侧", 0.5, 66.4, false},
+		{"南极圈边缘内侧", 179.5, -66.4, false},
+		{"极圈边缘外侧", 90, 66.6, true},
+		{"特罗姆瑟", 330, 69.6492, true},
+		{"南纬75", 45, -75, true},
+		{"接近北极", 210, 89.9, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asc := ascendantForPole(tt.ramc, tt.latitude, eps)
+			mc := NormalizeAngle(math.Atan2(math.Sin(tt.ramc*DEG_TO_RAD), math.Cos(tt.ramc*DEG_TO_RAD)*math.Cos(eps*DEG_TO_RAD)) * RAD_TO_DEG)
+
+			for _, system := range []models.HouseSystem{models.HousePlacidus, models.HouseKoch} {
+				effective := houseSystemForLatitude(system, tt.latitude, eps)
+				if tt.polar {
+					if effective != models.HousePorphyry {
+						t.Errorf("%s 在极圈内应回退到 Porphyry，实际 %s", system, effective)
+					}
+					continue
+				}
+				if effective != system {
+					t.Fatalf("%s 不应回退，实际 %s", system, effective)
+				}
+			}
+			if tt.polar {
+				return
+			}
+
+			tanPhi := math.Tan(tt.latitude * DEG_TO_RAD)
+
+			// Placidus：宫头赤经 = RAMC + 半弧分割
+			placidus := placidusCusps(tt.ramc, tt.latitude, eps, asc, mc)
+			checks := []struct {
+				house  int
+				expect func(ad float64) float64
+			}{
+				{11, func(ad float64) float64 { return tt.ramc + (90+ad)/3 }},
+				{12, func(ad float64) float64 { return tt.ramc + 2*(90+ad)/3 }},
+				{2, func(ad float64) float64 { return tt.ramc + 180 - 2*(90-ad)/3 }},
+				{3, func(ad float64) float64 { return tt.ramc + 180 - (90-ad)/3 }},
+			}
+			for _, c := range checks {
+				ra, decl := eclipticToEquatorial(placidus[c.house-1], eps)
+				ad := math.Asin(tanPhi*math.Tan(decl*DEG_TO_RAD)) * RAD_TO_DEG
+				if diff := AngleDifference(ra, NormalizeAngle(c.expect(ad))); diff > tolerance {
+					t.Errorf("Placidus 第%d宫偏差 %.2f″", c.house, diff*3600)
+				}
+			}
+
+			// Koch：宫头斜升 = 中天斜升 + 三等分弧
+			koch := kochCusps(tt.ramc, tt.latitude, eps, asc, mc)
+			_, mcDecl := eclipticToEquatorial(mc, eps)
+			adMC := math.Asin(tanPhi*math.Tan(mcDecl*DEG_TO_RAD)) * RAD_TO_DEG
+			oaMC := tt.ramc - adMC
+			upper := (90 + adMC) / 3
+			kochTargets := map[int]float64{
+				11: oaMC + upper,
+				12: oaMC + 2*upper,
+				2:  tt.ramc + 90 + upper,
+				3:  tt.ramc + 90 + 2*upper,
+			}
+			for house, target := range kochTargets {
+				oa := obliqueAscension(koch[house-1], tt.latitude, eps)
+				if diff := AngleDifference(oa, NormalizeAngle(target)); diff > tolerance {
+					t.Errorf("Koch 第%d宫偏差 %.2f″", house, diff*3600)
+				}
+			}
+
+			// 两种分宫制的轴点均与上升/中天一致
+			for name, cusps := range map[string][]float64{"placidus": placidus, "koch": koch} {
+				if AngleDifference(cusps[0], asc) > 1e-9 || AngleDifference(cusps[9], mc) > 1e-9 {
+					t.Errorf("%s 轴点宫头错误", name)
+				}
+			}
+		})
+	}
+}
+
+// TestPolarFallbackMatchesPorphyry 极圈内 Placidus/Koch 的宫头应与 Porphyry 完全一致
+func TestPolarFallbackMatchesPorphyry(t *testing.T) {
+	jd := DateToJulianDay(time.Date(2024, 12, 21, 12, 0, 0, 0, time.UTC))
+	lat, lon := 69.6492, 18.9553
+
+	porphyry, _, _ := CalculateHouses(jd, lat, lon, models.HousePorphyry)
+	for _, system := range []models.HouseSystem{models.HousePlacidus, models.HouseKoch} {
+		if got := EffectiveHouseSystem(system, jd, lat); got != models.HousePorphyry {
+			t.Errorf("%s 应回退到 Porphyry，实际 %s", system, got)
+		}
+		houses, _, _ := CalculateHouses(jd, lat, lon, system)
+		for i := range houses {
+			if AngleDifference(houses[i].Cusp, porphyry[i].Cusp) > 1e-9 {
+				t.Errorf("%s 第%d宫与 Porphyry 不一致", system, i+1)
+			}
+		}
+	}
+}
@@ -14,22 +14,26 @@ func CalculateInfluenceFactors(chart *models.NatalChart, date time.Time, transit
 	return CalculateInfluenceFactorsV2(chart, date, transitPositions)
 }
 
-// UpdateFactorWeights 更新因子权重（供运营调整）
-func UpdateFactorWeights(newWeights models.FactorWeights) {
-	DefaultFactorWeights = newWeights
+// UpdateFactorWeights 更新因子权重（供运营调整），发布新的配置快照
+func UpdateFactorWeights(newWeights models.FactorWeights) *ScoringConfig {
+	return updateScoringConfig(func(cfg *ScoringConfig) {
+		cfg.FactorWeights = newWeights
+	})
 }
 
 // GetCurrentFactorWeights 获取当前因子权重配置
 func GetCurrentFactorWeights() models.FactorWeights {
-	return DefaultFactorWeights
+	return CurrentScoringConfig().FactorWeights
 }
 
-// UpdateDimensionWeights 更新维度权重（供运营调整）
-func UpdateDimensionWeights(newWeights models.DimensionWeights) {
-	DefaultDimensionWeights = newWeights
+// UpdateDimensionWeights 更新维度权重（供运营调整），发布新的配置快照
+func UpdateDimensionWeights(newWeights models.DimensionWeights) *ScoringConfig {
+	return updateScoringConfig(func(cfg *ScoringConfig) {
+		cfg.DimensionWeights = newWeights
+	})
 }
 
 // GetCurrentDimensionWeights 获取当前维度权重配置
 func GetCurrentDimensionWeights() models.DimensionWeights {
-	return DefaultDimensionWeights
+	return CurrentScoringConfig().DimensionWeights
 }
@@ -0,0 +1,262 @@
+package astro
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"star/models"
+	"time"
+)
+
+// ==================== 换座（入座） ====================
+
+// Ingress 一次换座的精确时刻
+type Ingress struct {
+	Planet     models.PlanetID `json:"planet"`
+	Time       time.Time       `json:"time"`
+	FromSign   models.ZodiacID `json:"fromSign"`
+	ToSign     models.ZodiacID `json:"toSign"`
+	ToSignName string          `json:"toSignName"`
+	Retrograde bool            `json:"retrograde"` // 以逆行方向换座（退回前一星座）
+	ReEntry    bool            `json:"reEntry"`    // 逆行退出后再次进入该星座
+}
+
+// zodiacOffsetFunc 给定时刻的黄道偏移（回归黄道为 0）
+type zodiacOffsetFunc func(jd float64) float64
+
+// FindIngresses 求 [start, end) 内各天体的换座时刻，按时间排序；planets 为空时使用 DefaultBodies
+func FindIngresses(start, end time.Time, zodiac models.ZodiacMode, ayanamsa models.Ayanamsa, planets ...models.PlanetID) []Ingress {
+	if len(planets) == 0 {
+		planets = DefaultBodies
+	}
+	provider := CurrentEphemerisProvider()
+	from, to := DateToJulianDay(start.UTC()), DateToJulianDay(end.UTC())
+	offset := func(jd float64) float64 { return ZodiacOffset(zodiac, ayanamsa, jd) }
+
+	var ingresses []Ingress
+	for _, planet := range planets {
+		lookback := 0.0
+		if canRetrograde(planet) {
+			lookback = maxRetrogradeDays
+		}
+		ingresses = append(ingresses, findIngresses(provider, planet, from, to, lookback, offset)...)
+	}
+	sort.Slice(ingresses, func(i, j int) bool {
+		return ingresses[i].Time.Before(ingresses[j].Time)
+	})
+	return ingresses
+}
+
+// findIngresses 扫描 [from, to) 内黄经所在星座的变化并二分求出换座时刻
+// lookback 为向前多扫描的天数，用于识别范围开头处逆行后的再次入座
+func findIngresses(provider EphemerisProvider, planet models.PlanetID, from, to, lookback float64, offset zodiacOffsetFunc) []Ingress {
+	var ingresses []Ingress
+
+	step, ok := transitSearchSteps[planet]
+	if !ok {
+		step = 1
+	}
+	longitude := func(x float64) float64 {
+		return NormalizeAngle(planetPositionFrom(provider, planet, x).Longitude - offset(x))
+	}
+
+	// 最近一次逆行退出的星座
+	var retreatedFrom models.ZodiacID
+
+	prevX := from - lookback
+	prevSign := int(longitude(prevX) / 30)
+	for prevX < to {
+		x := math.Min(prevX+step, to)
+		sign := int(longitude(x) / 30)
+		if sign != prevSign {
+			ingress, ok := newIngress(provider, planet, prevX, x, prevSign, sign, longitude)
+			if ok {
+				if ingress.Retrograde {
+					retreatedFrom = ingress.FromSign
+				} else if ingress.ToSign == retreatedFrom {
+					ingress.ReEntry = true
+					retreatedFrom = ""
+				}
+				if !ingress.Time.Before(julianDayToTime(from)) {
+					ingresses = append(ingresses, ingress)
+				}
+			}
+		}
+		prevX, prevSign = x, sign
+	}
+
+	return ingresses
+}
+
+// newIngress 在 [a, b] 内求从星座 fromIdx 进入 toIdx 的时刻
+func newIngress(provider EphemerisProvider, planet models.PlanetID, a, b float64, fromIdx, toIdx int, longitude func(float64) float64) (Ingress, bool) {
+	var boundary float64
+	retrograde := false
+	switch (toIdx - fromIdx + 12) % 12 {
+	case 1:
+		boundary = float64(toIdx) * 30
+	case 11:
+		boundary = float64(fromIdx) * 30
+		retrograde = true
+	default:
+		return Ingress{}, false // 一步内跨越多个星座，步长不足
+	}
+
+	jd := bisect(func(x float64) float64 {
+		return normalizeSigned(longitude(x) - boundary)
+	}, a, b)
+
+	to := ZodiacSigns[toIdx]
+	return Ingress{
+		Planet:     planet,
+		Time:       julianDayToTime(jd),
+		FromSign:   ZodiacSigns[fromIdx].ID,
+		ToSign:     to.ID,
+		ToSignName: to.Name,
+		Retrograde: retrograde,
+	}, true
+}
+
+// ==================== 换座因子 ====================
+
+// ingressWindow 换座因子的影响窗口
+type ingressWindow struct {
+	before, after float64 // 换座前/后的天数
+	level         models.FactorTimeLevel
+}
+
+// slowIngressWindow 社会与外行星换座：月度级，换座后影响约一个月
+var slowIngressWindow = ingressWindow{before: 7, after: 30, level: models.TimeLevelMonthly}
+
+// fastIngressWindow 个人行星进入庙旺落陷星座：日度级
+var fastIngressWindow = ingressWindow{before: 1, after: 3, level: models.TimeLevelDaily}
+
+// ingressTone 天体换座本身的吉凶倾向（与所入星座的尊贵度叠加）
+var ingressTone = map[models.PlanetID]float64{
+	models.Jupiter:   1.5,
+	models.Saturn:    -1.0,
+	models.Uranus:    -0.5,
+	models.Neptune:   -0.5,
+	models.Pluto:     -0.5,
+	models.Chiron:    -0.5,
+	models.NorthNode: 0.5,
+}
+
+// significantIngressWindow 换座是否值得作为因子，返回其影响窗口
+// 木星至冥王星、凯龙与北交点的每次换座都计入；太阳至火星仅在进入庙旺落陷星座时计入；月亮换座过于频繁不计入
+func significantIngressWindow(planet models.PlanetID, sign models.ZodiacID) (ingressWindow, bool) {
+	if _, ok := ingressTone[planet]; ok {
+		return slowIngressWindow, true
+	}
+	switch planet {
+	case models.Sun, models.Mercury, models.Venus, models.Mars:
+		if GetDignity(planet, sign) != models.DignityPeregrine {
+			return fastIngressWindow, true
+		}
+	}
+	return ingressWindow{}, false
+}
+
+// nearestSignificantIngress 距 date 最近的重要换座；先筛选再取最近，较近的普通换座不会遮住同一窗口内的重要换座
+func nearestSignificantIngress(ingresses []Ingress, date time.Time) *Ingress {
+	var nearest *Ingress
+	for i := range ingresses {
+		if _, ok := significantIngressWindow(ingresses[i].Planet, ingresses[i].ToSign); !ok {
+			continue
+		}
+		if nearest == nil || absDuration(ingresses[i].Time.Sub(date)) < absDuration(nearest.Time.Sub(date)) {
+			nearest = &ingresses[i]
+		}
+	}
+	return nearest
+}
+
+// calculateIngressFactorsV2 计算重要换座因子，影响落在所入星座对应本命宫位的维度
+func calculateIngressFactorsV2(chart *models.NatalChart, weight float64, date time.Time) []models.InfluenceFactor {
+	var factors []models.InfluenceFactor
+
+	provider := CurrentEphemerisProvider()
+	jd := DateToJulianDay(date)
+	offset := func(x float64) float64 { return ChartZodiacOffset(chart, x) }
+
+	for _, planet := range DefaultBodies {
+		if planet == models.Moon {
+			continue
+		}
+		window := slowIngressWindow
+		if _, slow := ingressTone[planet]; !slow {
+			window = fastIngressWindow
+		}
+
+		from, to := jd-window.after, jd+window.before
+		nearest := nearestSignificantIngress(findIngresses(provider, planet, from, to, 0, offset), date)
+		if nearest == nil {
+			continue
+		}
+		if !nearest.Retrograde && canRetrograde(planet) {
+			// 逆行退出的换座可能早于窗口，向前多扫描一个逆行期以识别再次入座
+			nearest = nearestSignificantIngress(findIngresses(provider, planet, from, to, maxRetrogradeDays, offset), date)
+		}
+		window, _ = significantIngressWindow(planet, nearest.ToSign)
+
+		dignity := GetDignity(planet, nearest.ToSign)
+		value := GetDignityScore(dignity) + ingressTone[planet]
+		if nearest.Retrograde && canRetrograde(planet) {
+			value *= 0.7 // 逆行退回，主题是回顾而非开启
+		}
+		if value == 0 {
+			continue
+		}
+
+		// 所入星座起点（逆行时为终点）落在本命的宫位
+		cusp := float64(signIndex(nearest.ToSign)) * 30
+		if nearest.Retrograde {
+			cusp += 30
+		}
+		house := GetPlanetHouse(cusp, chart.Houses)
+		impact := EmphasizeDimension(GetPlanetDimensionImpact(planet), GetDimensionForHouseV2(house), 0.2)
+
+		planetInfo := GetPlanetInfo(planet)
+		name := planetInfo.Name + " enters " + nearest.ToSignName
+		if nearest.Retrograde {
+			name = planetInfo.Name + " retrogrades into " + nearest.ToSignName
+		}
+		description := fmt.Sprintf("%s on %s, activating natal house %d", name, nearest.Time.Format("2006-01-02"), house)
+		if dignity != models.DignityPeregrine {
+			description += fmt.Sprintf(" (%s)", dignity)
+		}
+		if nearest.ReEntry {
+			description += ", re-entering after its retrograde"
+		}
+
+		factors = append(factors, models.InfluenceFactor{
+			Type:      models.FactorIngress,
+			Name:      name,
+			TimeLevel: window.level,
+			Lifecycle: CreateLifecycleWithPeak(
+				nearest.Time.Add(-time.Duration(window.before*24)*time.Hour),
+				nearest.Time,
+				nearest.Time.Add(time.Duration(window.after*24)*time.Hour),
+			),
+			Description:     description,
+			BaseValue:       value,
+			Weight:          weight,
+			DimensionImpact: impact,
+			SourcePlanet:    planet,
+			IsPositive:      value > 0,
+			AstroReason:     "A planet changing sign shifts the tone of its expression; slow planets mark new chapters, personal planets entering dignity or debility change their daily strength",
+		})
+	}
+
+	return factors
+}
+
+// signIndex 星座序号（白羊座为 0）
+func signIndex(sign models.ZodiacID) int {
+	for i, z := range ZodiacSigns {
+		if z.ID == sign {
+			return i
+		}
+	}
+	return 0
+}
@@ -0,0 +1,122 @@
+package astro
+
+import (
+	"star/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestFindIngresses 测试换座时刻与逆行再入座
+// 参考：木星 2025-06-09 21:02 UTC 进入巨蟹座；水星 2025-03-03 入白羊座，03-30 逆行退回双鱼座，04-16 再次进入白羊座
+func TestFindIngresses(t *testing.T) {
+	useProvider(t, NewPreciseProvider())
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	jupiter := FindIngresses(start, end, models.ZodiacTropical, "", models.Jupiter)
+	if len(jupiter) != 1 || jupiter[0].ToSign != models.Cancer {
+		t.Fatalf("2025 上半年木星应只换座一次进入巨蟹座: %+v", jupiter)
+	}
+	want := time.Date(2025, 6, 9, 21, 2, 0, 0, time.UTC)
+	if d := jupiter[0].Time.Sub(want); d < -time.Hour || d > time.Hour {
+		t.Errorf("木星入巨蟹时刻 %s 与参考 %s 相差 %s", jupiter[0].Time, want, d)
+	}
+
+	mercury := FindIngresses(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC),
+		models.ZodiacTropical, "", models.Mercury)
+	var sequence []string
+	for _, ing := range mercury {
+		label := string(ing.ToSign)
+		if ing.Retrograde {
+			label += "(R)"
+		}
+		if ing.ReEntry {
+			label += "(re-entry)"
+		}
+		sequence = append(sequence, label)
+		t.Logf("水星 %s → %s %s", ing.FromSign, ing.ToSign, ing.Time.Format(time.RFC3339))
+	}
+	expected := []string{"aries", "pisces(R)", "aries(re-entry)"}
+	if len(sequence) != len(expected) {
+		t.Fatalf("水星换座序列应为 %v，实际 %v", expected, sequence)
+	}
+	for i := range expected {
+		if sequence[i] != expected[i] {
+			t.Errorf("水星换座序列应为 %v，实际 %v", expected, sequence)
+			break
+		}
+	}
+
+	// 恒星黄道下换座时刻不同
+	sidereal := FindIngresses(start, end, models.ZodiacSidereal, models.AyanamsaLahiri, models.Jupiter)
+	for _, ing := range sidereal {
+		if ing.ToSign == models.Cancer {
+			t.Errorf("恒星黄道下木星 2025 上半年不应进入巨蟹座: %+v", ing)
+		}
+	}
+}
+
+// TestIngressFactor 测试木星换座因子在换座时刻达到峰值
+func TestIngressFactor(t *testing.T) {
+	useProvider(t, NewPreciseProvider())
+
+	chart := CalculateNatalChart(models.BirthData{
+		Year: 1990, Month: 6, Day: 15, Hour: 14, Minute: 30,
+		Latitude: 39.9042, Longitude: 116.4074, Timezone: 8,
+	})
+
+	find := func(at time.Time) *models.InfluenceFactor {
+		result := buildFactorResult(calculateIngressFactorsV2(chart, 1, at), at)
+		for i, f := range result.Factors {
+			if f.SourcePlanet == models.Jupiter {
+				return &result.Factors[i]
+			}
+		}
+		return nil
+	}
+
+	peak := find(time.Date(2025, 6, 9, 21, 2, 0, 0, time.UTC))
+	if peak == nil {
+		t.Fatal("木星入巨蟹时应有换座因子")
+	}
+	// 巨蟹座为木星的旺宫：尊贵度 2 + 木星换座倾向 1.5
+	if peak.Type != models.FactorIngress || peak.BaseValue != 3.5 || peak.TimeLevel != models.TimeLevelMonthly {
+		t.Errorf("木星入旺宫因子异常: %+v", peak)
+	}
+	if peak.CurrentStrength < 0.99 {
+		t.Errorf("换座时刻因子强度应接近 1，实际 %.3f", peak.CurrentStrength)
+	}
+
+	later := find(time.Date(2025, 6, 25, 0, 0, 0, 0, time.UTC))
+	if later == nil || later.CurrentStrength >= peak.CurrentStrength {
+		t.Errorf("换座后半个月因子应仍在但减弱: %+v", later)
+	}
+	if find(time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)) != nil {
+		t.Error("换座一个多月后不应再有木星换座因子")
+	}
+
+	// 土星 2025-05-25 入白羊座，09-01 逆行退回双鱼座，2026-02-14 再次进入白羊座：退出早于因子窗口，需向前扫描一个逆行期
+	at := time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC)
+	var saturn *models.InfluenceFactor
+	for _, f := range calculateIngressFactorsV2(chart, 1, at) {
+		if f.SourcePlanet == models.Saturn {
+			f := f
+			saturn = &f
+		}
+	}
+	if saturn == nil || !strings.Contains(saturn.Description, "re-entering after its retrograde") {
+		t.Errorf("土星再次入白羊座应注明逆行后再入座: %+v", saturn)
+	}
+
+	// 较近的普通换座不应遮住同一窗口内的重要换座
+	date := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	ingresses := []Ingress{
+		{Planet: models.Mercury, Time: date.Add(time.Hour), ToSign: models.Aries},
+		{Planet: models.Mercury, Time: date.Add(-24 * time.Hour), ToSign: models.Pisces, Retrograde: true},
+	}
+	if nearest := nearestSignificantIngress(ingresses, date); nearest == nil || nearest.ToSign != models.Pisces {
+		t.Errorf("应取水星退回双鱼座（落陷）的重要换座: %+v", nearest)
+	}
+}
@@ -21,17 +21,18 @@ func CalculateLifeTrend(chart *models.NatalChart, startYear, endYear int, resolu
 	}
 
 	var points []models.LifeTrendPoint
+	cfg := CurrentScoringConfig() // 整条趋势使用同一份配置快照
 
 	// 根据分辨率生成数据点
 	switch resolution {
 	case "yearly":
-		points = generateYearlyTrend(chart, startYear, endYear)
+		points = generateYearlyTrend(cfg, chart, startYear, endYear)
 	case "quarterly":
-		points = generateQuarterlyTrend(chart, startYear, endYear)
+		points = generateQuarterlyTrend(cfg, chart, startYear, endYear)
 	case "monthly":
-		points = generateMonthlyTrend(chart, startYear, endYear)
+		points = generateMonthlyTrend(cfg, chart, startYear, endYear)
 	default:
-		points = generateYearlyTrend(chart, startYear, endYear)
+		points = generateYearlyTrend(cfg, chart, startYear, endYear)
 	}
 
 	// 生成摘要
@@ -50,7 +51,7 @@ func CalculateLifeTrend(chart *models.NatalChart, startYear, endYear int, resolu
 }
 
 // generateYearlyTrend 生成年度趋势
-func generateYearlyTrend(chart *models.NatalChart, startYear, endYear int) []models.LifeTrendPoint {
+func generateYearlyTrend(cfg *ScoringConfig, chart *models.NatalChart, startYear, endYear int) []models.LifeTrendPoint {
 	var points []models.LifeTrendPoint
 	birthYear := chart.BirthData.ToTime().Year()
 
@@ -61,7 +62,7 @@ func generateYearlyTrend(chart *models.NatalChart, startYear, endYear int) []mod
 		}
 
 		date := time.Date(year, 6, 15, 12, 0, 0, 0, time.UTC) // 使用年中点
-		point := calculateLifeTrendPoint(chart, date, year, age)
+		point := calculateLifeTrendPoint(cfg, chart, date, year, age)
 		points = append(points, point)
 	}
 
@@ -69,7 +70,7 @@ func generateYearlyTrend(chart *models.NatalChart, startYear, endYear int) []mod
 }
 
 // generateQuarterlyTrend 生成季度趋势
-func generateQuarterlyTrend(chart *models.NatalChart, startYear, endYear int) []models.LifeTrendPoint {
+func generateQuarterlyTrend(cfg *ScoringConfig, chart *models.NatalChart, startYear, endYear int) []models.LifeTrendPoint {
 	var points []models.LifeTrendPoint
 	birthYear := chart.BirthData.ToTime().Year()
 
@@ -82,7 +83,7 @@ func generateQuarterlyTrend(chart *models.NatalChart, startYear, endYear int) []
 		for quarter := 1; quarter <= 4; quarter++ {
 			month := (quarter-1)*3 + 2 // 每季度中间月
 			date := time.Date(year, time.Month(month), 15, 12, 0, 0, 0, time.UTC)
-			point := calculateLifeTrendPoint(chart, date, year, age)
+			point := calculateLifeTrendPoint(cfg, chart, date, year, age)
 			points = append(points, point)
 		}
 	}
@@ -91,7 +92,7 @@ func generateQuarterlyTrend(chart *models.NatalChart, startYear, endYear int) []
 }
 
 // generateMonthlyTrend 生成月度趋势
-func generateMonthlyTrend(chart *models.NatalChart, startYear, endYear int) []models.LifeTrendPoint {
+func generateMonthlyTrend(cfg *ScoringConfig, chart *models.NatalChart, startYear, endYear int) []models.LifeTrendPoint {
 	var points []models.LifeTrendPoint
 	birthYear := chart.BirthData.ToTime().Year()
 
@@ -103,7 +104,7 @@ func generateMonthlyTrend(chart *models.NatalChart, startYear, endYear int) []mo
 
 		for month := 1; month <= 12; month++ {
 			date := time.Date(year, time.Month(month), 15, 12, 0, 0, 0, time.UTC)
-			point := calculateLifeTrendPoint(chart, date, year, age)
+			point := calculateLifeTrendPoint(cfg, chart, date, year, age)
 			points = append(points, point)
 		}
 	}
@@ -113,15 +114,15 @@ func generateMonthlyTrend(chart *models.NatalChart, startYear, endYear int) []mo
 
 // calculateLifeTrendPoint 计算单个人生趋势点
 // 使用因子系统V2进行完整的分数计算
-func calculateLifeTrendPoint(chart *models.NatalChart, date time.Time, year, age int) models.LifeTrendPoint {
+func calculateLifeTrendPoint(cfg *ScoringConfig, chart *models.NatalChart, date time.Time, year, age int) models.LifeTrendPoint {
 	// 使用新版因子系统计算分数
-	scoreResult := CalculateScoresV2(chart, date)
+	scoreResult := cfg.Scores(chart, date)
 
 	// 获取行运位置（用于其他计算）
-	transitPositions := GetTransitPositions(date)
+	transitPositions := GetTransitPositionsForChart(chart, date)
 
 	// 计算行运相位（用于和谐/挑战分）
-	aspects := CalculateTransitToNatalAspects(transitPositions, chart.Planets)
+	aspects := CalculateTransitToNatalAspects(transitPositions, NatalPoints(chart))
 	transitScore := CalculateTransitScore(aspects)
 
 	// 计算年限法
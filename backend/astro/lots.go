@@ -0,0 +1,398 @@
+package astro
+
+import (
+	"fmt"
+	"star/models"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ==================== 阿拉伯点 ====================
+// 阿拉伯点（希腊点）= 上升点 + A - B。日间盘（太阳在地平线上）按公式计算，
+// 标记为夜间反转的点在夜间盘中交换 A 与 B（如福点：日间 ASC + 月亮 - 太阳，夜间 ASC + 太阳 - 月亮）。
+// 点目录与相位目录一样以版本化快照发布，运营可增加自定义点；公式的操作数可以是
+// 天体、上升点/天顶、宫头（house2）、宫主星（ruler2，古典守护星）或目录中排在前面的点
+
+// 公式中的特殊操作数
+const (
+	LotOperandAscendant = "ascendant"
+	LotOperandMidheaven = "midheaven"
+	lotOperandHouse     = "house" // house1 - house12：宫头
+	lotOperandRuler     = "ruler" // ruler1 - ruler12：宫头星座的古典守护星
+)
+
+// 阿拉伯点的默认容许度系数与显示颜色
+const (
+	lotOrbFactor = 0.5
+	lotColor     = "#daa520"
+)
+
+// LotDefinition 阿拉伯点定义
+type LotDefinition struct {
+	ID             models.PlanetID         `json:"id"`
+	Name           string                  `json:"name"`
+	Symbol         string                  `json:"symbol"`
+	Add            string                  `json:"add"`            // 公式中的 A
+	Subtract       string                  `json:"subtract"`       // 公式中的 B
+	ReverseAtNight bool                    `json:"reverseAtNight"` // 夜间盘交换 A 与 B
+	Weight         float64                 `json:"weight"`         // 相位权重（同 PlanetWeights）
+	Dimensions     *models.DimensionImpact `json:"dimensions,omitempty"`
+}
+
+// DefaultLots 默认阿拉伯点（赫尔墨斯七点及常用家庭、财帛点）
+var DefaultLots = []LotDefinition{
+	{models.LotFortune, "Part of Fortune", "⊗", string(models.Moon), string(models.Sun), true, 4,
+		&models.DimensionImpact{Career: 0.15, Relationship: 0.05, Health: 0.15, Finance: 0.60, Spiritual: 0.05}},
+	{models.LotSpirit, "Part of Spirit", "⊕", string(models.Sun), string(models.Moon), true, 3,
+		&models.DimensionImpact{Career: 0.45, Relationship: 0.05, Health: 0.10, Finance: 0.10, Spiritual: 0.30}},
+	{models.LotEros, "Part of Eros", "⊗", string(models.Venus), string(models.LotSpirit), true, 2,
+		&models.DimensionImpact{Career: 0.05, Relationship: 0.65, Health: 0.05, Finance: 0.05, Spiritual: 0.20}},
+	{models.LotNecessity, "Part of Necessity", "⊗", string(models.LotFortune), string(models.Mercury), true, 2,
+		&models.DimensionImpact{Career: 0.20, Relationship: 0.15, Health: 0.25, Finance: 0.10, Spiritual: 0.30}},
+	{models.LotCourage, "Part of Courage", "⊗", string(models.LotFortune), string(models.Mars), true, 2,
+		&models.DimensionImpact{Career: 0.40, Relationship: 0.05, Health: 0.35, Finance: 0.10, Spiritual: 0.10}},
+	{models.LotVictory, "Part of Victory", "⊗", string(models.Jupiter), string(models.LotSpirit), true, 2,
+		&models.DimensionImpact{Career: 0.50, Relationship: 0.10, Health: 0.05, Finance: 0.25, Spiritual: 0.10}},
+	{models.LotNemesis, "Part of Nemesis", "⊗", string(models.LotFortune), string(models.Saturn), true, 2,
+		&models.DimensionImpact{Career: 0.20, Relationship: 0.15, Health: 0.30, Finance: 0.10, Spiritual: 0.25}},
+	{models.LotMarriage, "Part of Marriage", "⊗", string(models.Venus), string(models.Saturn), false, 2,
+		&models.DimensionImpact{Career: 0.05, Relationship: 0.75, Health: 0.05, Finance: 0.10, Spiritual: 0.05}},
+	{models.LotFather, "Part of Father", "⊗", string(models.Saturn), string(models.Sun), true, 1,
+		&models.DimensionImpact{Career: 0.25, Relationship: 0.45, Health: 0.10, Finance: 0.10, Spiritual: 0.10}},
+	{models.LotMother, "Part of Mother", "⊗", string(models.Moon), string(models.Venus), true, 1,
+		&models.DimensionImpact{Career: 0.05, Relationship: 0.55, Health: 0.20, Finance: 0.05, Spiritual: 0.15}},
+	{models.LotChildren, "Part of Children", "⊗", string(models.Saturn), string(models.Jupiter), true, 1,
+		&models.DimensionImpact{Career: 0.05, Relationship: 0.60, Health: 0.15, Finance: 0.05, Spiritual: 0.15}},
+	{models.LotSiblings, "Part of Siblings", "⊗", string(models.Jupiter), string(models.Saturn), false, 1,
+		&models.DimensionImpact{Career: 0.10, Relationship: 0.60, Health: 0.10, Finance: 0.10, Spiritual: 0.10}},
+	{models.LotSubstance, "Part of Substance", "⊗", lotOperandHouse + "2", lotOperandRuler + "2", false, 2,
+		&models.DimensionImpact{Career: 0.15, Relationship: 0.05, Health: 0.05, Finance: 0.70, Spiritual: 0.05}},
+}
+
+// LotCatalogue 阿拉伯点目录快照（发布后不可修改）
+type LotCatalogue struct {
+	Version int64           `json:"version"`
+	Lots    []LotDefinition `json:"lots"` // 按顺序计算，公式只能引用排在前面的点
+}
+
+var (
+	lotCatalogue   atomic.Pointer[LotCatalogue]
+	lotCatalogueMu sync.Mutex // 串行化写操作
+)
+
+func init() {
+	catalogue := defaultLotCatalogue()
+	catalogue.Version = 1
+	lotCatalogue.Store(catalogue)
+}
+
+// defaultLotCatalogue 由默认定义构造点目录
+func defaultLotCatalogue() *LotCatalogue {
+	return (&LotCatalogue{Lots: DefaultLots}).Clone()
+}
+
+// CurrentLotCatalogue 获取当前阿拉伯点目录快照
+func CurrentLotCatalogue() *LotCatalogue {
+	return lotCatalogue.Load()
+}
+
+// Clone 深拷贝目录，供管理接口在副本上修改
+func (c *LotCatalogue) Clone() *LotCatalogue {
+	clone := *c
+	clone.Lots = make([]LotDefinition, len(c.Lots))
+	for i, lot := range c.Lots {
+		if lot.Dimensions != nil {
+			dims := *lot.Dimensions
+			lot.Dimensions = &dims
+		}
+		clone.Lots[i] = lot
+	}
+	return &clone
+}
+
+// Validate 校验目录配置
+func (c *LotCatalogue) Validate() error {
+	defined := make(map[models.PlanetID]bool, len(c.Lots))
+	for _, lot := range c.Lots {
+		if lot.ID == "" {
+			return fmt.Errorf("阿拉伯点标识不能为空")
+		}
+		if defined[lot.ID] {
+			return fmt.Errorf("阿拉伯点标识重复: %s", lot.ID)
+		}
+		if isBodyID(lot.ID) || isSpecialLotOperand(string(lot.ID)) {
+			return fmt.Errorf("阿拉伯点标识与天体或公式操作数冲突: %s", lot.ID)
+		}
+		if strings.TrimSpace(lot.Name) == "" {
+			return fmt.Errorf("阿拉伯点 %s 的名称不能为空", lot.ID)
+		}
+		for _, operand := range []string{lot.Add, lot.Subtract} {
+			if !isBodyID(models.PlanetID(operand)) && !isSpecialLotOperand(operand) && !defined[models.PlanetID(operand)] {
+				return fmt.Errorf("阿拉伯点 %s 的公式操作数无效: %s（点只能引用排在前面的点）", lot.ID, operand)
+			}
+		}
+		if lot.Add == lot.Subtract {
+			return fmt.Errorf("阿拉伯点 %s 的公式两个操作数相同", lot.ID)
+		}
+		if lot.Weight < 0 || lot.Weight > 10 {
+			return fmt.Errorf("阿拉伯点 %s 的权重必须在 0-10 之间", lot.ID)
+		}
+		if d := lot.Dimensions; d != nil {
+			for _, v := range []float64{d.Career, d.Relationship, d.Health, d.Finance, d.Spiritual} {
+				if v < 0 || v > 1 {
+					return fmt.Errorf("阿拉伯点 %s 的维度分配必须在 0-1 之间", lot.ID)
+				}
+			}
+		}
+		defined[lot.ID] = true
+	}
+	return nil
+}
+
+// UpdateLotCatalogue 校验并发布新的阿拉伯点目录
+func UpdateLotCatalogue(next *LotCatalogue) (*LotCatalogue, error) {
+	if err := next.Validate(); err != nil {
+		return nil, err
+	}
+
+	lotCatalogueMu.Lock()
+	defer lotCatalogueMu.Unlock()
+
+	published := next.Clone()
+	published.Version = lotCatalogue.Load().Version + 1
+	lotCatalogue.Store(published)
+	return published, nil
+}
+
+// ResetLotCatalogue 恢复默认阿拉伯点目录
+func ResetLotCatalogue() *LotCatalogue {
+	catalogue, _ := UpdateLotCatalogue(defaultLotCatalogue())
+	return catalogue
+}
+
+// Lot 按标识查找阿拉伯点定义
+func (c *LotCatalogue) Lot(id models.PlanetID) *LotDefinition {
+	for _, lot := range c.Lots {
+		if lot.ID == id {
+			return &lot
+		}
+	}
+	return nil
+}
+
+// lotDefinition 在当前目录中查找阿拉伯点（包初始化完成前返回 nil）
+func lotDefinition(id models.PlanetID) *LotDefinition {
+	catalogue := lotCatalogue.Load()
+	if catalogue == nil {
+		return nil
+	}
+	return catalogue.Lot(id)
+}
+
+// IsLot 是否为阿拉伯点
+func IsLot(id models.PlanetID) bool {
+	return lotDefinition(id) != nil
+}
+
+// isBodyID 是否为天体标识（含内部使用的平交点）
+func isBodyID(id models.PlanetID) bool {
+	for _, p := range Planets {
+		if p.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// isSpecialLotOperand 是否为上升点/天顶/宫头/宫主星操作数
+func isSpecialLotOperand(operand string) bool {
+	if operand == LotOperandAscendant || operand == LotOperandMidheaven {
+		return true
+	}
+	_, ok := lotOperandHouseNumber(operand)
+	return ok
+}
+
+// lotOperandHouseNumber 解析 houseN / rulerN 中的宫位号
+func lotOperandHouseNumber(operand string) (int, bool) {
+	for _, prefix := range []string{lotOperandHouse, lotOperandRuler} {
+		if rest, ok := strings.CutPrefix(operand, prefix); ok {
+			n, err := strconv.Atoi(rest)
+			return n, err == nil && n >= 1 && n <= 12
+		}
+	}
+	return 0, false
+}
+
+// ==================== 计算 ====================
+
+// ChartSect 日夜区分：太阳位于地平线以上（第 7-12 宫一侧）为日间盘
+func ChartSect(sunLongitude, ascendant float64) models.Sect {
+	if NormalizeAngle(sunLongitude-ascendant) >= 180 {
+		return models.SectDay
+	}
+	return models.SectNight
+}
+
+// planetsSect 按星盘中的太阳判断日夜区分，星盘不含太阳时按日间盘处理
+func planetsSect(planets []models.PlanetPosition, asc float64) models.Sect {
+	if sun := findPosition(planets, models.Sun); sun != nil {
+		return ChartSect(sun.Longitude, asc)
+	}
+	return models.SectDay
+}
+
+// lotContext 计算阿拉伯点所需的星盘数据
+type lotContext struct {
+	planets []models.PlanetPosition
+	lots    []models.LotPosition
+	houses  []models.HouseCusp
+	asc, mc float64
+}
+
+// longitude 操作数的黄经，星盘中缺少对应天体时返回 false
+func (ctx *lotContext) longitude(operand string) (float64, bool) {
+	switch operand {
+	case LotOperandAscendant:
+		return ctx.asc, true
+	case LotOperandMidheaven:
+		return ctx.mc, true
+	}
+	if n, ok := lotOperandHouseNumber(operand); ok {
+		if n > len(ctx.houses) {
+			return 0, false
+		}
+		cusp := ctx.houses[n-1].Cusp
+		if strings.HasPrefix(operand, lotOperandRuler) {
+			return ctx.longitude(string(TraditionalRulers[GetZodiacByLongitude(cusp).ID]))
+		}
+		return cusp, true
+	}
+	id := models.PlanetID(operand)
+	if p := findPosition(ctx.planets, id); p != nil {
+		return p.Longitude, true
+	}
+	for _, lot := range ctx.lots {
+		if lot.ID == id {
+			return lot.Longitude, true
+		}
+	}
+	return 0, false
+}
+
+// CalculateLots 按当前点目录计算星盘的阿拉伯点
+// 需要太阳判断日夜；公式引用的天体不在星盘中时跳过该点
+func CalculateLots(jd float64, planets []models.PlanetPosition, houses []models.HouseCusp, asc, mc float64) ([]models.LotPosition, models.Sect) {
+	sect := planetsSect(planets, asc)
+
+	ctx := &lotContext{planets: planets, houses: houses, asc: asc, mc: mc}
+	for _, def := range CurrentLotCatalogue().Lots {
+		add, sub := def.Add, def.Subtract
+		reversed := def.ReverseAtNight && sect == models.SectNight
+		if reversed {
+			add, sub = sub, add
+		}
+		a, okA := ctx.longitude(add)
+		b, okB := ctx.longitude(sub)
+		if !okA || !okB {
+			continue
+		}
+
+		pos := newMovingPosition(def.ID, jd, asc+a-b, 0, planetMotion{})
+		pos.Name, pos.Symbol = def.Name, def.Symbol
+		if len(houses) == 12 {
+			pos.House = GetPlanetHouse(pos.Longitude, houses)
+		}
+		ctx.lots = append(ctx.lots, models.LotPosition{
+			PlanetPosition: pos,
+			Formula:        "ASC + " + lotOperandName(add) + " - " + lotOperandName(sub),
+			Reversed:       reversed,
+		})
+	}
+	return ctx.lots, sect
+}
+
+// lotOperandName 操作数的显示名称
+func lotOperandName(operand string) string {
+	switch operand {
+	case LotOperandAscendant:
+		return "ASC"
+	case LotOperandMidheaven:
+		return "MC"
+	}
+	if n, ok := lotOperandHouseNumber(operand); ok {
+		if strings.HasPrefix(operand, lotOperandRuler) {
+			return fmt.Sprintf("Ruler of %d", n)
+		}
+		return fmt.Sprintf("Cusp %d", n)
+	}
+	if info := GetPlanetInfo(models.PlanetID(operand)); info != nil {
+		return info.Name
+	}
+	return operand
+}
+
+// LotPositions 阿拉伯点的位置列表
+func LotPositions(lots []models.LotPosition) []models.PlanetPosition {
+	positions := make([]models.PlanetPosition, len(lots))
+	for i, lot := range lots {
+		positions[i] = lot.PlanetPosition
+	}
+	return positions
+}
+
+// NatalPoints 本命盘中可被行运触发的全部点：天体与阿拉伯点
+func NatalPoints(chart *models.NatalChart) []models.PlanetPosition {
+	if len(chart.Lots) == 0 {
+		return chart.Planets
+	}
+	points := make([]models.PlanetPosition, 0, len(chart.Planets)+len(chart.Lots))
+	points = append(points, chart.Planets...)
+	return append(points, LotPositions(chart.Lots)...)
+}
+
+// pointName 点的显示名称：优先取位置自带的名称（本命盘计算后从目录删除的点仍可显示），其次查目录，最后使用标识
+func pointName(points []models.PlanetPosition, id models.PlanetID) string {
+	if p := findPosition(points, id); p != nil && p.Name != "" {
+		return p.Name
+	}
+	if info := GetPlanetInfo(id); info != nil {
+		return info.Name
+	}
+	return string(id)
+}
+
+// CalculateLotAspects 计算阿拉伯点与天体之间的本命相位（点与点之间不计）
+func CalculateLotAspects(lots []models.LotPosition, planets []models.PlanetPosition) []models.AspectData {
+	var aspects []models.AspectData
+
+	catalogue := CurrentAspectCatalogue()
+	defs := catalogue.Definitions(AspectContextNatal)
+	for _, lot := range LotPositions(lots) {
+		for _, planet := range planets {
+			for _, def := range defs {
+				aspect, ok := buildAspect(planet, lot, def, catalogue.Orb(def, planet.ID, lot.ID, AspectContextNatal))
+				if !ok {
+					continue
+				}
+				aspect.Interpretation = generateAspectInterpretation(planet, lot, def)
+				aspects = append(aspects, aspect)
+			}
+		}
+	}
+
+	return aspects
+}
+
+// bodyWeight 天体或阿拉伯点的相位权重
+func bodyWeight(id models.PlanetID) float64 {
+	if w, ok := PlanetWeights[id]; ok {
+		return w
+	}
+	if lot := lotDefinition(id); lot != nil {
+		return lot.Weight
+	}
+	return 0
+}
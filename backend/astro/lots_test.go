@@ -0,0 +1,209 @@
+package astro
+
+import (
+	"math"
+	"star/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+// lotTestChart 构造用于阿拉伯点测试的星盘数据（上升白羊座 10°，等宫制）
+func lotTestChart(sun float64) ([]models.PlanetPosition, []models.HouseCusp) {
+	longitudes := map[models.PlanetID]float64{
+		models.Sun: sun, models.Moon: 100, models.Mercury: 280, models.Venus: 250,
+		models.Mars: 200, models.Jupiter: 60, models.Saturn: 150,
+	}
+	var planets []models.PlanetPosition
+	for id, lon := range longitudes {
+		planets = append(planets, newPlanetPosition(id, lon, 0, false))
+	}
+	var houses []models.HouseCusp
+	for i, cusp := range equalCusps(10) {
+		houses = append(houses, models.HouseCusp{House: i + 1, Cusp: cusp})
+	}
+	return planets, houses
+}
+
+// lotLongitude 查找阿拉伯点黄经
+func lotLongitude(t *testing.T, lots []models.LotPosition, id models.PlanetID) float64 {
+	t.Helper()
+	for _, lot := range lots {
+		if lot.ID == id {
+			return lot.Longitude
+		}
+	}
+	t.Fatalf("缺少阿拉伯点 %s", id)
+	return 0
+}
+
+// TestLotsSectReversal 测试日夜盘公式反转
+func TestLotsSectReversal(t *testing.T) {
+	cases := []struct {
+		name  string
+		sun   float64
+		sect  models.Sect
+		wants map[models.PlanetID]float64
+	}{
+		// 日间盘：太阳 300° 在地平线上
+		{"日间盘", 300, models.SectDay, map[models.PlanetID]float64{
+			models.LotFortune:   170, // ASC + 月亮 - 太阳
+			models.LotSpirit:    210, // ASC + 太阳 - 月亮
+			models.LotEros:      50,  // ASC + 金星 - 精神点
+			models.LotMarriage:  110, // ASC + 金星 - 土星（不反转）
+			models.LotSubstance: 160, // ASC + 二宫头 - 二宫主（金星）
+		}},
+		// 夜间盘：太阳 60° 在地平线下
+		{"夜间盘", 60, models.SectNight, map[models.PlanetID]float64{
+			models.LotFortune:  330, // ASC + 太阳 - 月亮
+			models.LotSpirit:   50,  // ASC + 月亮 - 太阳
+			models.LotMarriage: 110,
+		}},
+	}
+
+	for _, tc := range cases {
+		planets, houses := lotTestChart(tc.sun)
+		lots, sect := CalculateLots(J2000, planets, houses, 10, 280)
+		if sect != tc.sect {
+			t.Errorf("%s: 日夜区分应为 %s，实际 %s", tc.name, tc.sect, sect)
+		}
+		for id, want := range tc.wants {
+			if got := lotLongitude(t, lots, id); math.Abs(got-want) > 1e-9 {
+				t.Errorf("%s: %s 应为 %.1f°，实际 %.4f°", tc.name, id, want, got)
+			}
+		}
+		for _, lot := range lots {
+			if lot.ID == models.LotFortune {
+				t.Logf("%s: %s %s (%s) 第 %d 宫", tc.name, lot.Name, lot.Formula, lot.SignName, lot.House)
+				if lot.Reversed != (tc.sect == models.SectNight) {
+					t.Errorf("%s: 福点的反转标记错误", tc.name)
+				}
+			}
+		}
+	}
+}
+
+// TestLotCatalogueCustomLot 测试自定义点公式与目录校验
+func TestLotCatalogueCustomLot(t *testing.T) {
+	t.Cleanup(func() { ResetLotCatalogue() })
+
+	next := CurrentLotCatalogue().Clone()
+	next.Lots = append(next.Lots, LotDefinition{
+		ID: "travel", Name: "Part of Travel", Symbol: "⊗",
+		Add: "ruler9", Subtract: "house9", Weight: 1,
+	})
+	if _, err := UpdateLotCatalogue(next); err != nil {
+		t.Fatalf("自定义点应通过校验: %v", err)
+	}
+
+	// 九宫头 250°（射手座），宫主木星 60°：10 + 60 - 250 = 180
+	planets, houses := lotTestChart(300)
+	lots, _ := CalculateLots(J2000, planets, houses, 10, 280)
+	if got := lotLongitude(t, lots, "travel"); math.Abs(got-180) > 1e-9 {
+		t.Errorf("旅行点应为 180°，实际 %.4f°", got)
+	}
+	if info := GetPlanetInfo("travel"); info == nil || info.Name != "Part of Travel" {
+		t.Errorf("自定义点应可按标识查找名称: %+v", info)
+	}
+
+	invalid := []LotDefinition{
+		{ID: "loop", Name: "Loop", Add: "later", Subtract: string(models.Sun)},
+		{ID: models.Mars, Name: "Mars", Add: string(models.Sun), Subtract: string(models.Moon)},
+		{ID: "bad", Name: "Bad", Add: "house13", Subtract: string(models.Moon)},
+	}
+	for _, lot := range invalid {
+		next := CurrentLotCatalogue().Clone()
+		next.Lots = append(next.Lots, lot)
+		if _, err := UpdateLotCatalogue(next); err == nil {
+			t.Errorf("非法定义应被拒绝: %+v", lot)
+		}
+	}
+}
+
+// TestLotsInNatalChart 测试阿拉伯点进入本命盘、相位、行运与年限法
+func TestLotsInNatalChart(t *testing.T) {
+	useProvider(t, NewPreciseProvider())
+
+	chart := CalculateNatalChart(models.BirthData{
+		Year: 1990, Month: 6, Day: 15, Hour: 14, Minute: 30,
+		Latitude: 39.9042, Longitude: 116.4074, Timezone: 8,
+	})
+	sun, moon := GetPlanetFromChart(chart, models.Sun), GetPlanetFromChart(chart, models.Moon)
+	if sun.House > 6 || chart.Sect != models.SectNight {
+		t.Fatalf("太阳在第 %d 宫（地平线下）应为夜间盘，实际 %s", sun.House, chart.Sect)
+	}
+
+	// 夜间盘福点：ASC + 太阳 - 月亮
+	fortune := GetPlanetFromChart(chart, models.LotFortune)
+	if fortune == nil {
+		t.Fatal("本命盘应包含福点")
+	}
+	if want := NormalizeAngle(chart.Ascendant + sun.Longitude - moon.Longitude); math.Abs(fortune.Longitude-want) > 1e-9 {
+		t.Errorf("福点应为 %.4f°，实际 %.4f°", want, fortune.Longitude)
+	}
+
+	lotAspects := 0
+	for _, a := range chart.Aspects {
+		if IsLot(a.Planet1) && IsLot(a.Planet2) {
+			t.Errorf("点与点之间不应计算相位: %s-%s", a.Planet1, a.Planet2)
+		}
+		if IsLot(a.Planet2) {
+			lotAspects++
+		}
+	}
+	if lotAspects == 0 {
+		t.Error("阿拉伯点应与天体形成本命相位")
+	}
+
+	found := false
+	for _, w := range FindTransitWindows(chart, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		if w.NatalPlanet == models.LotFortune {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("一年内应有行运天体触发本命福点")
+	}
+
+	profection := CalculateAnnualProfection(chart, 35)
+	for _, lp := range profection.LotProfections {
+		if lp.Lot != models.LotFortune {
+			continue
+		}
+		if want := ZodiacSigns[(signIndex(fortune.Sign)+35)%12].ID; lp.Sign != want {
+			t.Errorf("35 岁福点年限应在 %s，实际 %s", want, lp.Sign)
+		}
+		return
+	}
+	t.Error("年限法应包含福点年限")
+}
+
+// TestLotCatalogueShrinkScoresExistingChart 测试点目录删减后，已存储的本命盘仍可评分且保留点的名称
+func TestLotCatalogueShrinkScoresExistingChart(t *testing.T) {
+	useProvider(t, NewPreciseProvider())
+	t.Cleanup(func() { ResetLotCatalogue() })
+
+	chart := CalculateNatalChart(models.BirthData{
+		Year: 1990, Month: 6, Day: 15, Hour: 14, Minute: 30,
+		Latitude: 39.9042, Longitude: 116.4074, Timezone: 8,
+	})
+	next := CurrentLotCatalogue().Clone()
+	next.Lots = next.Lots[:2]
+	if _, err := UpdateLotCatalogue(next); err != nil {
+		t.Fatalf("删减点目录失败: %v", err)
+	}
+
+	removed := 0
+	for day := 0; day < 30; day++ {
+		date := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC).AddDate(0, 0, day)
+		for _, f := range CalculateInfluenceFactorsV2(chart, date, GetTransitPositionsForChart(chart, date)).Factors {
+			if f.Type == models.FactorAspectPhase && strings.HasSuffix(f.Name, "Part of Marriage") {
+				removed++
+			}
+		}
+	}
+	if removed == 0 {
+		t.Error("已删除的婚姻点仍应以本命盘中的名称参与相位因子")
+	}
+}
@@ -0,0 +1,101 @@
+package astro
+
+import "math"
+
+// ==================== 月球交点与远地点 ====================
+// 真交点与密切远地点由 Moshier 月球理论（moon_moshier.go）的位置与速度矢量求得，平交点与平远地点采用 Meeus 第 47 章的多项式
+
+// MeanLunarNode 月球升交点平黄经（度，平春分点）
+func MeanLunarNode(jdTT float64) float64 {
+	T := (jdTT - J2000) / 36525.0
+	return NormalizeAngle(125.0445479 - 1934.1362891*T + 0.0020754*T*T +
+		T*T*T/467441 - T*T*T*T/60616000)
+}
+
+// trueLunarNode 月球真升交点（密切轨道交点）黄经（度，平春分点）
+// 与 Swiss Ephemeris 的 "true node" 定义相同：由月球地心位置与速度矢量求瞬时轨道面与黄道的交线
+func trueLunarNode(jdTT float64) float64 {
+	const dt = 0.05 // 数值求导步长（天）
+
+	vector := func(jd float64) [3]float64 {
+		lon, lat, dist := moshierMoon(jd)
+		return sphericalToCartesian(lon*DEG_TO_RAD, lat*DEG_TO_RAD, dist)
+	}
+
+	r := vector(jdTT)
+	before, after := vector(jdTT-dt), vector(jdTT+dt)
+	var v [3]float64
+	for i := range v {
+		v[i] = (after[i] - before[i]) / (2 * dt)
+	}
+
+	// 轨道角动量 h = r × v，升交点方向为 ẑ × h
+	hx := r[1]*v[2] - r[2]*v[1]
+	hy := r[2]*v[0] - r[0]*v[2]
+	return NormalizeAngle(math.Atan2(hx, -hy) * RAD_TO_DEG)
+}
+
+// sphericalToCartesian 球坐标（弧度）转直角坐标
+func sphericalToCartesian(lon, lat, r float64) [3]float64 {
+	cosLat := math.Cos(lat)
+	return [3]float64{r * cosLat * math.Cos(lon), r * cosLat * math.Sin(lon), r * math.Sin(lat)}
+}
+
+// cartesianToSpherical 直角坐标转球坐标（弧度）
+func cartesianToSpherical(v [3]float64) (lon, lat, r float64) {
+	r = math.Sqrt(v[0]*v[0] + v[1]*v[1] + v[2]*v[2])
+	lon = math.Atan2(v[1], v[0])
+	if lon < 0 {
+		lon += 2 * math.Pi
+	}
+	lat = math.Asin(v[2] / r)
+	return lon, lat, r
+}
+
+// meanLunarApogee 月球平远地点（平黑月莉莉丝）黄经与黄纬（度，平春分点）
+// 远地点为平近地点的对冲点；黄纬取远地点方向在平均月球轨道面（倾角 5.145°）上的投影
+func meanLunarApogee(jdTT float64) (lon, lat float64) {
+	T := (jdTT - J2000) / 36525.0
+	perigee := 83.3532465 + 4069.0137287*T - 0.0103200*T*T - T*T*T/80053 + T*T*T*T/18999000
+	apogee := NormalizeAngle(perigee + 180)
+
+	// 轨道面内自升交点量起的角距，换算到黄道
+	node := MeanLunarNode(jdTT)
+	u := (apogee - node) * DEG_TO_RAD
+	inc := 5.145396 * DEG_TO_RAD
+	lon = NormalizeAngle(node + math.Atan2(math.Sin(u)*math.Cos(inc), math.Cos(u))*RAD_TO_DEG)
+	lat = math.Asin(math.Sin(u)*math.Sin(inc)) * RAD_TO_DEG
+	return lon, lat
+}
+
+// osculatingLunarApogee 月球密切远地点（真黑月莉莉丝）黄经与黄纬（度，平春分点）
+// 与 Swiss Ephemeris 的 "osculating apogee" 定义相同：由月球地心位置与速度矢量求瞬时开普勒轨道，
+// 远地点方向为偏心率矢量的反方向
+func osculatingLunarApogee(jdTT float64) (lon, lat float64) {
+	const dt = 0.05                                      // 数值求导步长（天）
+	const mu = (398600.4418 + 4902.8001) * 86400 * 86400 // 地月引力常数（km³/天²）
+
+	vector := func(jd float64) [3]float64 {
+		lon, lat, dist := moshierMoon(jd)
+		return sphericalToCartesian(lon*DEG_TO_RAD, lat*DEG_TO_RAD, dist)
+	}
+
+	r := vector(jdTT)
+	before, after := vector(jdTT-dt), vector(jdTT+dt)
+	var v [3]float64
+	for i := range v {
+		v[i] = (after[i] - before[i]) / (2 * dt)
+	}
+
+	// 偏心率矢量 e = (v × h)/μ - r/|r|，其中 h = r × v
+	h := [3]float64{r[1]*v[2] - r[2]*v[1], r[2]*v[0] - r[0]*v[2], r[0]*v[1] - r[1]*v[0]}
+	vh := [3]float64{v[1]*h[2] - v[2]*h[1], v[2]*h[0] - v[0]*h[2], v[0]*h[1] - v[1]*h[0]}
+	rLen := vectorLength(r)
+	var apogee [3]float64
+	for i := range apogee {
+		apogee[i] = -(vh[i]/mu - r[i]/rLen)
+	}
+
+	l, b, _ := cartesianToSpherical(apogee)
+	return l * RAD_TO_DEG, b * RAD_TO_DEG
+}
@@ -0,0 +1,211 @@
+package astro
+
+import (
+	"math"
+	"sort"
+	"star/models"
+	"time"
+)
+
+// ==================== 朔望与日月食日历 ====================
+// 月相由月日距角（月亮黄经 - 太阳黄经）决定，与黄道模式无关；
+// 新月 0°、上弦 90°、满月 180°、下弦 270°
+
+// 主要月相
+const (
+	LunationNew          = "new"
+	LunationFirstQuarter = "firstQuarter"
+	LunationFull         = "full"
+	LunationLastQuarter  = "lastQuarter"
+)
+
+// lunationNames 主要月相名称（按距角 0/90/180/270° 排列）
+var lunationNames = []struct {
+	Phase string
+	Name  string
+}{
+	{LunationNew, "New Moon"},
+	{LunationFirstQuarter, "First Quarter"},
+	{LunationFull, "Full Moon"},
+	{LunationLastQuarter, "Last Quarter"},
+}
+
+// lunationScanStep 扫描月日距角的步长（天），距角日变化约 10-15°
+const lunationScanStep = 0.5
+
+// eclipseLunationTolerance 食甚与朔望时刻匹配的容差（天）
+const eclipseLunationTolerance = 0.5
+
+// Lunation 一次主要月相的精确时刻
+type Lunation struct {
+	Phase      string          `json:"phase"` // new / firstQuarter / full / lastQuarter
+	Name       string          `json:"name"`
+	Time       time.Time       `json:"time"`
+	Longitude  float64         `json:"longitude"` // 月亮黄经
+	Sign       models.ZodiacID `json:"sign"`
+	SignDegree float64         `json:"signDegree"`
+	Eclipse    *EclipseInfo    `json:"eclipse,omitempty"` // 伴随的日食（新月）或月食（满月）
+}
+
+// elongation 月日距角（0-360°）
+func elongation(provider EphemerisProvider, jd float64) float64 {
+	sun := planetPositionFrom(provider, models.Sun, jd)
+	moon := planetPositionFrom(provider, models.Moon, jd)
+	return NormalizeAngle(moon.Longitude - sun.Longitude)
+}
+
+// elongationCrossing 自 jd 起沿 dir 方向（±1）求月日距角经过 target 的时刻
+func elongationCrossing(provider EphemerisProvider, jd, target, dir float64) float64 {
+	offset := func(x float64) float64 {
+		return normalizeSigned(elongation(provider, x) - target)
+	}
+
+	prev, prevOffset := jd, offset(jd)
+	if prevOffset == 0 {
+		return jd
+	}
+	// 一个朔望月内必然经过
+	for d := lunationScanStep; d <= 31; d += lunationScanStep {
+		x := jd + dir*d
+		cur := offset(x)
+		if crossesZero(prevOffset, cur) {
+			return bisect(offset, math.Min(prev, x), math.Max(prev, x))
+		}
+		prev, prevOffset = x, cur
+	}
+	return jd + dir*31
+}
+
+// FindLunations 求 [start, end) 内的新月、上弦、满月与下弦，并标注伴随的日月食
+func FindLunations(start, end time.Time, zodiac models.ZodiacMode, ayanamsa models.Ayanamsa) []Lunation {
+	provider := CurrentEphemerisProvider()
+	from, to := DateToJulianDay(start.UTC()), DateToJulianDay(end.UTC())
+	eclipses := FindEclipses(start.AddDate(0, 0, -1), end.AddDate(0, 0, 1))
+
+	var lunations []Lunation
+	prevX := from
+	prevQuarter := int(elongation(provider, prevX) / 90)
+	for prevX < to {
+		x := math.Min(prevX+lunationScanStep, to)
+		quarter := int(elongation(provider, x) / 90)
+		if quarter != prevQuarter {
+			target := float64(quarter) * 90
+			jd := bisect(func(t float64) float64 {
+				return normalizeSigned(elongation(provider, t) - target)
+			}, prevX, x)
+			if jd >= from && jd < to {
+				lunations = append(lunations, newLunation(provider, quarter, jd, ZodiacOffset(zodiac, ayanamsa, jd), eclipses))
+			}
+		}
+		prevX, prevQuarter = x, quarter
+	}
+
+	return lunations
+}
+
+// newLunation 构造月相记录；新月/满月与食甚相差不超过半天时附上食相
+func newLunation(provider EphemerisProvider, quarter int, jd, zodiacOffset float64, eclipses []EclipseInfo) Lunation {
+	moon := planetPositionFrom(provider, models.Moon, jd)
+	setPositionLongitude(&moon, moon.Longitude-zodiacOffset)
+
+	lunation := Lunation{
+		Phase:      lunationNames[quarter].Phase,
+		Name:       lunationNames[quarter].Name,
+		Time:       julianDayToTime(jd),
+		Longitude:  moon.Longitude,
+		Sign:       moon.Sign,
+		SignDegree: moon.SignDegree,
+	}
+
+	kind := EclipseKind("")
+	switch lunation.Phase {
+	case LunationNew:
+		kind = SolarEclipse
+	case LunationFull:
+		kind = LunarEclipse
+	}
+	for i, eclipse := range eclipses {
+		if eclipse.Kind == kind && math.Abs(eclipse.MaximumJD-jd) <= eclipseLunationTolerance {
+			lunation.Eclipse = &eclipses[i]
+			break
+		}
+	}
+	return lunation
+}
+
+// FindEclipses 求 [start, end) 内的全部日食与月食，按食甚时间排序
+func FindEclipses(start, end time.Time) []EclipseInfo {
+	provider := CurrentEphemerisProvider()
+	from, to := DateToJulianDay(start.UTC()), DateToJulianDay(end.UTC())
+
+	var eclipses []EclipseInfo
+	for _, kind := range []EclipseKind{SolarEclipse, LunarEclipse} {
+		jd := from
+		for jd < to {
+			eclipse, err := provider.NextEclipse(jd, kind, false)
+			if err != nil || eclipse.MaximumJD >= to {
+				break
+			}
+			eclipses = append(eclipses, eclipse)
+			// 同类食相至少相隔约一个朔望月
+			jd = eclipse.MaximumJD + 1
+		}
+	}
+	sort.Slice(eclipses, func(i, j int) bool {
+		return eclipses[i].MaximumJD < eclipses[j].MaximumJD
+	})
+	return eclipses
+}
+
+// ==================== 评分用月相区段 ====================
+
+// LunarPhaseSpan 一段八分月相（每 45° 一段）的精确起止时刻
+type LunarPhaseSpan struct {
+	Phase models.LunarPhaseInfo
+	Start time.Time // 距角到达本段下界
+	End   time.Time // 距角到达本段上界
+}
+
+// Principal 是否为主要月相（新月、上弦、满月、下弦），其起点即精确的朔望/弦时刻
+func (s LunarPhaseSpan) Principal() bool {
+	switch s.Phase.Phase {
+	case LunationNew, LunationFirstQuarter, LunationFull, LunationLastQuarter:
+		return true
+	}
+	return false
+}
+
+// Lifecycle 月相因子生命周期：主要月相峰值在精确时刻（区段起点），过渡月相峰值在区段中点
+func (s LunarPhaseSpan) Lifecycle() *models.FactorLifecycle {
+	peak := s.Start.Add(s.End.Sub(s.Start) / 2)
+	if s.Principal() {
+		peak = s.Start
+	}
+	return CreateLifecycleWithPeak(s.Start, peak, s.End)
+}
+
+// lunarPhaseSpans 按星历源缓存的月相区段
+var lunarPhaseSpans = newSpanCache[string, LunarPhaseSpan](32)
+
+// LunarPhaseSpanAt 求 jd 时刻所处月相区段
+func LunarPhaseSpanAt(jd float64) LunarPhaseSpan {
+	provider := CurrentEphemerisProvider()
+	t := julianDayToTime(jd)
+
+	if span, ok := lunarPhaseSpans.get(provider.Name(), func(s LunarPhaseSpan) bool {
+		return !t.Before(s.Start) && t.Before(s.End)
+	}); ok {
+		return span
+	}
+
+	angle := elongation(provider, jd)
+	lower := math.Floor(angle/45) * 45
+	span := LunarPhaseSpan{
+		Phase: GetLunarPhase(angle),
+		Start: julianDayToTime(elongationCrossing(provider, jd, lower, -1)),
+		End:   julianDayToTime(elongationCrossing(provider, jd, math.Mod(lower+45, 360), 1)),
+	}
+
+	lunarPhaseSpans.put(provider.Name(), span)
+	return span
+}
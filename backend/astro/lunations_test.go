@@ -0,0 +1,96 @@
+package astro
+
+import (
+	"star/models"
+	"testing"
+	"time"
+)
+
+// TestFindLunations 测试朔望时刻与伴随的日月食
+// 参考：2024-04-08 18:21 UTC 新月（日全食，食甚 18:17）；2025-03-14 06:55 UTC 满月（月全食）
+func TestFindLunations(t *testing.T) {
+	useProvider(t, NewPreciseProvider())
+
+	lunations := FindLunations(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		models.ZodiacTropical, "")
+	var phases []string
+	var newMoon *Lunation
+	for i, l := range lunations {
+		phases = append(phases, l.Phase)
+		t.Logf("%s %s %s %.2f", l.Name, l.Time.Format(time.RFC3339), l.Sign, l.SignDegree)
+		if l.Phase == LunationNew {
+			newMoon = &lunations[i]
+		}
+	}
+	expected := []string{LunationLastQuarter, LunationNew, LunationFirstQuarter, LunationFull}
+	if len(phases) != len(expected) {
+		t.Fatalf("2024 年 4 月月相序列应为 %v，实际 %v", expected, phases)
+	}
+	for i := range expected {
+		if phases[i] != expected[i] {
+			t.Fatalf("2024 年 4 月月相序列应为 %v，实际 %v", expected, phases)
+		}
+	}
+
+	want := time.Date(2024, 4, 8, 18, 21, 0, 0, time.UTC)
+	if d := newMoon.Time.Sub(want); d < -10*time.Minute || d > 10*time.Minute {
+		t.Errorf("新月时刻 %s 与参考 %s 相差 %s", newMoon.Time, want, d)
+	}
+	if newMoon.Sign != models.Aries {
+		t.Errorf("新月应在白羊座，实际 %s", newMoon.Sign)
+	}
+	if newMoon.Eclipse == nil || newMoon.Eclipse.Kind != SolarEclipse || newMoon.Eclipse.Type != EclipseTotal {
+		t.Fatalf("该新月应伴随日全食: %+v", newMoon.Eclipse)
+	}
+
+	eclipses := FindEclipses(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	// 2025 年：3-14 月全食、3-29 日偏食、9-7 月全食、9-21 日偏食
+	if len(eclipses) != 4 {
+		t.Fatalf("2025 年应有 4 次食，实际 %d: %+v", len(eclipses), eclipses)
+	}
+	if eclipses[0].Kind != LunarEclipse || eclipses[0].Type != EclipseTotal {
+		t.Errorf("2025 年第一次食应为月全食: %+v", eclipses[0])
+	}
+	want = time.Date(2025, 3, 14, 6, 58, 0, 0, time.UTC)
+	if d := eclipses[0].Maximum.Sub(want); d < -30*time.Minute || d > 30*time.Minute {
+		t.Errorf("月全食食甚 %s 与参考 %s 相差 %s", eclipses[0].Maximum, want, d)
+	}
+	for i := 1; i < len(eclipses); i++ {
+		if eclipses[i].MaximumJD < eclipses[i-1].MaximumJD {
+			t.Error("食相应按时间排序")
+		}
+	}
+}
+
+// TestLunarPhaseFactorLifecycle 测试月相因子生命周期取自真实的朔望边界
+func TestLunarPhaseFactorLifecycle(t *testing.T) {
+	useProvider(t, NewPreciseProvider())
+
+	// 2025-03-14 06:55 UTC 满月后一天
+	at := time.Date(2025, 3, 15, 6, 0, 0, 0, time.UTC)
+	factors := calculateLunarPhaseFactorsV2(nil, 1, at)
+	if len(factors) != 1 || factors[0].Lifecycle == nil {
+		t.Fatalf("应有一个带生命周期的月相因子: %+v", factors)
+	}
+	lifecycle := factors[0].Lifecycle
+	fullMoon := time.Date(2025, 3, 14, 6, 55, 0, 0, time.UTC)
+	if d := lifecycle.StartTime.Sub(fullMoon); d < -10*time.Minute || d > 10*time.Minute {
+		t.Errorf("满月区段应始于精确满月时刻，实际 %s", lifecycle.StartTime)
+	}
+	if !lifecycle.PeakTime.Equal(lifecycle.StartTime) {
+		t.Errorf("主要月相的峰值应在精确时刻: %+v", lifecycle)
+	}
+	// 距角 180° → 225° 约 3.5-4 天
+	if span := lifecycle.EndTime.Sub(lifecycle.StartTime); span < 3*24*time.Hour || span > 5*24*time.Hour {
+		t.Errorf("满月区段长度异常: %s", span)
+	}
+
+	// 过渡月相峰值在区段中点
+	crescent := LunarPhaseSpanAt(DateToJulianDay(time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC)))
+	if crescent.Phase.Phase != "crescent" || crescent.Principal() {
+		t.Fatalf("3 月 4 日应为蛾眉月: %+v", crescent)
+	}
+	if peak := crescent.Lifecycle().PeakTime; !peak.After(crescent.Start) || !peak.Before(crescent.End) {
+		t.Errorf("过渡月相峰值应在区段中点: %+v", crescent)
+	}
+}
@@ -0,0 +1,284 @@
+package astro
+
+import "math"
+
+// ==================== Moshier 月球理论 ====================
+// 月球地心坐标采用 S. L. Moshier 的月球理论（与 Swiss Ephemeris 内置 Moshier 模式的 swemmoon.c 相同）：
+// 以 ELP-2000/85 的平根数与周期项为基础，平根数与行星摄动项按 JPL DE404 重新拟合。
+// 系数表见 moon_moshier_tables.go，与 moshier_tables.go 同样沿用 Swiss Ephemeris 的双重许可
+
+// moshierMoonState 一次月球计算的中间量（角度单位为角秒）
+type moshierMoonState struct {
+	T                  float64       // 自 J2000 起的儒略世纪数
+	D, M, MP, NF, LP   float64       // 月日距角、太阳平近点角、月球平近点角、升交角距、月球平黄经
+	Ve, Ea, Ma, Ju, Sa float64       // 金星、地球、火星、木星、土星平黄经
+	ss, cc             [4][8]float64 // D、M、M′、F 倍角的正弦与余弦
+	pol                [3]float64    // 周期项累加：黄经、黄纬、距离
+}
+
+// moshierMoon 月球几何地心黄经、黄纬（度，瞬时平黄道与平春分点）与地心距离（千米）
+// jdTT 为地球时儒略日；未含光行时与章动
+func moshierMoon(jdTT float64) (lon, lat, dist float64) {
+	var m moshierMoonState
+	m.meanElements(jdTT)
+	T := m.T
+	z := &moshierMoonZ
+
+	sincos := func(arg float64) (float64, float64) {
+		return math.Sincos(arg * arcsecToRad)
+	}
+
+	// T² 项：l、l1、l2、l3 为黄经的常数、T、T²、T³ 项系数（l 为角秒，其余为 1e-5″）
+	m.chew(moshierMoonLRT2, 2)
+	m.chew(moshierMoonBT2, 4)
+
+	f := 18*m.Ve - 16*m.Ea
+	s, c := sincos(f - m.MP)
+	l := 6.367278*c + 12.747036*s
+	l1 := 23123.70*c - 10570.02*s
+	l2 := z[12]*c + z[13]*s
+	m.pol[2] += 5.01*c + 2.72*s
+
+	s, c = sincos(10*m.Ve - 3*m.Ea - m.MP)
+	l += -0.253102*c + 0.503359*s
+	l1 += 1258.46*c + 707.29*s
+	l2 += z[14]*c + z[15]*s
+
+	s, c = sincos(8*m.Ve - 13*m.Ea)
+	l += -0.187231*c - 0.127481*s
+	l1 += -319.87*c - 18.34*s
+	l2 += z[16]*c + z[17]*s
+
+	a := 4*m.Ea - 8*m.Ma + 3*m.Ju
+	s, c = sincos(a)
+	l += -0.866287*c + 0.248192*s
+	l1 += 41.87*c + 1053.97*s
+	l2 += z[18]*c + z[19]*s
+
+	s, c = sincos(a - m.MP)
+	l += -0.165009*c + 0.044176*s
+	l1 += 4.67*c + 201.55*s
+
+	s, c = sincos(f)
+	l += 0.330401*c + 0.661362*s
+	l1 += 1202.67*c - 555.59*s
+	l2 += z[20]*c + z[21]*s
+
+	s, c = sincos(f - 2*m.MP)
+	l += 0.352185*c + 0.705041*s
+	l1 += 1283.59*c - 586.43*s
+
+	s, c = sincos(2*m.Ju - 5*m.Sa)
+	l += -0.0347*c + 0.160041*s
+	l2 += z[22]*c + z[23]*s
+
+	s, c = sincos(m.LP - m.NF)
+	l += 0.000116*c + 7.06304*s
+	l1 += 298.8 * s
+
+	s, _ = sincos(m.M)
+	l3 := z[24] * s
+
+	for _, t := range []struct{ amp, arg float64 }{
+		{-0.2655, 2*m.D - m.M},
+		{-0.1568, m.M - m.MP},
+		{0.1309, m.M + m.MP},
+		{0.5568, 2*(m.D+m.M) - m.MP},
+		{-0.1910, 2*m.D - m.M - m.MP},
+	} {
+		_, c = sincos(t.arg)
+		m.pol[2] += t.amp * c * T
+	}
+
+	l2 += m.pol[0]
+	m.pol[0] = 0
+	m.pol[1] *= T
+	m.pol[2] *= T
+
+	// T 项（1e-5″ 与 1e-5 千米）
+	m.chew(moshierMoonBT, 4)
+	m.chew(moshierMoonLRT, 1)
+
+	for _, t := range []struct{ amp, arg float64 }{
+		{-1127, f - m.MP - m.NF - 2355767.6},
+		{-1123, f - m.MP + m.NF - 235353.6},
+		{1303, m.Ea + m.D + 51987.6},
+		{342, m.LP},
+	} {
+		s, _ = sincos(t.arg)
+		m.pol[1] += t.amp * s
+	}
+
+	for _, t := range []struct{ arg, lc, ls, l1c, l1s float64 }{
+		{2*m.Ve - 3*m.Ea, -0.34355, -0.000276, 105.90, 336.53},
+		{f - 2*m.D, 0.074668, 0.149501, 271.77, -124.20},
+		{f - 2*m.D - m.MP, 0.073444, 0.147094, 265.24, -121.16},
+		{f + 2*m.D - m.MP, 0.072844, 0.145829, 265.18, -121.29},
+		{f + 2*(m.D-m.MP), 0.070201, 0.140542, 255.36, -116.79},
+		{m.Ea + m.D - m.NF, 0.288209, -0.025901, -63.51, -240.14},
+		{2*m.Ea - 3*m.Ju + 2*m.D - m.MP, 0.077865, 0.438460, 210.57, 124.84},
+		{m.Ea - 2*m.Ma, -0.216579, 0.241702, 197.67, 125.23},
+		{a + m.MP, -0.165009, 0.044176, 4.67, 201.55},
+		{a + 2*m.D - m.MP, -0.133533, 0.041116, 6.95, 187.07},
+		{a - 2*m.D + m.MP, -0.133430, 0.041079, 6.28, 169.08},
+		{3*m.Ve - 4*m.Ea, -0.175074, 0.003035, 49.17, 150.57},
+	} {
+		s, c = sincos(t.arg)
+		l += t.lc*c + t.ls*s
+		l1 += t.l1c*c + t.l1s*s
+	}
+
+	s, _ = sincos(2*(m.Ea+m.D-m.MP) - 3*m.Ju + 213534)
+	l1 += 158.4 * s
+	l1 += m.pol[0]
+	m.pol[0] = 0
+	m.pol[1] *= 0.1 * T
+	m.pol[2] *= 0.1 * T
+
+	// 常数项中的行星摄动（角秒）
+	for _, t := range []struct{ amp, arg float64 }{
+		{1.14307, 2*(m.Ea-m.Ju+m.D) - m.MP + 648431.172},
+		{0.82155, m.Ve - m.Ea + 648035.568},
+		{0.64371, 2*m.D + 3*(m.Ve-m.Ea) - m.MP + 647933.184},
+		{0.63880, m.Ea - m.Ju + 4424.04},
+		{0.49331, m.LP + m.MP - m.NF + 4.68},
+		{0.4914, m.LP - m.MP - m.NF + 4.68},
+		{0.36061, m.LP + m.NF + 2.52},
+		{0.30154, 2*m.Ve - 2*m.Ea + 736.2},
+		{0.28282, 2*m.Ea - 3*m.Ju + 2*m.D - 2*m.MP + 36138.2},
+		{0.24516, 2*m.Ea - 2*m.Ju + 2*m.D - 2*m.MP + 311.0},
+		{0.21117, m.Ea - m.Ju - 2*m.D + m.MP + 6275.88},
+		{0.19444, 2*(m.Ea-m.Ma) - 846.36},
+		{-0.18457, 2*(m.Ea-m.Ju) + 1569.96},
+		{0.18256, 2*(m.Ea-m.Ju) - m.MP - 55.8},
+		{0.16499, m.Ea - m.Ju - 2*m.D + 6490.08},
+		{0.16427, m.Ea - 2*m.Ju - 212378.4},
+		{0.16088, m.MP + 2*(m.Ve-m.Ea-m.D) + 1122.48},
+		{-0.15350, m.Ve - m.Ea - m.MP + 32.04},
+		{0.14346, m.Ea - m.Ju - m.MP + 4488.88},
+		{0.13594, 2*(m.Ve-m.Ea+m.D) - m.MP - 8.64},
+		{0.13432, 2*(m.Ve-m.Ea-m.D) + 1319.76},
+		{-0.13122, m.Ve - m.Ea - 2*m.D + m.MP - 56.16},
+		{-0.12722, m.Ve - m.Ea + m.MP + 54.36},
+		{0.12539, 3*(m.Ve-m.Ea) - m.MP + 433.8},
+		{0.10994, m.Ea - m.Ju + m.MP + 4002.12},
+		{0.10652, 20*m.Ve - 21*m.Ea - 2*m.D + m.MP - 317511.72},
+		{0.10490, 26*m.Ve - 29*m.Ea - m.MP + 270002.52},
+		{0.10386, 3*m.Ve - 4*m.Ea + m.D - m.MP - 322765.56},
+	} {
+		s, _ = sincos(t.arg)
+		l += t.amp * s
+	}
+
+	var b float64
+	for _, t := range []struct{ amp, arg float64 }{
+		{8.04508, m.LP + 648002.556},
+		{1.51021, m.Ea + m.D + 996048.252},
+		{0.63037, f - m.MP + m.NF + 95554.332},
+		{0.63014, f - m.MP - m.NF + 95553.792},
+		{0.45587, m.LP - m.MP + 2.9},
+		{-0.41573, m.LP + m.MP + 2.5},
+		{0.32623, m.LP - 2*m.NF + 3.2},
+		{0.29855, m.LP - 2*m.D + 2.5},
+	} {
+		s, _ = sincos(t.arg)
+		b += t.amp * s
+	}
+
+	// 主要周期项（1e-4″ 与 1e-4 千米）
+	m.chew(moshierMoonLR, 1)
+	m.chew(moshierMoonMB, 3)
+
+	l += (l1 + (l2+l3*T)*T) * T * 1e-5
+	lon = mod3600(m.LP+l+1e-4*m.pol[0]) / 3600
+	lat = (b + 1e-4*m.pol[1]) / 3600
+	dist = 385000.52899 + 1e-4*m.pol[2]
+	return lon, lat, dist
+}
+
+// meanElements 计算月球与行星的平根数（角秒），并预先求出 D、M、M′、F 的倍角正弦与余弦
+func (m *moshierMoonState) meanElements(jdTT float64) {
+	T := (jdTT - J2000) / 36525.0
+	T2 := T * T
+	fracT := math.Mod(T, 1)
+	z := &moshierMoonZ
+	m.T = T
+
+	m.M = mod3600(129600000*fracT - 3418.961646*T + 1287104.76154)
+	m.M += (-0.552891801772 + (0.00014732069041+(-1.1297037031e-05+(-4.77258489e-08+(8.8555011e-11+
+		(4.237343e-13+(-3.83508e-15+(-1.039e-17+1.62e-20*T)*T)*T)*T)*T)*T)*T)*T) * T2
+	m.NF = mod3600(295263.0983*T + 1739232000*fracT - 0.207941990176*T + 335779.55755)
+	m.MP = mod3600(715923.4728*T + 1717200000*fracT - 0.2035946368532*T + 485868.28096)
+	m.D = mod3600(1105601.4603*T + 1601856000*fracT + 0.3962893294503*T + 1072260.73512)
+	m.LP = mod3600(1108372.83264*T + 1731456000*fracT - 0.6784914260953*T + 785939.95571)
+	m.NF += (z[0] + (z[1]+z[2]*T)*T) * T2
+	m.MP += (z[3] + (z[4]+z[5]*T)*T) * T2
+	m.D += (z[6] + (z[7]+z[8]*T)*T) * T2
+	m.LP += (z[9] + (z[10]+z[11]*T)*T) * T2
+
+	m.Ve = mod3600(655127.283046 + 210664136.4335482*T)
+	m.Ve += (0.005871373088 + (-1.4244812531e-05+(-2.26602516e-09+(-3.0622898e-10+(2.509418e-13+
+		(4.43201e-15+(6.097e-18+(-1.95e-20-9.36e-23*T)*T)*T)*T)*T)*T)*T)*T) * T2
+	m.Ea = mod3600(361679.214649 + 129597742.26669231*T)
+	m.Ea += (-0.020199859001 + (8.863982531e-06+(1.515912254e-07+(1.7228268e-10+(-1.226182e-12+
+		(-1.08402e-14+(2.846e-17+(2.976e-19-1.16e-22*T)*T)*T)*T)*T)*T)*T)*T) * T2
+	m.Ma = mod3600(1279559.78866+68905077.59284*T) + (0.00938012-1.043e-05*T)*T2
+	m.Ju = mod3600(123665.34212+10925660.428608*T) + (-0.306037836351+1.543273e-05*T)*T2
+	m.Sa = mod3600(180278.89694+4399609.65932*T) + (0.756161437443+(-6.874806e-05+4.475946e-08*T)*T)*T2
+
+	for i, arg := range [4]float64{m.D, m.M, m.MP, m.NF} {
+		n := 4
+		if i == 0 {
+			n = 6
+		}
+		s, c := math.Sincos(arg * arcsecToRad)
+		m.ss[i][0], m.cc[i][0] = s, c
+		for k := 1; k < n; k++ {
+			m.ss[i][k] = s*m.cc[i][k-1] + c*m.ss[i][k-1]
+			m.cc[i][k] = c*m.cc[i][k-1] - s*m.ss[i][k-1]
+		}
+	}
+}
+
+// chew 累加一张周期项表，每行前四列为 D、M、M′、F 的倍数
+// kind 1：黄经与距离，各分高低两段；2：黄经与距离；3：黄纬，分高低两段；4：黄纬
+func (m *moshierMoonState) chew(table []int16, kind int) {
+	width := map[int]int{1: 8, 2: 6, 3: 6, 4: 5}[kind]
+	for p := table; len(p) >= width; p = p[width:] {
+		first := true
+		var sv, cv float64
+		for i := 0; i < 4; i++ {
+			j := int(p[i])
+			if j == 0 {
+				continue
+			}
+			k := j
+			if k < 0 {
+				k = -k
+			}
+			su, cu := m.ss[i][k-1], m.cc[i][k-1]
+			if j < 0 {
+				su = -su
+			}
+			if first {
+				sv, cv = su, cu
+				first = false
+			} else {
+				sv, cv = su*cv+cu*sv, cu*cv-su*sv
+			}
+		}
+
+		switch kind {
+		case 1:
+			m.pol[0] += (10000*float64(p[4]) + float64(p[5])) * sv
+			m.pol[2] += (10000*float64(p[6]) + float64(p[7])) * cv
+		case 2:
+			m.pol[0] += float64(p[4]) * sv
+			m.pol[2] += float64(p[5]) * cv
+		case 3:
+			m.pol[1] += (10000*float64(p[4]) + float64(p[5])) * sv
+		case 4:
+			m.pol[1] += float64(p[4]) * sv
+		}
+	}
+}
@@ -0,0 +1,396 @@
+// Moshier 月球理论系数表，取自 Swiss Ephemeris 的 swemmoon.c
+
+// Copyright (C) 1997 - 2021 Astrodienst AG, Switzerland.  All rights reserved.
+//
+//  License conditions
+//  ------------------
+//
+//  This file is part of Swiss Ephemeris.
+//
+//  Swiss Ephemeris is distributed with NO WARRANTY OF ANY KIND.  No author
+//  or distributor accepts any responsibility for the consequences of using it,
+//  or for whether it serves any particular purpose or works at all, unless he
+//  or she says so in writing.
+//
+//  Swiss Ephemeris is made available by its authors under a dual licensing
+//  system. The software developer, who uses any part of Swiss Ephemeris
+//  in his or her software, must choose between one of the two license models,
+//  which are
+//  a) GNU Affero General Public License (AGPL)
+//  b) Swiss Ephemeris Professional License
+//
+//  The choice must be made before the software developer distributes software
+//  containing parts of Swiss Ephemeris to others, and before any public
+//  service using the developed software is activated.
+//
+//  If the developer choses the AGPL software license, he or she must fulfill
+//  the conditions of that license, which includes the obligation to place his
+//  or her whole software project under the AGPL or a compatible license.
+//  See https://www.gnu.org/licenses/agpl-3.0.html
+//
+//  If the developer choses the Swiss Ephemeris Professional license,
+//  he must follow the instructions as found in http://www.astro.com/swisseph/
+//  and purchase the Swiss Ephemeris Professional Edition from Astrodienst
+//  and sign the corresponding license contract.
+//
+//  The License grants you the right to use, copy, modify and redistribute
+//  Swiss Ephemeris, but only under certain conditions described in the License.
+//  Among other things, the License requires that the copyright notices and
+//  this notice be preserved on all copies.
+//
+//  Authors of the Swiss Ephemeris: Dieter Koch and Alois Treindl
+//
+//  The authors of Swiss Ephemeris have no control or influence over any of
+//  the derived works, i.e. over software or services created by other
+//  programmers which use Swiss Ephemeris functions.
+//
+//  The names of the authors or of the copyright holder (Astrodienst) must not
+//  be used for promoting any software, product or service which uses or contains
+//  the Swiss Ephemeris. This copyright notice is the ONLY place where the
+//  names of the authors can legally appear, except in cases where they have
+//  given special permission in writing.
+//
+//  The trademarks 'Swiss Ephemeris' and 'Swiss Ephemeris inside' may be used
+//  for promoting such software, products or services.
+//
+
+package astro
+
+// moshierMoonZ 平根数的高次改正项与行星摄动的 T² 振幅
+var moshierMoonZ = [25]float64{
+	-13.12045233711,
+	-0.00113821591258,
+	-9.646018347184e-06,
+	31.46734198839,
+	0.0476835758578,
+	-0.0003421689790404,
+	-6.84707090541,
+	-0.005834100476561,
+	-0.0002905334122698,
+	-5.663161722088,
+	0.005722859298199,
+	-8.466472828815e-05,
+	-84.29817796435,
+	-207.2552484689,
+	7.876842214863,
+	1.836463749022,
+	-15.57471855361,
+	-20.06969124724,
+	21.52670284757,
+	-6.179946916139,
+	-0.9070028191196,
+	-12.70848233038,
+	-2.145589319058,
+	13.81936399935,
+	-1.999840061168,
+}
+
+// moshierMoonLR 黄经与距离的主要周期项：D、M、M′、F 的倍数，黄经与距离各分高低两段（高段×10000+低段，单位 1e-4″ 与 1e-4 千米）
+var moshierMoonLR = []int16{
+	0, 0, 1, 0, 22639, 5858, -20905, -3550,
+	2, 0, -1, 0, 4586, 4383, -3699, -1109,
+	2, 0, 0, 0, 2369, 9139, -2955, -9676,
+	0, 0, 2, 0, 769, 257, -569, -9251,
+	0, 1, 0, 0, -666, -4171, 48, 8883,
+	0, 0, 0, 2, -411, -5957, -3, -1483,
+	2, 0, -2, 0, 211, 6556, 246, 1585,
+	2, -1, -1, 0, 205, 4358, -152, -1377,
+	2, 0, 1, 0, 191, 9562, -170, -7331,
+	2, -1, 0, 0, 164, 7285, -204, -5860,
+	0, 1, -1, 0, -147, -3213, -129, -6201,
+	1, 0, 0, 0, -124, -9881, 108, 7427,
+	0, 1, 1, 0, -109, -3803, 104, 7552,
+	2, 0, 0, -2, 55, 1771, 10, 3211,
+	0, 0, 1, 2, -45, -996, 0, 0,
+	0, 0, 1, -2, 39, 5333, 79, 6606,
+	4, 0, -1, 0, 38, 4298, -34, -7825,
+	0, 0, 3, 0, 36, 1238, -23, -2104,
+	4, 0, -2, 0, 30, 7726, -21, -6363,
+	2, 1, -1, 0, -28, -3971, 24, 2085,
+	2, 1, 0, 0, -24, -3582, 30, 8238,
+	1, 0, -1, 0, -18, -5847, -8, -3791,
+	1, 1, 0, 0, 17, 9545, -16, -6747,
+	2, -1, 1, 0, 14, 5303, -12, -8314,
+	2, 0, 2, 0, 14, 3797, -10, -4448,
+	4, 0, 0, 0, 13, 8991, -11, -6500,
+	2, 0, -3, 0, 13, 1941, 14, 4027,
+	0, 1, -2, 0, -9, -6791, -7, -27,
+	2, 0, -1, 2, -9, -3659, 0, 7740,
+	2, -1, -2, 0, 8, 6055, 10, 562,
+	1, 0, 1, 0, -8, -4531, 6, 3220,
+	2, -2, 0, 0, 8, 502, -9, -8845,
+	0, 1, 2, 0, -7, -6302, 5, 7509,
+	0, 2, 0, 0, -7, -4475, 1, 657,
+	2, -2, -1, 0, 7, 3712, -4, -9501,
+	2, 0, 1, -2, -6, -3832, 4, 1311,
+	2, 0, 0, 2, -5, -7416, 0, 0,
+	4, -1, -1, 0, 4, 3740, -3, -9580,
+	0, 0, 2, 2, -3, -9976, 0, 0,
+	3, 0, -1, 0, -3, -2097, 3, 2582,
+	2, 1, 1, 0, -2, -9145, 2, 6164,
+	4, -1, -2, 0, 2, 7319, -1, -8970,
+	0, 2, -1, 0, -2, -5679, -2, -1171,
+	2, 2, -1, 0, -2, -5212, 2, 3536,
+	2, 1, -2, 0, 2, 4889, 0, 1437,
+	2, -1, 0, -2, 2, 1461, 0, 6571,
+	4, 0, 1, 0, 1, 9777, -1, -4226,
+	0, 0, 4, 0, 1, 9337, -1, -1169,
+	4, -1, 0, 0, 1, 8708, -1, -5714,
+	1, 0, -2, 0, -1, -7530, -1, -7385,
+	2, 1, 0, -2, -1, -4372, 0, -1357,
+	0, 0, 2, -2, -1, -3726, -4, -4212,
+	1, 1, 1, 0, 1, 2618, 0, -9333,
+	3, 0, -2, 0, -1, -2241, 0, 8624,
+	4, 0, -3, 0, 1, 1868, 0, -5142,
+	2, -1, 2, 0, 1, 1770, 0, -8488,
+	0, 2, 1, 0, -1, -1617, 1, 1655,
+	1, 1, -1, 0, 1, 777, 0, 8512,
+	2, 0, 3, 0, 1, 595, 0, -6697,
+	2, 0, 1, 2, 0, -9902, 0, 0,
+	2, 0, -4, 0, 0, 9483, 0, 7785,
+	2, -2, 1, 0, 0, 7517, 0, -6575,
+	0, 1, -3, 0, 0, -6694, 0, -4224,
+	4, 1, -1, 0, 0, -6352, 0, 5788,
+	1, 0, 2, 0, 0, -5840, 0, 3785,
+	1, 0, 0, -2, 0, -5833, 0, -7956,
+	6, 0, -2, 0, 0, 5716, 0, -4225,
+	2, 0, -2, -2, 0, -5606, 0, 4726,
+	1, -1, 0, 0, 0, -5569, 0, 4976,
+	0, 1, 3, 0, 0, -5459, 0, 3551,
+	2, 0, -2, 2, 0, -5357, 0, 7740,
+	2, 0, -1, -2, 0, 1790, 8, 7516,
+	3, 0, 0, 0, 0, 4042, -1, -4189,
+	2, -1, -3, 0, 0, 4784, 0, 4950,
+	2, -1, 3, 0, 0, 932, 0, -585,
+	2, 0, 2, -2, 0, -4538, 0, 2840,
+	2, -1, -1, 2, 0, -4262, 0, 373,
+	0, 0, 0, 4, 0, 4203, 0, 0,
+	0, 1, 0, 2, 0, 4134, 0, -1580,
+	6, 0, -1, 0, 0, 3945, 0, -2866,
+	2, -1, 0, 2, 0, -3821, 0, 0,
+	2, -1, 1, -2, 0, -3745, 0, 2094,
+	4, 1, -2, 0, 0, -3576, 0, 2370,
+	1, 1, -2, 0, 0, 3497, 0, 3323,
+	2, -3, 0, 0, 0, 3398, 0, -4107,
+	0, 0, 3, 2, 0, -3286, 0, 0,
+	4, -2, -1, 0, 0, -3087, 0, -2790,
+	0, 1, -1, -2, 0, 3015, 0, 0,
+	4, 0, -1, -2, 0, 3009, 0, -3218,
+	2, -2, -2, 0, 0, 2942, 0, 3430,
+	6, 0, -3, 0, 0, 2925, 0, -1832,
+	2, 1, 2, 0, 0, -2902, 0, 2125,
+	4, 1, 0, 0, 0, -2891, 0, 2445,
+	4, -1, 1, 0, 0, 2825, 0, -2029,
+	3, 1, -1, 0, 0, 2737, 0, -2126,
+	0, 1, 1, 2, 0, 2634, 0, 0,
+	1, 0, 0, 2, 0, 2543, 0, 0,
+	3, 0, 0, -2, 0, -2530, 0, 2010,
+	2, 2, -2, 0, 0, -2499, 0, -1089,
+	2, -3, -1, 0, 0, 2469, 0, -1481,
+	3, -1, -1, 0, 0, -2314, 0, 2556,
+	4, 0, 2, 0, 0, 2185, 0, -1392,
+	4, 0, -1, 2, 0, -2013, 0, 0,
+	0, 2, -2, 0, 0, -1931, 0, 0,
+	2, 2, 0, 0, 0, -1858, 0, 0,
+	2, 1, -3, 0, 0, 1762, 0, 0,
+	4, 0, -2, 2, 0, -1698, 0, 0,
+	4, -2, -2, 0, 0, 1578, 0, -1083,
+	4, -2, 0, 0, 0, 1522, 0, -1281,
+	3, 1, 0, 0, 0, 1499, 0, -1077,
+	1, -1, -1, 0, 0, -1364, 0, 1141,
+	1, -3, 0, 0, 0, -1281, 0, 0,
+	6, 0, 0, 0, 0, 1261, 0, -859,
+	2, 0, 2, 2, 0, -1239, 0, 0,
+	1, -1, 1, 0, 0, -1207, 0, 1100,
+	0, 0, 5, 0, 0, 1110, 0, -589,
+	0, 3, 0, 0, 0, -1013, 0, 213,
+	4, -1, -3, 0, 0, 998, 0, 0,
+}
+
+// moshierMoonMB 黄纬的主要周期项：D、M、M′、F 的倍数，黄纬分高低两段（单位 1e-4″）
+var moshierMoonMB = []int16{
+	0, 0, 0, 1, 18461, 2387,
+	0, 0, 1, 1, 1010, 1671,
+	0, 0, 1, -1, 999, 6936,
+	2, 0, 0, -1, 623, 6524,
+	2, 0, -1, 1, 199, 4837,
+	2, 0, -1, -1, 166, 5741,
+	2, 0, 0, 1, 117, 2607,
+	0, 0, 2, 1, 61, 9120,
+	2, 0, 1, -1, 33, 3572,
+	0, 0, 2, -1, 31, 7597,
+	2, -1, 0, -1, 29, 5766,
+	2, 0, -2, -1, 15, 5663,
+	2, 0, 1, 1, 15, 1216,
+	2, 1, 0, -1, -12, -941,
+	2, -1, -1, 1, 8, 8681,
+	2, -1, 0, 1, 7, 9586,
+	2, -1, -1, -1, 7, 4346,
+	0, 1, -1, -1, -6, -7314,
+	4, 0, -1, -1, 6, 5796,
+	0, 1, 0, 1, -6, -4601,
+	0, 0, 0, 3, -6, -2965,
+	0, 1, -1, 1, -5, -6324,
+	1, 0, 0, 1, -5, -3684,
+	0, 1, 1, 1, -5, -3113,
+	0, 1, 1, -1, -5, -759,
+	0, 1, 0, -1, -4, -8396,
+	1, 0, 0, -1, -4, -8057,
+	0, 0, 3, 1, 3, 9841,
+	4, 0, 0, -1, 3, 6745,
+	4, 0, -1, 1, 2, 9985,
+	0, 0, 1, -3, 2, 7986,
+	4, 0, -2, 1, 2, 4139,
+	2, 0, 0, -3, 2, 1863,
+	2, 0, 2, -1, 2, 1462,
+	2, -1, 1, -1, 1, 7660,
+	2, 0, -2, 1, -1, -6244,
+	0, 0, 3, -1, 1, 5813,
+	2, 0, 2, 1, 1, 5198,
+	2, 0, -3, -1, 1, 5156,
+	2, 1, -1, 1, -1, -3178,
+	2, 1, 0, 1, -1, -2643,
+	4, 0, 0, 1, 1, 1919,
+	2, -1, 1, 1, 1, 1346,
+	2, -2, 0, -1, 1, 859,
+	0, 0, 1, 3, -1, -194,
+	2, 1, 1, -1, 0, -8227,
+	1, 1, 0, -1, 0, 8042,
+	1, 1, 0, 1, 0, 8026,
+	0, 1, -2, -1, 0, -7932,
+	2, 1, -1, -1, 0, -7910,
+	1, 0, 1, 1, 0, -6674,
+	2, -1, -2, -1, 0, 6502,
+	0, 1, 2, 1, 0, -6388,
+	4, 0, -2, -1, 0, 6337,
+	4, -1, -1, -1, 0, 5958,
+	1, 0, 1, -1, 0, -5889,
+	4, 0, 1, -1, 0, 4734,
+	1, 0, -1, -1, 0, -4299,
+	4, -1, 0, -1, 0, 4149,
+	2, -2, 0, 1, 0, 3835,
+	3, 0, 0, -1, 0, -3518,
+	4, -1, -1, 1, 0, 3388,
+	2, 0, -1, -3, 0, 3291,
+	2, -2, -1, 1, 0, 3147,
+	0, 1, 2, -1, 0, -3129,
+	3, 0, -1, -1, 0, -3052,
+	0, 1, -2, 1, 0, -3013,
+	2, 0, 1, -3, 0, -2912,
+	2, -2, -1, -1, 0, 2686,
+	0, 0, 4, 1, 0, 2633,
+	2, 0, -3, 1, 0, 2541,
+	2, 0, -1, 3, 0, -2448,
+	2, 1, 1, 1, 0, -2370,
+	4, -1, -2, 1, 0, 2138,
+	4, 0, 1, 1, 0, 2126,
+	3, 0, -1, 1, 0, -2059,
+	4, 1, -1, -1, 0, -1719,
+}
+
+// moshierMoonLRT 黄经与距离的 T 项：D、M、M′、F 的倍数，黄经与距离各分高低两段（单位 1e-5″ 与 1e-5 千米）
+var moshierMoonLRT = []int16{
+	0, 1, 0, 0, 16, 7680, -1, -2302,
+	2, -1, -1, 0, -5, -1642, 3, 8245,
+	2, -1, 0, 0, -4, -1383, 5, 1395,
+	0, 1, -1, 0, 3, 7115, 3, 2654,
+	0, 1, 1, 0, 2, 7560, -2, -6396,
+	2, 1, -1, 0, 0, 7118, 0, -6068,
+	2, 1, 0, 0, 0, 6128, 0, -7754,
+	1, 1, 0, 0, 0, -4516, 0, 4194,
+	2, -2, 0, 0, 0, -4048, 0, 4970,
+	0, 2, 0, 0, 0, 3747, 0, -540,
+	2, -2, -1, 0, 0, -3707, 0, 2490,
+	2, -1, 1, 0, 0, -3649, 0, 3222,
+	0, 1, -2, 0, 0, 2438, 0, 1760,
+	2, -1, -2, 0, 0, -2165, 0, -2530,
+	0, 1, 2, 0, 0, 1923, 0, -1450,
+	0, 2, -1, 0, 0, 1292, 0, 1070,
+	2, 2, -1, 0, 0, 1271, 0, -6070,
+	4, -1, -1, 0, 0, -1098, 0, 990,
+	2, 0, 0, 0, 0, 1073, 0, -1360,
+	2, 0, -1, 0, 0, 839, 0, -630,
+	2, 1, 1, 0, 0, 734, 0, -660,
+	4, -1, -2, 0, 0, -688, 0, 480,
+	2, 1, -2, 0, 0, -630, 0, 0,
+	0, 2, 1, 0, 0, 587, 0, -590,
+	2, -1, 0, -2, 0, -540, 0, -170,
+	4, -1, 0, 0, 0, -468, 0, 390,
+	2, -2, 1, 0, 0, -378, 0, 330,
+	2, 1, 0, -2, 0, 364, 0, 0,
+	1, 1, 1, 0, 0, -317, 0, 240,
+	2, -1, 2, 0, 0, -295, 0, 210,
+	1, 1, -1, 0, 0, -270, 0, -210,
+	2, -3, 0, 0, 0, -256, 0, 310,
+	2, -3, -1, 0, 0, -187, 0, 110,
+	0, 1, -3, 0, 0, 169, 0, 110,
+	4, 1, -1, 0, 0, 158, 0, -150,
+	4, -2, -1, 0, 0, -155, 0, 140,
+	0, 0, 1, 0, 0, 155, 0, -250,
+	2, -2, -2, 0, 0, -148, 0, -170,
+}
+
+// moshierMoonBT 黄纬的 T 项：D、M、M′、F 的倍数，黄纬（1e-5″）
+var moshierMoonBT = []int16{
+	2, -1, 0, -1, -7430,
+	2, 1, 0, -1, 3043,
+	2, -1, -1, 1, -2229,
+	2, -1, 0, 1, -1999,
+	2, -1, -1, -1, -1869,
+	0, 1, -1, -1, 1696,
+	0, 1, 0, 1, 1623,
+	0, 1, -1, 1, 1418,
+	0, 1, 1, 1, 1339,
+	0, 1, 1, -1, 1278,
+	0, 1, 0, -1, 1217,
+	2, -2, 0, -1, -547,
+	2, -1, 1, -1, -443,
+	2, 1, -1, 1, 331,
+	2, 1, 0, 1, 317,
+	2, 0, 0, -1, 295,
+}
+
+// moshierMoonLRT2 黄经与距离的 T² 项：D、M、M′、F 的倍数，黄经（1e-5″）、距离（1e-5 千米）
+var moshierMoonLRT2 = []int16{
+	0, 1, 0, 0, 487, -36,
+	2, -1, -1, 0, -150, 111,
+	2, -1, 0, 0, -120, 149,
+	0, 1, -1, 0, 108, 95,
+	0, 1, 1, 0, 80, -77,
+	2, 1, -1, 0, 21, -18,
+	2, 1, 0, 0, 20, -23,
+	1, 1, 0, 0, -13, 12,
+	2, -2, 0, 0, -12, 14,
+	2, -1, 1, 0, -11, 9,
+	2, -2, -1, 0, -11, 7,
+	0, 2, 0, 0, 11, 0,
+	2, -1, -2, 0, -6, -7,
+	0, 1, -2, 0, 7, 5,
+	0, 1, 2, 0, 6, -4,
+	2, 2, -1, 0, 5, -3,
+	0, 2, -1, 0, 5, 3,
+	4, -1, -1, 0, -3, 3,
+	2, 0, 0, 0, 3, -4,
+	4, -1, -2, 0, -2, 0,
+	2, 1, -2, 0, -2, 0,
+	2, -1, 0, -2, -2, 0,
+	2, 1, 1, 0, 2, -2,
+	2, 0, -1, 0, 2, 0,
+	0, 2, 1, 0, 2, 0,
+}
+
+// moshierMoonBT2 黄纬的 T² 项：D、M、M′、F 的倍数，黄纬（1e-5″）
+var moshierMoonBT2 = []int16{
+	2, -1, 0, -1, -22,
+	2, 1, 0, -1, 9,
+	2, -1, 0, 1, -6,
+	2, -1, -1, 1, -6,
+	2, -1, -1, -1, -5,
+	0, 1, 0, 1, 5,
+	0, 1, -1, -1, 5,
+	0, 1, 1, 1, 4,
+	0, 1, 1, -1, 4,
+	0, 1, 0, -1, 4,
+	0, 1, -1, 1, 4,
+	2, -2, 0, -1, -2,
+}
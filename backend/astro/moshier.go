@@ -0,0 +1,160 @@
+package astro
+
+import "math"
+
+//go:generate go run gen_moshier.go
+
+// ==================== Moshier 行星理论 ====================
+// 行星日心坐标采用 S. L. Moshier 对 JPL DE404 的解析拟合（与 Swiss Ephemeris 内置 Moshier 模式相同），
+// 适用范围为公元前 3000 年至公元 3000 年，黄经误差约 1″。系数表由 gen_moshier.go 从 include/swemptab.h 生成。
+// swemptab.h 属于 Swiss Ephemeris，生成的 moshier_tables.go 沿用其 AGPL / Swiss Ephemeris Professional 双重许可，
+// 即使以 CGO_ENABLED=0 构建，二进制也包含 Swiss Ephemeris 的代码（见 docs/SWISS_EPHEMERIS.md 的许可证说明）
+
+// moshierTable 单颗行星的拟合系数表
+type moshierTable struct {
+	maxHarmonic [9]int // 各平黄经参数的最高倍数
+	maxPowerOfT int    // 时间幂次上限
+	args        []int8 // 周期项参数描述
+	lon         []float64
+	lat         []float64
+	rad         []float64
+	distance    float64 // 平均日心距离 (AU)
+}
+
+const (
+	moshierTimescale = 3652500.0                // 时间单位：一万儒略年
+	arcsecToRad      = 4.8481368110953599359e-6 // 每角秒的弧度数
+)
+
+// moshierFreqs 水星至冥王星平黄经的变化率（角秒/万年）
+var moshierFreqs = [9]float64{
+	53810162868.8982,
+	21066413643.3548,
+	12959774228.3429,
+	6890507749.3988,
+	1092566037.7991,
+	439960985.5372,
+	154248119.3933,
+	78655032.0744,
+	52272245.1795,
+}
+
+// moshierPhases J2000 平黄经（角秒）
+var moshierPhases = [9]float64{
+	252.25090552 * 3600,
+	181.97980085 * 3600,
+	100.46645683 * 3600,
+	355.43299958 * 3600,
+	34.35151874 * 3600,
+	50.07744430 * 3600,
+	314.05500511 * 3600,
+	304.34866548 * 3600,
+	860492.1546,
+}
+
+// mod3600 将角秒归一化到一周（1296000″）之内
+func mod3600(x float64) float64 {
+	return x - 1.296e6*math.Floor(x/1.296e6)
+}
+
+// moshierHeliocentric 计算行星在 J2000 黄道坐标系中的日心黄经、黄纬（弧度）与距离（AU）
+// jdTT 为地球时儒略日
+func moshierHeliocentric(table *moshierTable, jdTT float64) (lon, lat, r float64) {
+	T := (jdTT - J2000) / moshierTimescale
+
+	// 预先计算各平黄经参数的倍角正弦与余弦
+	var ss, cc [9][24]float64
+	for i := 0; i < 9; i++ {
+		n := table.maxHarmonic[i]
+		if n <= 0 {
+			continue
+		}
+		arg := (mod3600(moshierFreqs[i]*T) + moshierPhases[i]) * arcsecToRad
+		s, c := math.Sin(arg), math.Cos(arg)
+		ss[i][0], cc[i][0] = s, c
+		for k := 1; k < n; k++ {
+			ss[i][k] = s*cc[i][k-1] + c*ss[i][k-1]
+			cc[i][k] = c*cc[i][k-1] - s*ss[i][k-1]
+		}
+	}
+
+	p := table.args
+	pl, pb, pr := table.lon, table.lat, table.rad
+	var sl, sb, sr float64
+
+	// polynomial 以霍纳法计算 nt 次多项式，返回消耗的系数个数
+	polynomial := func(coef []float64, nt int) float64 {
+		v := coef[0]
+		for i := 1; i <= nt; i++ {
+			v = v*T + coef[i]
+		}
+		return v
+	}
+	// periodic 计算余弦、正弦振幅的多项式
+	periodic := func(coef []float64, nt int) (cu, su float64) {
+		cu, su = coef[0], coef[1]
+		for i := 1; i <= nt; i++ {
+			cu = cu*T + coef[2*i]
+			su = su*T + coef[2*i+1]
+		}
+		return cu, su
+	}
+
+	for {
+		np := int(p[0])
+		p = p[1:]
+		if np < 0 {
+			break
+		}
+
+		if np == 0 {
+			// 多项式项（长期项）
+			nt := int(p[0])
+			p = p[1:]
+			sl += mod3600(polynomial(pl, nt))
+			sb += polynomial(pb, nt)
+			sr += polynomial(pr, nt)
+			pl, pb, pr = pl[nt+1:], pb[nt+1:], pr[nt+1:]
+			continue
+		}
+
+		// 合成周期项的幅角：Σ j·(第 m 个平黄经)
+		first := true
+		var sv, cv float64
+		for ip := 0; ip < np; ip++ {
+			j := int(p[0])
+			m := int(p[1]) - 1
+			p = p[2:]
+			if j == 0 {
+				continue
+			}
+			k := j
+			if k < 0 {
+				k = -k
+			}
+			su, cu := ss[m][k-1], cc[m][k-1]
+			if j < 0 {
+				su = -su
+			}
+			if first {
+				sv, cv = su, cu
+				first = false
+			} else {
+				sv, cv = su*cv+cu*sv, cu*cv-su*sv
+			}
+		}
+
+		nt := int(p[0])
+		p = p[1:]
+
+		cu, su := periodic(pl, nt)
+		sl += cu*cv + su*sv
+		cu, su = periodic(pb, nt)
+		sb += cu*cv + su*sv
+		cu, su = periodic(pr, nt)
+		sr += cu*cv + su*sv
+		pl, pb, pr = pl[2*nt+2:], pb[2*nt+2:], pr[2*nt+2:]
+	}
+
+	return arcsecToRad * sl, arcsecToRad * sb, arcsecToRad*table.distance*sr + table.distance
+}
@@ -1,6 +1,7 @@
 package astro
 
 import (
+	"fmt"
 	"star/models"
 )
 
@@ -9,18 +10,49 @@ func CalculateNatalChart(birthData models.BirthData) *models.NatalChart {
 	// 计算儒略日
 	jd := DateToJulianDay(birthData.ToTime())
 
+	// 黄道模式（回归/恒星）
+	zodiac, ayanamsa := ResolveZodiac(birthData)
+	birthData.Zodiac, birthData.Ayanamsa = zodiac, ayanamsa
+	zodiacOffset := ZodiacOffset(zodiac, ayanamsa, jd)
+
+	// 天体选择与交点类型
+	bodies, nodeType := ResolveBodies(birthData)
+	birthData.NodeType = nodeType
+	if len(birthData.Bodies) > 0 {
+		birthData.Bodies = bodies
+	}
+
 	// 计算行星位置 - 使用 Swiss Ephemeris
-	planets := GetPlanetPositionsUnified(jd)
+	planets := ApplyZodiacToPositions(GetBodyPositionsUnified(jd, bodies, nodeType), zodiacOffset)
 
-	// 计算宫位 - 使用 Swiss Ephemeris
-	houses, ascendant, midheaven := CalculateHousesUnified(jd, birthData.Latitude, birthData.Longitude)
+	// 计算宫位 - 使用 Swiss Ephemeris，按出生数据中选择的分宫制
+	houseSystem := ResolveHouseSystem(birthData.HouseSystem)
+	birthData.HouseSystem = houseSystem
+	effectiveSystem := EffectiveHouseSystem(houseSystem, jd, birthData.Latitude)
+	houses, ascendant, midheaven := CalculateHousesUnified(jd, birthData.Latitude, birthData.Longitude, houseSystem)
+	houses, ascendant, midheaven = ApplyZodiacToHouses(houses, ascendant, midheaven, effectiveSystem, zodiacOffset)
 
 	// 为行星分配宫位
 	planets = AssignHousesToPlanets(planets, houses)
 
+	// 先天尊贵：按日夜区分与所选界体系重算尊贵分
+	terms := ResolveTermSystem(birthData.Terms)
+	birthData.Terms = terms
+	sect := planetsSect(planets, ascendant)
+	dignities := ApplyEssentialDignities(planets, sect, terms)
+
+	// 后天尊贵：宫位、与太阳的距离、速度、东出西入与日夜区分
+	ApplyAccidentalDignities(planets, sect)
+
+	// 定位星树与互容
+	dispositors := CalculateDispositors(planets)
+
 	// 计算相位
 	aspects := CalculateAspects(planets)
 
+	// 计算赤纬平行/反平行
+	parallels := CalculateParallels(planets)
+
 	// 检测图形相位
 	patterns := DetectPatterns(aspects, planets)
 
@@ -28,11 +60,24 @@ func CalculateNatalChart(birthData models.BirthData) *models.NatalChart {
 	elementBalance := CalculateElementBalance(planets)
 	modalityBalance := CalculateModalityBalance(planets)
 
+	// 星盘形状与半球、象限分布
+	shape := CalculateChartShape(planets)
+	hemispheres := CalculateHemisphereBalance(planets)
+	quadrants := CalculateQuadrantBalance(planets)
+
 	// 找出主导行星
-	dominantPlanets := FindDominantPlanets(planets, aspects)
+	dominantPlanets := FindDominantPlanets(planets, aspects, dispositors)
 
-	// 确定命主星
-	chartRuler := GetChartRuler(ascendant)
+	// 确定命主星：考虑候选守护星的状态、互容与定位链
+	chartRulerInfo := AnalyzeChartRuler(ascendant, planets, dispositors)
+	chartRuler := chartRulerOf(chartRulerInfo, ascendant)
+
+	// 阿拉伯点（按日夜反转）及其与天体的相位
+	lots, _ := CalculateLots(jd, planets, houses, ascendant, midheaven)
+	aspects = append(aspects, CalculateLotAspects(lots, planets)...)
+
+	// 恒星合相/平行
+	fixedStars := CalculateNatalFixedStars(jd, zodiacOffset, planets, ascendant, midheaven)
 
 	return &models.NatalChart{
 		BirthData:       birthData,
@@ -40,18 +85,83 @@ func CalculateNatalChart(birthData models.BirthData) *models.NatalChart {
 		Houses:          houses,
 		Ascendant:       ascendant,
 		Midheaven:       midheaven,
+		HouseSystem:     effectiveSystem,
+		Zodiac:          zodiac,
+		Ayanamsa:        ayanamsa,
+		AyanamsaValue:   zodiacOffset,
 		Aspects:         aspects,
+		Parallels:       parallels,
+		OutOfBounds:     OutOfBoundsPlanets(planets),
 		Patterns:        patterns,
 		ElementBalance:  elementBalance,
 		ModalityBalance: modalityBalance,
+		Shape:           shape,
+		Hemispheres:     hemispheres,
+		Quadrants:       quadrants,
 		DominantPlanets: dominantPlanets,
 		ChartRuler:      chartRuler,
+		ChartRulerInfo:  chartRulerInfo,
+		Sect:            sect,
+		Lots:            lots,
+		FixedStars:      fixedStars,
+		Dignities:       dignities,
+		Dispositors:     dispositors,
 	}
 }
 
-// GetPlanetFromChart 从星盘中获取指定行星
+// ValidateBirthData 校验出生数据中的星盘选项
+func ValidateBirthData(birthData models.BirthData) error {
+	if _, err := ParseHouseSystem(birthData.HouseSystem); err != nil {
+		return err
+	}
+	if _, err := ParseZodiacMode(birthData.Zodiac); err != nil {
+		return err
+	}
+	if _, err := ParseAyanamsa(birthData.Ayanamsa); err != nil {
+		return err
+	}
+	if _, err := ParseNodeType(birthData.NodeType); err != nil {
+		return err
+	}
+	if _, err := ParseTermSystem(birthData.Terms); err != nil {
+		return err
+	}
+	if _, err := ParseBodies(birthData.Bodies); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ValidateLocation 校验地理位置（为空时视为未提供）
+func ValidateLocation(location *models.GeoLocation) error {
+	if location == nil {
+		return nil
+	}
+	if location.Latitude < -90 || location.Latitude > 90 {
+		return fmt.Errorf("纬度超出范围 [-90, 90]: %v", location.Latitude)
+	}
+	if location.Longitude < -180 || location.Longitude > 180 {
+		return fmt.Errorf("经度超出范围 [-180, 180]: %v", location.Longitude)
+	}
+	return nil
+}
+
+// GetPlanetFromChart 从星盘中获取指定行星（也可查找阿拉伯点）
 func GetPlanetFromChart(chart *models.NatalChart, planetID models.PlanetID) *models.PlanetPosition {
-	for _, p := range chart.Planets {
+	if p := findPosition(chart.Planets, planetID); p != nil {
+		return p
+	}
+	for _, lot := range chart.Lots {
+		if lot.ID == planetID {
+			return &lot.PlanetPosition
+		}
+	}
+	return nil
+}
+
+// findPosition 从位置列表中查找指定天体
+func findPosition(positions []models.PlanetPosition, planetID models.PlanetID) *models.PlanetPosition {
+	for _, p := range positions {
 		if p.ID == planetID {
 			return &p
 		}
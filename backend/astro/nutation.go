@@ -0,0 +1,100 @@
+package astro
+
+import "math"
+
+// ==================== 黄赤交角与章动 ====================
+
+// MeanObliquity 计算平黄赤交角（IAU 1980，度）
+func MeanObliquity(jd float64) float64 {
+	T := (jd - J2000) / 36525.0
+	seconds := 21.448 - 46.8150*T - 0.00059*T*T + 0.001813*T*T*T
+	return 23 + 26.0/60 + seconds/3600
+}
+
+// nutationTerm IAU 1980 章动序列的一项：D、M、M′、F、Ω 的倍数与系数（0.0001″）
+type nutationTerm struct {
+	D, M, Mp, F, Omega int
+	Psi, PsiT          float64 // Δψ 正弦系数及其每世纪变化
+	Eps, EpsT          float64 // Δε 余弦系数及其每世纪变化
+}
+
+// nutationTerms IAU 1980 章动序列中振幅不小于 0.0011″ 的项（Meeus 表 22.A）
+var nutationTerms = []nutationTerm{
+	{0, 0, 0, 0, 1, -171996, -174.2, 92025, 8.9},
+	{-2, 0, 0, 2, 2, -13187, -1.6, 5736, -3.1},
+	{0, 0, 0, 2, 2, -2274, -0.2, 977, -0.5},
+	{0, 0, 0, 0, 2, 2062, 0.2, -895, 0.5},
+	{0, 1, 0, 0, 0, 1426, -3.4, 54, -0.1},
+	{0, 0, 1, 0, 0, 712, 0.1, -7, 0},
+	{-2, 1, 0, 2, 2, -517, 1.2, 224, -0.6},
+	{0, 0, 0, 2, 1, -386, -0.4, 200, 0},
+	{0, 0, 1, 2, 2, -301, 0, 129, -0.1},
+	{-2, -1, 0, 2, 2, 217, -0.5, -95, 0.3},
+	{-2, 0, 1, 0, 0, -158, 0, 0, 0},
+	{-2, 0, 0, 2, 1, 129, 0.1, -70, 0},
+	{0, 0, -1, 2, 2, 123, 0, -53, 0},
+	{2, 0, 0, 0, 0, 63, 0, 0, 0},
+	{0, 0, 1, 0, 1, 63, 0.1, -33, 0},
+	{2, 0, -1, 2, 2, -59, 0, 26, 0},
+	{0, 0, -1, 0, 1, -58, -0.1, 32, 0},
+	{0, 0, 1, 2, 1, -51, 0, 27, 0},
+	{-2, 0, 2, 0, 0, 48, 0, 0, 0},
+	{0, 0, -2, 2, 1, 46, 0, -24, 0},
+	{2, 0, 0, 2, 2, -38, 0, 16, 0},
+	{0, 0, 2, 2, 2, -31, 0, 13, 0},
+	{0, 0, 2, 0, 0, 29, 0, 0, 0},
+	{-2, 0, 1, 2, 2, 29, 0, -12, 0},
+	{0, 0, 0, 2, 0, 26, 0, 0, 0},
+	{-2, 0, 0, 2, 0, -22, 0, 0, 0},
+	{0, 0, -1, 2, 1, 21, 0, -10, 0},
+	{0, 2, 0, 0, 0, 17, -0.1, 0, 0},
+	{2, 0, -1, 0, 1, 16, 0, -8, 0},
+	{-2, 2, 0, 2, 2, -16, 0.1, 7, 0},
+	{0, 1, 0, 0, 1, -15, 0, 9, 0},
+	{-2, 0, 1, 0, 1, -13, 0, 7, 0},
+	{0, -1, 0, 0, 1, -12, 0, 6, 0},
+	{0, 0, 2, -2, 0, 11, 0, 0, 0},
+}
+
+// Nutation 计算黄经章动 Δψ 与交角章动 Δε（度）
+// 采用 IAU 1980 章动序列的主要项，截断误差小于 0.01″
+func Nutation(jd float64) (dpsi, deps float64) {
+	T := (jd - J2000) / 36525.0
+	T2, T3 := T*T, T*T*T
+
+	// 日月平距角、太阳与月亮平近点角、月球纬度幅角、升交点平黄经
+	D := (297.85036 + 445267.111480*T - 0.0019142*T2 + T3/189474) * DEG_TO_RAD
+	M := (357.52772 + 35999.050340*T - 0.0001603*T2 - T3/300000) * DEG_TO_RAD
+	Mp := (134.96298 + 477198.867398*T + 0.0086972*T2 + T3/56250) * DEG_TO_RAD
+	F := (93.27191 + 483202.017538*T - 0.0036825*T2 + T3/327270) * DEG_TO_RAD
+	omega := (125.04452 - 1934.136261*T + 0.0020708*T2 + T3/450000) * DEG_TO_RAD
+
+	for _, n := range nutationTerms {
+		arg := float64(n.D)*D + float64(n.M)*M + float64(n.Mp)*Mp + float64(n.F)*F + float64(n.Omega)*omega
+		dpsi += (n.Psi + n.PsiT*T) * math.Sin(arg)
+		deps += (n.Eps + n.EpsT*T) * math.Cos(arg)
+	}
+
+	return dpsi / 36000000, deps / 36000000
+}
+
+// TrueObliquity 计算真黄赤交角（平黄赤交角 + 交角章动，度）
+func TrueObliquity(jd float64) float64 {
+	_, deps := Nutation(jd)
+	return MeanObliquity(jd) + deps
+}
+
+// ApparentSiderealTime 计算格林威治视恒星时（度）
+// 平恒星时加上赤经章动（Δψ·cos ε）
+func ApparentSiderealTime(jd float64) float64 {
+	T := (jd - J2000) / 36525.0
+
+	// 格林威治平恒星时
+	gmst := 280.46061837 + 360.98564736629*(jd-J2000) +
+		0.000387933*T*T - T*T*T/38710000
+
+	dpsi, _ := Nutation(jd)
+	eps := TrueObliquity(jd) * DEG_TO_RAD
+
+	return NormalizeAngle(gmst + dpsi*math.Cos(eps))
+}
@@ -16,6 +16,10 @@ type PlanetaryHourInfo struct {
 	DayRuler      models.PlanetID `json:"dayRuler"`
 	Influence     float64         `json:"influence"`
 	BestFor       []string        `json:"bestFor"`
+	Start         time.Time       `json:"start"`           // 行星时开始（日出/日落等分）
+	End           time.Time       `json:"end"`             // 行星时结束
+	IsDaytime     bool            `json:"isDaytime"`       // 日间行星时（1-12）
+	Polar         string          `json:"polar,omitempty"` // polarDay / polarNight
 }
 
 // 迦勒底行星顺序（行星时循环顺序）
@@ -46,42 +50,142 @@ var planetaryHourActivities = map[models.PlanetID][]string{
 	models.Saturn:  {"planning", "meditation", "solitude", "organizing"},
 }
 
-// CalculatePlanetaryHourEnhanced 计算行星时（增强版）
-func CalculatePlanetaryHourEnhanced(t time.Time, lat, lon float64) PlanetaryHourInfo {
-	// 获取日主星
-	dayRuler := dayRulers[t.Weekday()]
+// 极区日出日落状态
+const (
+	PolarDay   = "polarDay"   // 极昼：太阳整日不落
+	PolarNight = "polarNight" // 极夜：太阳整日不升
+)
 
-	// 计算日出日落时间（简化：使用固定的 6:00 和 18:00）
-	// 完整实现需要根据纬度和日期计算实际日出日落时间
-	sunriseHour := 6.0
-	sunsetHour := 18.0
+// PlanetaryDay 一个行星日：日出起至次日日出止，日间与夜间各分为 12 个不等长的行星时
+type PlanetaryDay struct {
+	Sunrise     time.Time       `json:"sunrise"`
+	Sunset      time.Time       `json:"sunset"`
+	NextSunrise time.Time       `json:"nextSunrise"`
+	DayRuler    models.PlanetID `json:"dayRuler"`
+	Polar       string          `json:"polar,omitempty"` // 极昼/极夜时按当地平太阳时 6:00/18:00 等分
+}
 
-	// 日间和夜间小时数
-	dayLength := sunsetHour - sunriseHour   // 12 小时
-	nightLength := 24 - dayLength           // 12 小时
+// HourBounds 第 n 个行星时（1-24）的起止时刻
+func (d PlanetaryDay) HourBounds(n int) (time.Time, time.Time) {
+	from, to, idx := d.Sunrise, d.Sunset, n-1
+	if n > 12 {
+		from, to, idx = d.Sunset, d.NextSunrise, n-13
+	}
+	length := to.Sub(from) / 12
+	bound := func(k int) time.Time {
+		if k == 12 {
+			return to
+		}
+		return from.Add(time.Duration(k) * length).Round(time.Second)
+	}
+	return bound(idx), bound(idx + 1)
+}
 
-	// 当前时间（小时）
-	currentHour := float64(t.Hour()) + float64(t.Minute())/60.0
+// localMeanTime 以经度换算的当地平太阳时（用 UTC 时区承载）
+func localMeanTime(t time.Time, lon float64) time.Time {
+	return t.UTC().Add(time.Duration(lon / 15 * float64(time.Hour)))
+}
 
-	// 判断是日间还是夜间，计算行星时编号
-	var planetaryHourNum int
-	var hourLength float64
+// polarPlanetaryDay 太阳不升不落时，以当地平太阳时 6:00 与 18:00 作为日出日落
+func polarPlanetaryDay(t time.Time, lon float64, polar string) PlanetaryDay {
+	local := localMeanTime(t, lon)
+	if local.Hour() < 6 {
+		local = local.AddDate(0, 0, -1)
+	}
+	offset := time.Duration(lon / 15 * float64(time.Hour))
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC).Add(-offset)
+	return PlanetaryDay{
+		Sunrise:     midnight.Add(6 * time.Hour),
+		Sunset:      midnight.Add(18 * time.Hour),
+		NextSunrise: midnight.Add(30 * time.Hour),
+		DayRuler:    dayRulers[local.Weekday()],
+		Polar:       polar,
+	}
+}
 
-	if currentHour >= sunriseHour && currentHour < sunsetHour {
-		// 日间
-		hourLength = dayLength / 12.0
-		hoursFromSunrise := currentHour - sunriseHour
-		planetaryHourNum = int(hoursFromSunrise/hourLength) + 1
-	} else {
-		// 夜间
-		hourLength = nightLength / 12.0
-		var hoursFromSunset float64
-		if currentHour >= sunsetHour {
-			hoursFromSunset = currentHour - sunsetHour
-		} else {
-			hoursFromSunset = (24 - sunsetHour) + currentHour
+// planetaryDays 按星历源与地点缓存的行星日；接口接受任意坐标，容量固定以免缓存随请求无限增长
+var planetaryDays = newSpanCache[planetaryDayKey, PlanetaryDay](256)
+
+// planetaryDayKey 行星日缓存键
+type planetaryDayKey struct {
+	provider string
+	lat, lon float64
+}
+
+// PlanetaryDayAt 求 t 时刻在 (lat, lon) 所处的行星日（t 之前最近一次日出起）
+// 日主星取日出时当地平太阳时的星期，而非服务器或客户端时钟的星期
+func PlanetaryDayAt(t time.Time, lat, lon float64) PlanetaryDay {
+	provider := CurrentEphemerisProvider()
+	key := planetaryDayKey{provider.Name(), lat, lon}
+
+	if day, ok := planetaryDays.get(key, func(d PlanetaryDay) bool {
+		return !t.Before(d.Sunrise) && t.Before(d.NextSunrise)
+	}); ok {
+		return day
+	}
+
+	day := findPlanetaryDay(provider, t, lat, lon)
+	planetaryDays.put(key, day)
+	return day
+}
+
+// findPlanetaryDay 搜索 t 之前最近一次日出、其后的日落与下一次日出；任一不存在时按极昼/极夜处理
+func findPlanetaryDay(provider EphemerisProvider, t time.Time, lat, lon float64) PlanetaryDay {
+	jd := DateToJulianDay(t.UTC())
+
+	polar := func() PlanetaryDay {
+		alt, err := altitudeOf(providerEclipticPosition(provider, models.Sun), jd, lat, lon)
+		state := PolarNight
+		if err == nil && alt > horizonAltitude(models.Sun) {
+			state = PolarDay
 		}
-		planetaryHourNum = int(hoursFromSunset/hourLength) + 13
+		return polarPlanetaryDay(t, lon, state)
+	}
+
+	// 自约 1.2 天前起向后找，保留不晚于 t 的最后一次日出
+	sunrise, err := provider.RiseSet(models.Sun, jd-1.2, lat, lon, EventRise)
+	if err != nil || sunrise > jd {
+		return polar()
+	}
+	for {
+		next, err := provider.RiseSet(models.Sun, sunrise+0.01, lat, lon, EventRise)
+		if err != nil || next > jd {
+			break
+		}
+		sunrise = next
+	}
+
+	sunset, err := provider.RiseSet(models.Sun, sunrise, lat, lon, EventSet)
+	if err != nil {
+		return polar()
+	}
+	nextSunrise, err := provider.RiseSet(models.Sun, sunset, lat, lon, EventRise)
+	if err != nil || nextSunrise <= jd {
+		return polar()
+	}
+
+	rise := julianDayToTime(sunrise)
+	return PlanetaryDay{
+		Sunrise:     rise,
+		Sunset:      julianDayToTime(sunset),
+		NextSunrise: julianDayToTime(nextSunrise),
+		DayRuler:    dayRulers[localMeanTime(rise, lon).Weekday()],
+	}
+}
+
+// CalculatePlanetaryHourEnhanced 计算行星时（增强版）
+// 按 (lat, lon) 的真实日出日落划分不等长的日间与夜间行星时
+func CalculatePlanetaryHourEnhanced(t time.Time, lat, lon float64) PlanetaryHourInfo {
+	day := PlanetaryDayAt(t, lat, lon)
+
+	// 计算行星时编号
+	var planetaryHourNum int
+	if t.Before(day.Sunset) {
+		hourLength := day.Sunset.Sub(day.Sunrise) / 12
+		planetaryHourNum = int(t.Sub(day.Sunrise)/hourLength) + 1
+	} else {
+		hourLength := day.NextSunrise.Sub(day.Sunset) / 12
+		planetaryHourNum = int(t.Sub(day.Sunset)/hourLength) + 13
 	}
 
 	// 限制在 1-24 范围
@@ -92,10 +196,15 @@ func CalculatePlanetaryHourEnhanced(t time.Time, lat, lon float64) PlanetaryHour
 		planetaryHourNum = 1
 	}
 
+	return planetaryHourInfo(day, planetaryHourNum)
+}
+
+// planetaryHourInfo 构造行星日中第 n 个行星时的信息
+func planetaryHourInfo(day PlanetaryDay, n int) PlanetaryHourInfo {
 	// 找到日主星在迦勒底顺序中的位置
 	dayRulerIdx := 0
 	for i, p := range chaldeanOrder {
-		if p == dayRuler {
+		if p == day.DayRuler {
 			dayRulerIdx = i
 			break
 		}
@@ -103,23 +212,28 @@ func CalculatePlanetaryHourEnhanced(t time.Time, lat, lon float64) PlanetaryHour
 
 	// 计算当前行星时的主管行星
 	// 行星时从日主星开始，按迦勒底顺序循环
-	rulerIdx := (dayRulerIdx + planetaryHourNum - 1) % 7
+	rulerIdx := (dayRulerIdx + n - 1) % 7
 	ruler := chaldeanOrder[rulerIdx]
 
 	// 获取行星信息
 	planetInfo := GetPlanetInfo(ruler)
 
 	// 计算影响值（基于行星吉凶性质）
-	influence := getPlanetaryHourInfluence(ruler, dayRuler)
+	influence := getPlanetaryHourInfluence(ruler, day.DayRuler)
 
+	start, end := day.HourBounds(n)
 	return PlanetaryHourInfo{
-		PlanetaryHour: planetaryHourNum,
+		PlanetaryHour: n,
 		Ruler:         ruler,
 		PlanetName:    planetInfo.Name,
 		PlanetSymbol:  planetInfo.Symbol,
-		DayRuler:      dayRuler,
+		DayRuler:      day.DayRuler,
 		Influence:     influence,
 		BestFor:       planetaryHourActivities[ruler],
+		Start:         start,
+		End:           end,
+		IsDaytime:     n <= 12,
+		Polar:         day.Polar,
 	}
 }
 
@@ -147,12 +261,24 @@ func getPlanetaryHourInfluence(ruler, dayRuler models.PlanetID) float64 {
 }
 
 // GetPlanetaryHoursForDate 获取指定日期所有行星时
+// 返回该日（以 (lat, lon) 的当地日期计）日出开始的行星日中 24 个行星时及其真实起止时刻
 func GetPlanetaryHoursForDate(date time.Time, lat, lon float64) []PlanetaryHourInfo {
+	// 当地平太阳时正午所在的行星日即始于当日日出
+	offset := time.Duration(lon / 15 * float64(time.Hour))
+	noon := time.Date(date.Year(), date.Month(), date.Day(), 12, 0, 0, 0, time.UTC).Add(-offset)
+	day := PlanetaryDayAt(noon, lat, lon)
+
 	hours := make([]PlanetaryHourInfo, 24)
-	for h := 0; h < 24; h++ {
-		t := time.Date(date.Year(), date.Month(), date.Day(), h, 0, 0, 0, date.Location())
-		hours[h] = CalculatePlanetaryHourEnhanced(t, lat, lon)
+	for n := 1; n <= 24; n++ {
+		hours[n-1] = planetaryHourInfo(day, n)
 	}
 	return hours
 }
 
+// ObserverLocation 观测地点：优先使用当前所在地，否则使用出生地
+func ObserverLocation(chart *models.NatalChart) (lat, lon float64) {
+	if chart.CurrentLocation != nil {
+		return chart.CurrentLocation.Latitude, chart.CurrentLocation.Longitude
+	}
+	return chart.BirthData.Latitude, chart.BirthData.Longitude
+}
@@ -129,6 +129,82 @@ func TestGetPlanetaryHoursForDate(t *testing.T) {
 	}
 }
 
+// TestPlanetaryHoursRealSunrise 测试按真实日出日落划分的不等长行星时
+// 参考：伦敦 2024-06-21（周五）日出约 03:43 UT，日落约 20:21 UT
+func TestPlanetaryHoursRealSunrise(t *testing.T) {
+	useProvider(t, NewPreciseProvider())
+
+	hours := GetPlanetaryHoursForDate(time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC), 51.5074, -0.1278)
+	if len(hours) != 24 {
+		t.Fatalf("应该返回 24 个行星时，但得到 %d", len(hours))
+	}
+
+	sunrise := time.Date(2024, 6, 21, 3, 43, 0, 0, time.UTC)
+	sunset := time.Date(2024, 6, 21, 20, 21, 0, 0, time.UTC)
+	if d := hours[0].Start.Sub(sunrise); d < -2*time.Minute || d > 2*time.Minute {
+		t.Errorf("第一个行星时应始于日出 %s，实际 %s", sunrise, hours[0].Start)
+	}
+	if d := hours[12].Start.Sub(sunset); d < -2*time.Minute || d > 2*time.Minute {
+		t.Errorf("第十三个行星时应始于日落 %s，实际 %s", sunset, hours[12].Start)
+	}
+	if hours[0].DayRuler != models.Venus || hours[0].Ruler != models.Venus {
+		t.Errorf("周五第一个行星时应由金星主管: %+v", hours[0])
+	}
+	for i := 1; i < 24; i++ {
+		if !hours[i].Start.Equal(hours[i-1].End) {
+			t.Errorf("行星时 %d 与 %d 不连续", i, i+1)
+		}
+	}
+
+	// 夏至日间行星时长于夜间
+	day := hours[0].End.Sub(hours[0].Start)
+	night := hours[12].End.Sub(hours[12].Start)
+	t.Logf("日间行星时 %s，夜间行星时 %s", day, night)
+	if day < 80*time.Minute || night > 40*time.Minute {
+		t.Errorf("伦敦夏至行星时长度异常: 日间 %s，夜间 %s", day, night)
+	}
+
+	// 某时刻所处行星时与全天列表一致
+	info := CalculatePlanetaryHourEnhanced(hours[5].Start.Add(time.Minute), 51.5074, -0.1278)
+	if info.PlanetaryHour != 6 || info.Ruler != hours[5].Ruler || !info.IsDaytime {
+		t.Errorf("行星时应为第 6 个日间行星时: %+v", info)
+	}
+
+	// 东京当地周五早晨（UTC 仍为周四）日主星应为金星
+	tokyo := CalculatePlanetaryHourEnhanced(time.Date(2024, 6, 20, 22, 0, 0, 0, time.UTC), 35.6762, 139.6503)
+	if tokyo.DayRuler != models.Venus {
+		t.Errorf("东京周五早晨日主星应为金星，实际 %s", tokyo.DayRuler)
+	}
+}
+
+// TestPlanetaryHoursPolarDay 测试极昼时按当地平太阳时等分行星时
+func TestPlanetaryHoursPolarDay(t *testing.T) {
+	useProvider(t, NewPreciseProvider())
+
+	hours := GetPlanetaryHoursForDate(time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC), 69.6492, 18.9553)
+	if hours[0].Polar != PolarDay {
+		t.Fatalf("特罗姆瑟夏至应为极昼: %+v", hours[0])
+	}
+	for _, h := range hours {
+		if d := h.End.Sub(h.Start); d < 59*time.Minute || d > 61*time.Minute {
+			t.Errorf("极昼行星时应等长 1 小时，实际 %s", d)
+		}
+	}
+}
+
+// TestPlanetaryDayCacheBounded 测试任意坐标的请求不会使行星日缓存无限增长
+func TestPlanetaryDayCacheBounded(t *testing.T) {
+	useProvider(t, NewBuiltinProvider())
+
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < planetaryDays.limit+20; i++ {
+		PlanetaryDayAt(at, 30+float64(i)*0.01, 100)
+	}
+	if n := planetaryDays.len(); n > planetaryDays.limit {
+		t.Errorf("行星日缓存应不超过 %d 个，实际 %d", planetaryDays.limit, n)
+	}
+}
+
 // BenchmarkPlanetaryHour 性能测试
 func BenchmarkPlanetaryHour(b *testing.B) {
 	testDate := time.Date(2024, 1, 7, 12, 0, 0, 0, time.UTC)
@@ -65,9 +65,40 @@ func CalculateAnnualProfection(chart *models.NatalChart, age int) *models.Annual
 		LordNatalHouse: lordNatalHouse,
 		LordNatalSign:  lordNatalSign,
 		Description:    description,
+		ActivatedLots:  activatedLots(chart, zodiac.ID),
+		LotProfections: calculateLotProfections(chart, age),
 	}
 }
 
+// activatedLots 落在年度星座中的阿拉伯点
+func activatedLots(chart *models.NatalChart, sign models.ZodiacID) []models.PlanetID {
+	var ids []models.PlanetID
+	for _, lot := range chart.Lots {
+		if lot.Sign == sign {
+			ids = append(ids, lot.ID)
+		}
+	}
+	return ids
+}
+
+// calculateLotProfections 以各阿拉伯点所在星座为起点推进 age 个星座（如福点年限的年主主管当年财务）
+func calculateLotProfections(chart *models.NatalChart, age int) []models.LotProfection {
+	var result []models.LotProfection
+	for _, lot := range chart.Lots {
+		zodiac := ZodiacSigns[(signIndex(lot.Sign)+age)%12]
+		lord := GetPlanetInfo(zodiac.Ruler)
+		result = append(result, models.LotProfection{
+			Lot:      lot.ID,
+			LotName:  lot.Name,
+			Sign:     zodiac.ID,
+			SignName: zodiac.Name,
+			Lord:     zodiac.Ruler,
+			LordName: lord.Name,
+		})
+	}
+	return result
+}
+
 // generateProfectionDescription 生成年限法描述
 func generateProfectionDescription(house int, houseInfo *HouseInfo, _ *ZodiacInfo, lordInfo *PlanetInfo) string {
 	return fmt.Sprintf(
@@ -24,7 +24,9 @@ func CalculateProgressions(chart *models.NatalChart, targetDateStr string) *mode
 
 	// 计算推运行星位置 - 使用 Swiss Ephemeris
 	progressedJd := DateToJulianDay(progressedDate)
-	progressedPositions := GetPlanetPositionsUnified(progressedJd)
+	zodiacOffset := ChartZodiacOffset(chart, progressedJd)
+	bodies, nodeType := ResolveBodies(chart.BirthData)
+	progressedPositions := ApplyZodiacToPositions(GetBodyPositionsUnified(progressedJd, bodies, nodeType), zodiacOffset)
 
 	// 创建推运行星列表
 	progressedPlanets := make([]models.ProgressedPlanet, len(progressedPositions))
@@ -59,11 +61,14 @@ func CalculateProgressions(chart *models.NatalChart, targetDateStr string) *mode
 		progressedJd,
 		chart.BirthData.Latitude,
 		chart.BirthData.Longitude,
+		chart.HouseSystem,
 	)
+	progressedHouses, progressedAsc, progressedMc = ApplyZodiacToHouses(
+		progressedHouses, progressedAsc, progressedMc, chart.HouseSystem, zodiacOffset)
 	_ = progressedHouses
 
 	// 计算推运相位
-	aspects := CalculateAspects(progressedPositions)
+	aspects := CalculateAspectsFor(AspectContextProgressed, progressedPositions)
 
 	// 计算推运月相
 	var sunLon, moonLon float64
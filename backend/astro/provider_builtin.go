@@ -0,0 +1,60 @@
+package astro
+
+import "star/models"
+
+// ==================== 内置解析算法提供者 ====================
+
+// BuiltinProvider 基于 ephemeris.go 中解析算法的星历提供者（纯 Go，无 CGO 依赖）
+type BuiltinProvider struct{}
+
+// NewBuiltinProvider 创建内置算法提供者
+func NewBuiltinProvider() *BuiltinProvider {
+	return &BuiltinProvider{}
+}
+
+// Name 提供者标识
+func (p *BuiltinProvider) Name() string {
+	return ProviderBuiltin
+}
+
+// Description 数据源描述
+func (p *BuiltinProvider) Description() string {
+	return "Built-in Algorithm (NOT RECOMMENDED)"
+}
+
+// PlanetPosition 计算天体位置
+func (p *BuiltinProvider) PlanetPosition(planet models.PlanetID, jd float64) (models.PlanetPosition, error) {
+	return builtinPlanetPosition(planet, jd), nil
+}
+
+// builtinPlanetPosition 内置算法计算天体位置，速度由前后半天的位置差求得
+func builtinPlanetPosition(planet models.PlanetID, jd float64) models.PlanetPosition {
+	pos := CalculatePlanetPosition(planet, jd)
+	before := CalculatePlanetPosition(planet, jd-0.5)
+	after := CalculatePlanetPosition(planet, jd+0.5)
+
+	return newMovingPosition(planet, jd, pos.Longitude, pos.Latitude, planetMotion{
+		Speed:         normalizeSigned(after.Longitude - before.Longitude),
+		LatitudeSpeed: after.Latitude - before.Latitude,
+	})
+}
+
+// Houses 计算宫位
+func (p *BuiltinProvider) Houses(jd, lat, lon float64, system models.HouseSystem) ([]models.HouseCusp, float64, float64, error) {
+	houses, asc, mc := CalculateHouses(jd, lat, lon, system)
+	return houses, asc, mc, nil
+}
+
+// RiseSet 查找下一次升起或落下
+func (p *BuiltinProvider) RiseSet(body models.PlanetID, jd, lat, lon float64, event RiseSetEvent) (float64, error) {
+	return findRiseSet(providerEclipticPosition(p, body), body, jd, lat, lon, event)
+}
+
+// NextEclipse 按 Meeus 算法查找日月食
+func (p *BuiltinProvider) NextEclipse(jd float64, kind EclipseKind, backward bool) (EclipseInfo, error) {
+	maxJD, eclipseType, magnitude, err := searchEclipse(jd, kind, backward)
+	if err != nil {
+		return EclipseInfo{}, err
+	}
+	return newEclipseInfo(p, kind, eclipseType, maxJD, magnitude), nil
+}
@@ -0,0 +1,276 @@
+package astro
+
+import (
+	"fmt"
+	"math"
+	"star/models"
+)
+
+// ==================== 纯 Go 高精度星历提供者 ====================
+// 行星：Moshier DE404 拟合（moshier.go，约 1″），月球：Moshier 月球理论（moon_moshier.go，约 1″），
+// 北交点：由月球位置与速度求得的密切（真）交点或平交点，黑月莉莉丝：月球平远地点或密切远地点。
+// 凯龙星、谷神星等小天体只有二体轨道近似（数角分至约 1°），本提供者不提供，由统一星历层回退到内置算法。
+// 输出与 Swiss Ephemeris 默认设置一致：地心视位置，含光行时、周年光行差、岁差与章动，参考真春分点
+
+const (
+	lightTimePerAU = 0.0057755183      // 光行 1 AU 所需天数
+	speedOfLight   = 173.1446326846693 // 光速（AU/天）
+	kmPerAU        = 149597870.7
+	earthMoonRatio = 81.30056 // 地月质量比
+	speedStep      = 0.05     // 计算速度的数值求导步长（天）
+)
+
+// preciseTables 各行星对应的 Moshier 系数表
+var preciseTables = map[models.PlanetID]*moshierTable{
+	models.Mercury: &moshierMercury,
+	models.Venus:   &moshierVenus,
+	models.Mars:    &moshierMars,
+	models.Jupiter: &moshierJupiter,
+	models.Saturn:  &moshierSaturn,
+	models.Uranus:  &moshierUranus,
+	models.Neptune: &moshierNeptune,
+	models.Pluto:   &moshierPluto,
+}
+
+// keplerElements 密切轨道根数（J2000 黄道与春分点）
+type keplerElements struct {
+	A, E, I, Node, Peri float64 // AU、离心率、倾角、升交点黄经、近日点幅角（度）
+	Tp                  float64 // 过近日点时刻（儒略日，TT）
+}
+
+// chironElements 凯龙星密切轨道根数
+// 未计入大行星摄动，1950-2050 年间误差约数角分
+var chironElements = keplerElements{13.6481, 0.3832, 6.9352, 209.3711, 339.4350, 2450128.5}
+
+// keplerBodies 按二体轨道计算的小天体（凯龙星、谷神星、智神星、婚神星、灶神星、阋神星），仅供内置算法使用
+// 根数取近年历元，未计入木星摄动，主带小行星在 2000-2040 年间误差约 1°
+var keplerBodies = map[models.PlanetID]keplerElements{
+	models.Chiron: chironElements,
+	models.Ceres:  {2.7675, 0.0785, 10.587, 80.27, 73.60, 2459920.6},
+	models.Pallas: {2.7725, 0.2300, 34.84, 172.90, 310.30, 2456630.0},
+	models.Juno:   {2.6686, 0.2562, 12.99, 169.85, 248.20, 2458447.5},
+	models.Vesta:  {2.3615, 0.0894, 7.142, 103.81, 151.20, 2459576.5},
+	models.Eris:   {67.864, 0.4370, 44.04, 35.87, 151.43, 2545579.0},
+}
+
+// PreciseProvider 纯 Go 高精度星历提供者
+type PreciseProvider struct{}
+
+// NewPreciseProvider 创建纯 Go 高精度提供者
+func NewPreciseProvider() *PreciseProvider {
+	return &PreciseProvider{}
+}
+
+// Name 提供者标识
+func (p *PreciseProvider) Name() string {
+	return ProviderPrecise
+}
+
+// Description 数据源描述
+func (p *PreciseProvider) Description() string {
+	return "Pure-Go Ephemeris (Moshier DE404 planets and Moon)"
+}
+
+// Cacheable 单次计算开销较大，经由星历缓存读取
+func (p *PreciseProvider) Cacheable() bool {
+	return true
+}
+
+// PlanetPosition 计算天体的地心视位置（jd 为世界时）
+func (p *PreciseProvider) PlanetPosition(planet models.PlanetID, jd float64) (models.PlanetPosition, error) {
+	jdTT := TerrestrialTime(jd)
+
+	lon, lat, dist, err := preciseGeocentric(planet, jdTT)
+	if err != nil {
+		return models.PlanetPosition{}, err
+	}
+
+	// 前后 speedStep 天中心差分求速度
+	lonBefore, latBefore, _, _ := preciseGeocentric(planet, jdTT-speedStep)
+	lonAfter, latAfter, _, _ := preciseGeocentric(planet, jdTT+speedStep)
+
+	return newMovingPosition(planet, jd, lon, lat, planetMotion{
+		Distance:      dist,
+		Speed:         normalizeSigned(lonAfter-lonBefore) / (2 * speedStep),
+		LatitudeSpeed: (latAfter - latBefore) / (2 * speedStep),
+	}), nil
+}
+
+// Houses 计算宫位
+func (p *PreciseProvider) Houses(jd, lat, lon float64, system models.HouseSystem) ([]models.HouseCusp, float64, float64, error) {
+	houses, asc, mc := CalculateHouses(jd, lat, lon, system)
+	return houses, asc, mc, nil
+}
+
+// RiseSet 查找下一次升起或落下
+func (p *PreciseProvider) RiseSet(body models.PlanetID, jd, lat, lon float64, event RiseSetEvent) (float64, error) {
+	return findRiseSet(providerEclipticPosition(p, body), body, jd, lat, lon, event)
+}
+
+// NextEclipse 按 Meeus 算法查找日月食
+func (p *PreciseProvider) NextEclipse(jd float64, kind EclipseKind, backward bool) (EclipseInfo, error) {
+	maxJD, eclipseType, magnitude, err := searchEclipse(jd, kind, backward)
+	if err != nil {
+		return EclipseInfo{}, err
+	}
+	return newEclipseInfo(p, kind, eclipseType, maxJD, magnitude), nil
+}
+
+// ==================== 视位置计算 ====================
+
+// preciseApparent 计算天体的地心视黄经、视黄纬（度，真春分点）
+func preciseApparent(planet models.PlanetID, jdTT float64) (lon, lat float64, err error) {
+	lon, lat, _, err = preciseGeocentric(planet, jdTT)
+	return lon, lat, err
+}
+
+// preciseGeocentric 计算天体的地心视黄经、视黄纬（度）与地心距离（AU，交点与远地点为 0）
+// 只有二体轨道近似的小天体达不到角秒级精度，返回错误
+func preciseGeocentric(planet models.PlanetID, jdTT float64) (lon, lat, dist float64, err error) {
+	if _, ok := keplerBodies[planet]; ok {
+		return 0, 0, 0, fmt.Errorf("%w: 纯 Go 星历对 %s 只有二体轨道近似，未达到角秒级精度", ErrBodyNotProvided, planet)
+	}
+	return apparentGeocentric(planet, jdTT)
+}
+
+// apparentGeocentric 计算天体的地心视黄经、视黄纬（度）与地心距离（AU），小天体按二体轨道计算
+func apparentGeocentric(planet models.PlanetID, jdTT float64) (lon, lat, dist float64, err error) {
+	dpsi, _ := Nutation(jdTT)
+
+	switch planet {
+	case models.Moon:
+		// 光行时：观测到的是月球在 t-τ 时刻的地心位置（地球公转的光行时效应与周年光行差相互抵消）
+		_, _, dist = moshierMoon(jdTT)
+		lon, lat, _ = moshierMoon(jdTT - dist/kmPerAU*lightTimePerAU)
+		return NormalizeAngle(lon + dpsi), lat, dist / kmPerAU, nil
+	case models.NorthNode:
+		return NormalizeAngle(trueLunarNode(jdTT) + dpsi), 0, 0, nil
+	case models.MeanNode:
+		return NormalizeAngle(MeanLunarNode(jdTT) + dpsi), 0, 0, nil
+	case models.Lilith:
+		lon, lat = meanLunarApogee(jdTT)
+		return NormalizeAngle(lon + dpsi), lat, 0, nil
+	case models.OscLilith:
+		lon, lat = osculatingLunarApogee(jdTT)
+		return NormalizeAngle(lon + dpsi), lat, 0, nil
+	}
+
+	earth := earthHeliocentric(jdTT)
+	var geo [3]float64
+	if planet == models.Sun {
+		geo = [3]float64{-earth[0], -earth[1], -earth[2]}
+	} else {
+		// 光行时迭代：观测到的是天体在 t-τ 时刻发出的光
+		tau := 0.0
+		for i := 0; i < 3; i++ {
+			helio, err := heliocentricJ2000(planet, jdTT-tau)
+			if err != nil {
+				return 0, 0, 0, err
+			}
+			for k := range geo {
+				geo[k] = helio[k] - earth[k]
+			}
+			tau = vectorLength(geo) * lightTimePerAU
+		}
+	}
+
+	// 周年光行差：视方向向地球运动方向偏移 v/c
+	before, after := earthHeliocentric(jdTT-0.01), earthHeliocentric(jdTT+0.01)
+	dist = vectorLength(geo)
+	for k := range geo {
+		velocity := (after[k] - before[k]) / 0.02
+		geo[k] += dist * velocity / speedOfLight
+	}
+
+	l, b, _ := cartesianToSpherical(geo)
+	l, b = precessEclipticFromJ2000(l, b, jdTT)
+	return NormalizeAngle(l*RAD_TO_DEG + dpsi), b * RAD_TO_DEG, dist, nil
+}
+
+// earthHeliocentric 地球日心直角坐标（AU，J2000 黄道）
+// Moshier 表给出地月质心，需按地月质量比扣除月球的偏移
+func earthHeliocentric(jdTT float64) [3]float64 {
+	l, b, r := moshierHeliocentric(&moshierEMB, jdTT)
+	emb := sphericalToCartesian(l, b, r)
+
+	moonLon, moonLat, moonDist := moshierMoon(jdTT)
+	moonLon, moonLat = precessEclipticToJ2000(moonLon*DEG_TO_RAD, moonLat*DEG_TO_RAD, jdTT)
+	moon := sphericalToCartesian(moonLon, moonLat, moonDist/kmPerAU)
+	for k := range emb {
+		emb[k] -= moon[k] / (earthMoonRatio + 1)
+	}
+	return emb
+}
+
+// heliocentricJ2000 行星日心直角坐标（AU，J2000 黄道）
+func heliocentricJ2000(planet models.PlanetID, jdTT float64) ([3]float64, error) {
+	if table, ok := preciseTables[planet]; ok {
+		l, b, r := moshierHeliocentric(table, jdTT)
+		return sphericalToCartesian(l, b, r), nil
+	}
+	if el, ok := keplerBodies[planet]; ok {
+		return el.heliocentric(jdTT), nil
+	}
+	return [3]float64{}, fmt.Errorf("纯 Go 星历不支持天体: %s", planet)
+}
+
+// heliocentric 按二体轨道计算日心坐标
+func (el keplerElements) heliocentric(jdTT float64) [3]float64 {
+	n := 0.01720209895 / math.Sqrt(el.A*el.A*el.A) // 平均角速度（弧度/天）
+	M := math.Mod(n*(jdTT-el.Tp), 2*math.Pi)
+	E := solveKepler(M, el.E)
+
+	// 轨道平面坐标
+	x := el.A * (math.Cos(E) - el.E)
+	y := el.A * math.Sqrt(1-el.E*el.E) * math.Sin(E)
+
+	w := el.Peri * DEG_TO_RAD
+	o := el.Node * DEG_TO_RAD
+	i := el.I * DEG_TO_RAD
+	cosW, sinW := math.Cos(w), math.Sin(w)
+	cosO, sinO := math.Cos(o), math.Sin(o)
+	cosI, sinI := math.Cos(i), math.Sin(i)
+
+	return [3]float64{
+		(cosO*cosW-sinO*sinW*cosI)*x + (-cosO*sinW-sinO*cosW*cosI)*y,
+		(sinO*cosW+cosO*sinW*cosI)*x + (-sinO*sinW+cosO*cosW*cosI)*y,
+		(sinW*sinI)*x + (cosW*sinI)*y,
+	}
+}
+
+// precessEclipticFromJ2000 将 J2000 黄道坐标岁差改正到瞬时平黄道（弧度）
+// 采用 Lieske (1977) 黄道岁差参数（Meeus 第 21 章）
+func precessEclipticFromJ2000(lon, lat, jdTT float64) (float64, float64) {
+	t := (jdTT - J2000) / 36525.0
+	eta := ((47.0029*t - 0.03302*t*t + 0.000060*t*t*t) / 3600) * DEG_TO_RAD
+	pi := (174.876384 + (-869.8089*t+0.03536*t*t)/3600) * DEG_TO_RAD
+	p := ((5029.0966*t + 1.11113*t*t - 0.000006*t*t*t) / 3600) * DEG_TO_RAD
+
+	a := math.Cos(eta)*math.Cos(lat)*math.Sin(pi-lon) - math.Sin(eta)*math.Sin(lat)
+	b := math.Cos(lat) * math.Cos(pi-lon)
+	c := math.Cos(eta)*math.Sin(lat) + math.Sin(eta)*math.Cos(lat)*math.Sin(pi-lon)
+
+	return p + pi - math.Atan2(a, b), math.Asin(c)
+}
+
+// precessEclipticToJ2000 将瞬时平黄道坐标岁差改正回 J2000 黄道（弧度），为 precessEclipticFromJ2000 的逆变换
+func precessEclipticToJ2000(lon, lat, jdTT float64) (float64, float64) {
+	t := (jdTT - J2000) / 36525.0
+	eta := ((47.0029*t - 0.03302*t*t + 0.000060*t*t*t) / 3600) * DEG_TO_RAD
+	pi := (174.876384 + (-869.8089*t+0.03536*t*t)/3600) * DEG_TO_RAD
+	p := ((5029.0966*t + 1.11113*t*t - 0.000006*t*t*t) / 3600) * DEG_TO_RAD
+
+	u := p + pi - lon
+	a := math.Cos(lat) * math.Sin(u)
+	b := math.Cos(lat) * math.Cos(u)
+	c := math.Sin(lat)
+
+	y := math.Cos(eta)*a + math.Sin(eta)*c
+	z := math.Cos(eta)*c - math.Sin(eta)*a
+	return pi - math.Atan2(y, b), math.Asin(z)
+}
+
+// vectorLength 矢量长度
+func vectorLength(v [3]float64) float64 {
+	return math.Sqrt(v[0]*v[0] + v[1]*v[1] + v[2]*v[2])
+}
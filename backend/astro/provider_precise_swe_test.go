@@ -0,0 +1,69 @@
+//go:build swe
+// +build swe
+
+package astro
+
+import (
+	"math"
+	"os"
+	"star/models"
+	"testing"
+	"time"
+
+	"github.com/mshafiee/swephgo"
+)
+
+// TestPreciseProviderAgainstSwiss 纯 Go 星历与 Swiss Ephemeris 星历文件（JPL DE431 压缩）逐日比对
+// 需 -tags swe 构建并以 SE_EPHE_PATH 指向 sepl_18.se1、semo_18.se1 等文件；未找到文件时 Swiss Ephemeris
+// 会回退到与纯 Go 星历同源的 Moshier 理论，比对没有意义，因此跳过
+func TestPreciseProviderAgainstSwiss(t *testing.T) {
+	path := os.Getenv("SE_EPHE_PATH")
+	if path == "" {
+		t.Skip("未设置 SE_EPHE_PATH，跳过与 Swiss Ephemeris 星历文件的比对")
+	}
+	InitSwissEphemeris(path)
+
+	precise := NewPreciseProvider()
+	tolerances := []struct {
+		planet models.PlanetID
+		arcsec float64 // 黄经、黄纬容差（角秒），与 docs/SWISS_EPHEMERIS.md 中的精度表一致
+	}{
+		{models.Sun, 1},
+		{models.Mercury, 1},
+		{models.Venus, 1},
+		{models.Mars, 1},
+		{models.Jupiter, 1},
+		{models.Saturn, 1},
+		{models.Uranus, 1},
+		{models.Neptune, 1},
+		{models.Pluto, 1},
+		{models.Moon, 1},
+	}
+
+	for year := 1950; year <= 2050; year += 7 {
+		jd := DateToJulianDay(time.Date(year, 3, 21, 0, 0, 0, 0, time.UTC))
+		for _, tc := range tolerances {
+			xx := make([]float64, 6)
+			serr := make([]byte, 256)
+			sweMu.Lock()
+			ret := swephgo.CalcUt(jd, sweBodyMap[tc.planet], swephgo.SeflgSwieph, xx, serr)
+			sweMu.Unlock()
+			if ret < 0 {
+				t.Fatal(sweError(serr))
+			}
+			if int(ret)&swephgo.SeflgSwieph == 0 {
+				t.Skipf("Swiss Ephemeris 未找到 %s 的星历文件，已回退到 Moshier", tc.planet)
+			}
+
+			pos, err := precise.PlanetPosition(tc.planet, jd)
+			if err != nil {
+				t.Fatal(err)
+			}
+			dLon := AngleDifference(pos.Longitude, xx[0]) * math.Cos(xx[1]*DEG_TO_RAD) * 3600
+			dLat := math.Abs(pos.Latitude-xx[1]) * 3600
+			if dLon > tc.arcsec || dLat > tc.arcsec {
+				t.Errorf("%d 年 %s 与 Swiss Ephemeris 相差 Δλ·cosβ=%.2f″ Δβ=%.2f″，超过 %.0f″", year, tc.planet, dLon, dLat, tc.arcsec)
+			}
+		}
+	}
+}
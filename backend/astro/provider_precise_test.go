@@ -0,0 +1,115 @@
+package astro
+
+import (
+	"math"
+	"star/models"
+	"testing"
+	"time"
+)
+
+// TestNutationMeeus 章动与黄赤交角（Meeus 例 22.a，1987-04-10 0h TD）
+func TestNutationMeeus(t *testing.T) {
+	jd := 2446895.5
+	dpsi, deps := Nutation(jd)
+	if math.Abs(dpsi*3600-(-3.788)) > 0.01 || math.Abs(deps*3600-9.443) > 0.01 {
+		t.Errorf("章动错误: Δψ=%.3f″ Δε=%.3f″", dpsi*3600, deps*3600)
+	}
+	if eps := TrueObliquity(jd); math.Abs(eps-(23+26.0/60+36.850/3600))*3600 > 0.01 {
+		t.Errorf("真黄赤交角错误: %.6f°", eps)
+	}
+}
+
+// TestMoshierMoonMeeus 月球位置（Meeus 例 47.a，1992-04-12 0h TD）
+// 例题为第 47 章的截断级数（黄经约 10″、黄纬约 4″），只用于发现系数表的转录错误；
+// 与独立星历的角秒级比对见 provider_precise_swe_test.go
+func TestMoshierMoonMeeus(t *testing.T) {
+	lon, lat, dist := moshierMoon(2448724.5)
+	if math.Abs(lon-133.162655)*3600 > 10 || math.Abs(lat-(-3.229126))*3600 > 4 || math.Abs(dist-368409.7) > 10 {
+		t.Errorf("月球位置错误: λ=%.6f° β=%.6f° Δ=%.1f km", lon, lat, dist)
+	}
+}
+
+// TestPreciseProviderReference 纯 Go 星历与完整理论的参考视位置比对（月球见 TestMoshierMoonMeeus）
+func TestPreciseProviderReference(t *testing.T) {
+	tests := []struct {
+		name      string
+		planet    models.PlanetID
+		jdTT      float64
+		ra, decl  float64 // 视赤经、视赤纬（度）
+		tolerance float64 // 角秒
+	}{
+		// Meeus 例 25.b：太阳 1992-10-13 0h TD，α=13h13m30.749s δ=-7°47′01.74″（VSOP87 完整理论）
+		{"太阳", models.Sun, 2448908.5, (13 + 13.0/60 + 30.749/3600) * 15, -(7 + 47.0/60 + 1.74/3600), 2},
+		// Meeus 例 33.a：金星 1992-12-20 0h TD，α=21h04m41.454s δ=-18°53′16.84″
+		{"金星", models.Venus, 2448976.5, (21 + 4.0/60 + 41.454/3600) * 15, -(18 + 53.0/60 + 16.84/3600), 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lon, lat, err := preciseApparent(tt.planet, tt.jdTT)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ra, decl := EquatorialCoordinates(lon, lat, TrueObliquity(tt.jdTT))
+			dRA := AngleDifference(ra, tt.ra) * math.Cos(decl*DEG_TO_RAD) * 3600
+			dDecl := math.Abs(decl-tt.decl) * 3600
+			t.Logf("λ=%.6f° β=%.6f° 偏差 Δα·cosδ=%.2f″ Δδ=%.2f″", lon, lat, dRA, dDecl)
+			if dRA > tt.tolerance || dDecl > tt.tolerance {
+				t.Errorf("偏差超过 %.1f″", tt.tolerance)
+			}
+		})
+	}
+}
+
+// TestPreciseProviderBodies 纯 Go 星历覆盖除凯龙星外的全部默认天体，并与内置低精度算法大致一致；
+// 只有二体轨道近似的小天体不由纯 Go 星历提供
+func TestPreciseProviderBodies(t *testing.T) {
+	precise := NewPreciseProvider()
+	jd := DateToJulianDay(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	for _, planet := range DefaultBodies {
+		pos, err := precise.PlanetPosition(planet, jd)
+		if _, kepler := keplerBodies[planet]; kepler {
+			if err == nil {
+				t.Errorf("%s 只有二体轨道近似，不应由纯 Go 星历提供", planet)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s 计算失败: %v", planet, err)
+		}
+		t.Logf("%-10s %s %6.2f°  逆行=%v", planet, pos.Sign, pos.SignDegree, pos.Retrograde)
+		rough := CalculatePlanetPosition(planet, jd)
+		diff := AngleDifference(pos.Longitude, rough.Longitude)
+		if diff > 3 {
+			t.Errorf("%s 与内置算法相差过大: %.3f°", planet, diff)
+		}
+	}
+}
+
+// TestTrueNodeAndChiron 真交点与凯龙星
+func TestTrueNodeAndChiron(t *testing.T) {
+	// 真交点围绕平交点摆动，幅度不超过约 1.7°
+	for _, jd := range []float64{J2000, 2455197.5, 2460310.5} {
+		diff := AngleDifference(trueLunarNode(jd), MeanLunarNode(jd))
+		if diff > 1.8 {
+			t.Errorf("真交点偏离平交点过大: %.3f°", diff)
+		}
+	}
+
+	// 凯龙星进入双鱼座（2010-04-20）与白羊座（2018-04-17）
+	ingresses := []struct {
+		date      time.Time
+		longitude float64
+	}{
+		{time.Date(2010, 4, 20, 0, 0, 0, 0, time.UTC), 330},
+		{time.Date(2018, 4, 17, 0, 0, 0, 0, time.UTC), 0},
+	}
+	for _, in := range ingresses {
+		lon := CalculatePlanetPosition(models.Chiron, DateToJulianDay(in.date)).Longitude
+		t.Logf("凯龙星 %s: %.3f°", in.date.Format("2006-01-02"), lon)
+		if AngleDifference(lon, in.longitude) > 0.5 {
+			t.Errorf("凯龙星位置偏差过大: %.3f°", lon)
+		}
+	}
+}
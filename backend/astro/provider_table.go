@@ -0,0 +1,125 @@
+package astro
+
+import (
+	"fmt"
+	"math"
+	"star/models"
+)
+
+// ==================== 表驱动星历提供者（测试用） ====================
+
+// tableOrbit 圆轨道近似：天体以恒定角速度沿圆轨道运行
+// 日心天体按地球与行星的圆轨道几何求地心黄经，因而仍会出现逆行；
+// 结果完全确定、与平台无关，适合在无 CGO 环境下对评分流水线做单元测试
+type tableOrbit struct {
+	Heliocentric bool    // 是否为日心轨道（否则直接给出地心平黄经）
+	A            float64 // 半长轴 (AU)
+	L0           float64 // J2000 平黄经 (度)
+	N            float64 // 平均日行度 (度/天)
+}
+
+// tableEarth 地球圆轨道
+var tableEarth = tableOrbit{true, 1.00000, 100.46457, 0.98560912}
+
+// tableOrbits 各天体的圆轨道参数
+var tableOrbits = map[models.PlanetID]tableOrbit{
+	models.Moon:      {false, 0, 218.31645, 13.17639648},
+	models.Mercury:   {true, 0.38710, 252.25032, 4.09233445},
+	models.Venus:     {true, 0.72333, 181.97910, 1.60213034},
+	models.Mars:      {true, 1.52371, 355.44657, 0.52402068},
+	models.Jupiter:   {true, 5.20289, 34.39644, 0.08308529},
+	models.Saturn:    {true, 9.53668, 49.95424, 0.03344414},
+	models.Uranus:    {true, 19.18916, 313.23810, 0.01172834},
+	models.Neptune:   {true, 30.06992, 304.87997, 0.00598103},
+	models.Pluto:     {true, 39.48212, 238.92904, 0.00397570},
+	models.NorthNode: {false, 0, 125.04455, -0.05295377},
+	models.Chiron:    {true, 13.64800, 251.00000, 0.01955000},
+	models.MeanNode:  {false, 0, 125.04455, -0.05295377},
+	models.Lilith:    {false, 0, 263.35325, 0.11140408},
+	models.OscLilith: {false, 0, 263.35325, 0.11140408},
+	models.Ceres:     {true, 2.76750, 160.83600, 0.21407829},
+	models.Pallas:    {true, 2.77250, 117.55500, 0.21349944},
+	models.Juno:      {true, 2.66860, 297.47300, 0.22608870},
+	models.Vesta:     {true, 2.36150, 233.01400, 0.27159524},
+	models.Eris:      {true, 97.00000, 19.60000, 0.00103000}, // 取远日点附近的距离与角速度
+}
+
+// TableProvider 确定性的表驱动星历提供者，仅用于测试与比对
+type TableProvider struct{}
+
+// NewTableProvider 创建表驱动提供者
+func NewTableProvider() *TableProvider {
+	return &TableProvider{}
+}
+
+// Name 提供者标识
+func (p *TableProvider) Name() string {
+	return ProviderTable
+}
+
+// Description 数据源描述
+func (p *TableProvider) Description() string {
+	return "Deterministic Table Ephemeris (TEST ONLY)"
+}
+
+// tableHeliocentric 圆轨道日心直角坐标
+func tableHeliocentric(orbit tableOrbit, days float64) (x, y float64) {
+	l := (orbit.L0 + orbit.N*days) * DEG_TO_RAD
+	return orbit.A * math.Cos(l), orbit.A * math.Sin(l)
+}
+
+// tableLongitude 计算天体的地心黄经
+func tableLongitude(planet models.PlanetID, jd float64) (float64, error) {
+	days := jd - J2000
+	if planet == models.Sun {
+		return NormalizeAngle(tableEarth.L0 + tableEarth.N*days + 180), nil
+	}
+
+	orbit, ok := tableOrbits[planet]
+	if !ok {
+		return 0, fmt.Errorf("表驱动星历不支持天体: %s", planet)
+	}
+	if !orbit.Heliocentric {
+		return NormalizeAngle(orbit.L0 + orbit.N*days), nil
+	}
+
+	xP, yP := tableHeliocentric(orbit, days)
+	xE, yE := tableHeliocentric(tableEarth, days)
+	return NormalizeAngle(math.Atan2(yP-yE, xP-xE) * RAD_TO_DEG), nil
+}
+
+// PlanetPosition 计算天体位置（黄纬恒为 0）
+func (p *TableProvider) PlanetPosition(planet models.PlanetID, jd float64) (models.PlanetPosition, error) {
+	longitude, err := tableLongitude(planet, jd)
+	if err != nil {
+		return models.PlanetPosition{}, err
+	}
+
+	// 以前后半天的黄经差求速度并判断逆行
+	before, _ := tableLongitude(planet, jd-0.5)
+	after, _ := tableLongitude(planet, jd+0.5)
+
+	return newMovingPosition(planet, jd, longitude, 0, planetMotion{
+		Speed: normalizeSigned(after - before),
+	}), nil
+}
+
+// Houses 计算宫位（宫位为纯几何计算，直接复用纯 Go 实现）
+func (p *TableProvider) Houses(jd, lat, lon float64, system models.HouseSystem) ([]models.HouseCusp, float64, float64, error) {
+	houses, asc, mc := CalculateHouses(jd, lat, lon, system)
+	return houses, asc, mc, nil
+}
+
+// RiseSet 基于圆轨道位置查找升落
+func (p *TableProvider) RiseSet(body models.PlanetID, jd, lat, lon float64, event RiseSetEvent) (float64, error) {
+	return findRiseSet(providerEclipticPosition(p, body), body, jd, lat, lon, event)
+}
+
+// NextEclipse 按 Meeus 算法查找日月食（与圆轨道位置无关，结果同样确定）
+func (p *TableProvider) NextEclipse(jd float64, kind EclipseKind, backward bool) (EclipseInfo, error) {
+	maxJD, eclipseType, magnitude, err := searchEclipse(jd, kind, backward)
+	if err != nil {
+		return EclipseInfo{}, err
+	}
+	return newEclipseInfo(p, kind, eclipseType, maxJD, magnitude), nil
+}
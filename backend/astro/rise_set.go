@@ -0,0 +1,108 @@
+package astro
+
+import (
+	"math"
+	"star/models"
+)
+
+// ==================== 天体升落 ====================
+
+const (
+	riseSetStep   = 10.0 / 1440 // 扫描步长：10 分钟
+	riseSetWindow = 2.0         // 搜索窗口：2 天（覆盖月亮每日推迟约 50 分钟的情况）
+)
+
+// horizonAltitude 天体升落时中心的几何高度（度）
+// 太阳与月亮按上边缘接触地平计算，均含 34′ 标准大气折射；月亮另计地平视差
+func horizonAltitude(body models.PlanetID) float64 {
+	switch body {
+	case models.Sun:
+		return -0.8333
+	case models.Moon:
+		return 0.125
+	}
+	return -0.5667
+}
+
+// EquatorialCoordinates 黄道坐标转换为赤经赤纬（度）
+func EquatorialCoordinates(longitude, latitude, eps float64) (ra, decl float64) {
+	l := longitude * DEG_TO_RAD
+	b := latitude * DEG_TO_RAD
+	e := eps * DEG_TO_RAD
+	ra = NormalizeAngle(math.Atan2(math.Sin(l)*math.Cos(e)-math.Tan(b)*math.Sin(e), math.Cos(l)) * RAD_TO_DEG)
+	decl = math.Asin(math.Sin(b)*math.Cos(e)+math.Cos(b)*math.Sin(e)*math.Sin(l)) * RAD_TO_DEG
+	return ra, decl
+}
+
+// altitudeOf 计算天体在指定时刻与地点的地平高度（度）
+func altitudeOf(position func(jd float64) (float64, float64, error), jd, lat, lon float64) (float64, error) {
+	lambda, beta, err := position(jd)
+	if err != nil {
+		return 0, err
+	}
+	ra, decl := EquatorialCoordinates(lambda, beta, TrueObliquity(jd))
+	hourAngle := (ApparentSiderealTime(jd) + lon - ra) * DEG_TO_RAD
+	phi := lat * DEG_TO_RAD
+	d := decl * DEG_TO_RAD
+	sinAlt := math.Sin(phi)*math.Sin(d) + math.Cos(phi)*math.Cos(d)*math.Cos(hourAngle)
+	return math.Asin(sinAlt) * RAD_TO_DEG, nil
+}
+
+// findRiseSet 扫描高度曲线查找下一次升起或落下，再以二分法精化到约 1 秒
+// position 返回天体在给定时刻的黄经与黄纬
+func findRiseSet(position func(jd float64) (float64, float64, error), body models.PlanetID, jd, lat, lon float64, event RiseSetEvent) (float64, error) {
+	h0 := horizonAltitude(body)
+	height := func(t float64) (float64, error) {
+		alt, err := altitudeOf(position, t, lat, lon)
+		return alt - h0, err
+	}
+
+	// 采样点对齐到以 J2000 为原点的固定网格，从不同起点搜索同一事件得到完全相同的结果
+	start := J2000 + math.Floor((jd-J2000)/riseSetStep)*riseSetStep
+	prev, err := height(start)
+	if err != nil {
+		return 0, err
+	}
+	for t := start + riseSetStep; t <= jd+riseSetWindow; t += riseSetStep {
+		cur, err := height(t)
+		if err != nil {
+			return 0, err
+		}
+
+		crossed := (event == EventRise && prev < 0 && cur >= 0) ||
+			(event == EventSet && prev >= 0 && cur < 0)
+		if crossed {
+			lo, hi := t-riseSetStep, t
+			for hi-lo > 1.0/86400 {
+				mid := (lo + hi) / 2
+				h, err := height(mid)
+				if err != nil {
+					return 0, err
+				}
+				if (h >= 0) == (event == EventRise) {
+					hi = mid
+				} else {
+					lo = mid
+				}
+			}
+			// 首个网格区间可能包含 jd 之前的事件
+			if root := (lo + hi) / 2; root >= jd {
+				return root, nil
+			}
+		}
+		prev = cur
+	}
+
+	return 0, ErrNoRiseSet
+}
+
+// providerEclipticPosition 将提供者的行星位置包装为升落搜索使用的坐标函数
+func providerEclipticPosition(provider EphemerisProvider, body models.PlanetID) func(jd float64) (float64, float64, error) {
+	return func(jd float64) (float64, float64, error) {
+		pos, err := provider.PlanetPosition(body, jd)
+		if err != nil {
+			return 0, 0, err
+		}
+		return pos.Longitude, pos.Latitude, nil
+	}
+}
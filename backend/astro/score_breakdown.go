@@ -39,6 +39,7 @@ type FactorContribution struct {
 	Dimension   string             `json:"dimension,omitempty"` // 主要影响维度
 	Description string             `json:"description"`
 	IsPositive  bool               `json:"isPositive"`
+	Phase       string             `json:"phase,omitempty"` // 相位阶段：applying / separating
 }
 
 // DimensionBreakdown 维度分值分解
@@ -143,17 +144,22 @@ func isTimeLevelVisible(factorLevel models.FactorTimeLevel, visibleLevels []mode
 
 // CalculateScoreBreakdown 计算分值组成详情
 func CalculateScoreBreakdown(chart *models.NatalChart, t time.Time, granularity string, userID string) ScoreBreakdownResponse {
+	return scoreBreakdown(CurrentScoringConfig(), chart, t, granularity, userID)
+}
+
+// scoreBreakdown 按配置快照计算分值组成详情
+func scoreBreakdown(cfg *ScoringConfig, chart *models.NatalChart, t time.Time, granularity string, userID string) ScoreBreakdownResponse {
 	// 1. 获取行运位置
-	transitPositions := GetTransitPositions(t)
+	transitPositions := GetTransitPositionsForChart(chart, t)
 	
 	// 2. 计算相位
-	aspects := CalculateTransitToNatalAspects(transitPositions, chart.Planets)
+	aspects := CalculateTransitToNatalAspects(transitPositions, NatalPoints(chart))
 	
 	// 3. 计算每个维度的相位贡献
 	dimensionAspectScores := calculateDimensionAspectScoresDetailed(aspects)
 	
 	// 4. 获取所有影响因子
-	factorResult := CalculateInfluenceFactors(chart, t, transitPositions)
+	factorResult := cfg.InfluenceFactors(chart, t, transitPositions)
 	
 	// 5. 过滤可见因子
 	visibleLevels := GetVisibleTimeLevels(granularity)
@@ -187,6 +193,7 @@ func CalculateScoreBreakdown(chart *models.NatalChart, t time.Time, granularity
 				Dimension:   string(f.SourcePlanet),
 				Description: f.Description,
 				IsPositive:  f.IsPositive,
+				Phase:       f.Phase,
 			}
 			
 			levelStr := string(f.TimeLevel)
@@ -203,7 +210,7 @@ func CalculateScoreBreakdown(chart *models.NatalChart, t time.Time, granularity
 	
 	// 7. 添加自定义因子
 	if userID != "" {
-		customFactors := GetActiveCustomFactors(userID, t)
+		customFactors := cfg.ActiveCustomFactors(userID, t)
 		for _, cf := range customFactors {
 			lifecycle := &models.FactorLifecycle{
 				StartTime: cf.StartTime,
@@ -319,7 +326,7 @@ func calculateDimensionAspectScoresDetailed(aspects []models.AspectData) map[str
 		natalPlanet := aspect.Planet2
 		
 		// 计算相位分值
-		aspectValue := getUnifiedAspectValue(string(aspect.AspectType), aspect.Orb)
+		aspectValue := getUnifiedAspectValue(aspect)
 		
 		// 根据行星-维度权重分配分值
 		for _, d := range dimensions {
@@ -379,9 +386,10 @@ func calculateFactorDimensionContribution(fc FactorContribution, dimension strin
 func GetMultiGranularityBreakdown(chart *models.NatalChart, t time.Time, userID string) map[string]ScoreBreakdownResponse {
 	granularities := []string{"hour", "day", "month", "year"}
 	result := make(map[string]ScoreBreakdownResponse)
+	cfg := CurrentScoringConfig() // 各粒度使用同一份配置快照
 	
 	for _, g := range granularities {
-		result[g] = CalculateScoreBreakdown(chart, t, g, userID)
+		result[g] = scoreBreakdown(cfg, chart, t, g, userID)
 	}
 	
 	return result
@@ -437,10 +445,11 @@ func GetActiveFactorsInRange(chart *models.NatalChart, t time.Time, granularity
 	
 	// 4. 收集所有采样点的因子
 	factorMap := make(map[string]*ActiveFactorInfo)
+	cfg := CurrentScoringConfig()
 	
 	for _, sampleTime := range samplePoints {
-		transitPositions := GetTransitPositions(sampleTime)
-		factorResult := CalculateInfluenceFactors(chart, sampleTime, transitPositions)
+		transitPositions := GetTransitPositionsForChart(chart, sampleTime)
+		factorResult := cfg.InfluenceFactors(chart, sampleTime, transitPositions)
 		
 		if factorResult == nil {
 			continue
@@ -457,7 +466,7 @@ func GetActiveFactorsInRange(chart *models.NatalChart, t time.Time, granularity
 			// 计算当前强度
 			strength := 1.0
 			if f.Lifecycle != nil {
-				strength = CalculateFactorStrength(f.Lifecycle, sampleTime)
+				strength = CalculateFactorStrengthWithPeak(f.Lifecycle, sampleTime)
 			}
 			
 			if existing, ok := factorMap[key]; ok {
@@ -498,7 +507,7 @@ func GetActiveFactorsInRange(chart *models.NatalChart, t time.Time, granularity
 	
 	// 4. 添加自定义因子
 	if userID != "" {
-		customFactors := GetActiveCustomFactorsInRange(userID, rangeStart, rangeEnd)
+		customFactors := activeCustomFactorsInRange(cfg, userID, rangeStart, rangeEnd)
 		for _, cf := range customFactors {
 			key := "custom_" + cf.ID
 			if _, ok := factorMap[key]; !ok {
@@ -651,7 +660,12 @@ func generateSamplePoints(start, end time.Time, granularity string) []time.Time
 
 // GetActiveCustomFactorsInRange 获取时间范围内活跃的自定义因子
 func GetActiveCustomFactorsInRange(userID string, start, end time.Time) []CustomFactorDefinition {
-	factors := GetAllCustomFactors(userID)
+	return activeCustomFactorsInRange(CurrentScoringConfig(), userID, start, end)
+}
+
+// activeCustomFactorsInRange 获取快照中时间范围内活跃的自定义因子
+func activeCustomFactorsInRange(cfg *ScoringConfig, userID string, start, end time.Time) []CustomFactorDefinition {
+	factors := cfg.CustomFactors(userID)
 	var active []CustomFactorDefinition
 	
 	for _, f := range factors {
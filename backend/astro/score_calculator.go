@@ -1,6 +1,7 @@
 package astro
 
 import (
+	"fmt"
 	"math"
 	"star/models"
 	"time"
@@ -29,20 +30,25 @@ type ScoreResult struct {
 
 // CalculateScoresV2 计算某时刻的完整分数（新版）
 func CalculateScoresV2(chart *models.NatalChart, date time.Time) *ScoreResult {
+	return CurrentScoringConfig().Scores(chart, date)
+}
+
+// Scores 按此配置快照计算某时刻的完整分数
+func (cfg *ScoringConfig) Scores(chart *models.NatalChart, date time.Time) *ScoreResult {
 	// 1. 计算本命盘基础分
 	baseScores := CalculateNatalBaseScores(chart)
 
 	// 2. 获取行运位置
-	transitPositions := GetTransitPositions(date)
+	transitPositions := GetTransitPositionsForChart(chart, date)
 
 	// 3. 计算所有影响因子（新版）
-	factors := CalculateInfluenceFactorsV2(chart, date, transitPositions)
+	factors := cfg.InfluenceFactors(chart, date, transitPositions)
 
 	// 4. 根据因子计算维度分数
 	dimensions := calculateDimensionScoresFromFactors(baseScores, factors)
 
 	// 5. 根据维度分数计算综合分数
-	overall := calculateOverallFromDimensions(dimensions)
+	overall := calculateOverallFromDimensions(dimensions, cfg.DimensionWeights)
 
 	return &ScoreResult{
 		Overall:    overall,
@@ -84,8 +90,7 @@ func calculateDimensionScoresFromFactors(baseScores models.NatalBaseScores, fact
 }
 
 // calculateOverallFromDimensions 根据维度分数计算综合分数
-func calculateOverallFromDimensions(dimensions models.DimensionScoresV2) float64 {
-	weights := DefaultDimensionWeights
+func calculateOverallFromDimensions(dimensions models.DimensionScoresV2, weights models.DimensionWeights) float64 {
 
 	overall := dimensions.Career*weights.Career +
 		dimensions.Relationship*weights.Relationship +
@@ -126,11 +131,16 @@ func NormalizeScoreV2(raw float64) float64 {
 
 // CalculateInfluenceFactorsV2 计算影响因子（新版）
 func CalculateInfluenceFactorsV2(chart *models.NatalChart, date time.Time, transitPositions []models.PlanetPosition) *models.FactorResult {
-	weights := DefaultFactorWeights
+	return CurrentScoringConfig().InfluenceFactors(chart, date, transitPositions)
+}
+
+// InfluenceFactors 按此配置快照的因子权重计算影响因子
+func (cfg *ScoringConfig) InfluenceFactors(chart *models.NatalChart, date time.Time, transitPositions []models.PlanetPosition) *models.FactorResult {
+	weights := cfg.FactorWeights
 	var factors []models.InfluenceFactor
 
 	// 1. 尊贵度因子
-	dignityFactors := calculateDignityFactorsV2(transitPositions, weights.Dignity)
+	dignityFactors := calculateDignityFactorsV2(transitPositions, chart.BirthData.Terms, weights.Dignity)
 	factors = append(factors, dignityFactors...)
 
 	// 2. 逆行因子
@@ -155,9 +165,33 @@ func CalculateInfluenceFactorsV2(chart *models.NatalChart, date time.Time, trans
 
 	// 7. 月亮空亡因子
 	jd := DateToJulianDay(date)
-	vocFactors := calculateVoidOfCourseFactorsV2(jd, weights.VoidOfCourse, date)
+	vocFactors := calculateVoidOfCourseFactorsV2(chart, jd, weights.VoidOfCourse, date)
 	factors = append(factors, vocFactors...)
 
+	// 8. 赤纬平行因子
+	parallelFactors := calculateParallelFactorsV2(chart, transitPositions, weights.Parallel, date)
+	factors = append(factors, parallelFactors...)
+
+	// 9. 出界因子
+	outOfBoundsFactors := calculateOutOfBoundsFactorsV2(transitPositions, weights.OutOfBounds, jd)
+	factors = append(factors, outOfBoundsFactors...)
+
+	// 10. 换座因子
+	ingressFactors := calculateIngressFactorsV2(chart, weights.Ingress, date)
+	factors = append(factors, ingressFactors...)
+
+	// 11. 日月食因子
+	eclipseFactors := calculateEclipseFactorsV2(chart, weights.Eclipse, date)
+	factors = append(factors, eclipseFactors...)
+
+	// 12. 恒星因子
+	fixedStarFactors := calculateFixedStarFactorsV2(chart, weights.FixedStar, date)
+	factors = append(factors, fixedStarFactors...)
+
+	// 13. 后天尊贵因子
+	accidentalFactors := calculateAccidentalFactorsV2(chart, transitPositions, weights.Accidental, date)
+	factors = append(factors, accidentalFactors...)
+
 	// 构建结果
 	return buildFactorResult(factors, date)
 }
@@ -189,7 +223,7 @@ func buildFactorResult(factors []models.InfluenceFactor, date time.Time) *models
 		}
 
 		// 计算当前强度（根据生命周期）
-		factor.CurrentStrength = CalculateFactorStrength(factor.Lifecycle, date)
+		factor.CurrentStrength = CalculateFactorStrengthWithPeak(factor.Lifecycle, date)
 
 		// 计算最终调整值
 		factor.Adjustment = factor.BaseValue * factor.Weight * factor.CurrentStrength
@@ -263,45 +297,66 @@ func itoa(i int) string {
 // ==================== 各类因子计算（新版） ====================
 
 // calculateDignityFactorsV2 计算尊贵度因子（新版）
-func calculateDignityFactorsV2(transitPositions []models.PlanetPosition, weight float64) []models.InfluenceFactor {
+// 基础值为先天尊贵分合计（入庙、旺相、三分性、界、面、落陷、失势、游离）相对该行星平均分的差值，按 dignityPointScale 换算
+func calculateDignityFactorsV2(transitPositions []models.PlanetPosition, terms models.TermSystem, weight float64) []models.InfluenceFactor {
 	var factors []models.InfluenceFactor
 
 	for _, p := range transitPositions {
-		dignity := GetDignity(p.ID, p.Sign)
-		score := GetDignityScore(dignity)
+		if _, ok := DignityTable[p.ID]; !ok {
+			continue
+		}
+		dignity := EssentialDignities(p.ID, p.Longitude, "", terms)
+		if len(dignity.Dignities) == 0 {
+			continue
+		}
+		// 以行星的平均尊贵分为零点，避免游离使每日评分整体偏负
+		score := dignityValue(TransitDignityScore(dignity, terms))
 
 		if score == 0 {
 			continue
 		}
 
 		var name, description, reason string
-		var isPositive bool
 
 		planetInfo := GetPlanetInfo(p.ID)
 		zodiacInfo := GetZodiacInfo(p.Sign)
 
-		switch dignity {
+		// 以最主要的一项尊贵或无力命名
+		switch dignity.Dignities[0] {
 		case models.DignityDomicile:
 			name = planetInfo.Name + " in Domicile"
 			description = planetInfo.Name + " in " + zodiacInfo.Name + " - domicile position, powerful energy"
 			reason = "Planet in its ruling sign, most natural and powerful expression"
-			isPositive = true
 		case models.DignityExaltation:
 			name = planetInfo.Name + " Exalted"
 			description = planetInfo.Name + " in " + zodiacInfo.Name + " - exalted position, enhanced energy"
 			reason = "Planet in the sign that elevates its energy"
-			isPositive = true
 		case models.DignityDetriment:
 			name = planetInfo.Name + " in Detriment"
 			description = planetInfo.Name + " in " + zodiacInfo.Name + " - detriment position, restricted energy"
 			reason = "Planet in opposite sign, expression is hindered"
-			isPositive = false
 		case models.DignityFall:
 			name = planetInfo.Name + " in Fall"
 			description = planetInfo.Name + " in " + zodiacInfo.Name + " - fall position, requires extra effort"
 			reason = "Planet in opposite of exaltation, weakest energy"
-			isPositive = false
+		case models.DignityTriplicity:
+			name = planetInfo.Name + " in Own Triplicity"
+			description = planetInfo.Name + " in " + zodiacInfo.Name + " - rules the element, steady support"
+			reason = "Triplicity rulers share the governance of an element and give a planet reliable backing"
+		case models.DignityTerm:
+			name = planetInfo.Name + " in Own Term"
+			description = planetInfo.Name + " in " + zodiacInfo.Name + " - in its own bounds, modest strength"
+			reason = "Terms divide each sign into five unequal bounds; a planet in its own bound has a say in the outcome"
+		case models.DignityFace:
+			name = planetInfo.Name + " in Own Face"
+			description = planetInfo.Name + " in " + zodiacInfo.Name + " - in its own decan, minor dignity"
+			reason = "Faces (decans) are the smallest dignity, keeping a planet from being entirely without resources"
+		case models.DignityPeregrine:
+			name = planetInfo.Name + " Peregrine"
+			description = planetInfo.Name + " in " + zodiacInfo.Name + " - peregrine, without essential dignity"
+			reason = "A planet with no essential dignity wanders without support and acts unreliably"
 		}
+		description += fmt.Sprintf(" (dignity score %+.0f, %+.1f against its average)", dignity.Score, score/dignityPointScale)
 
 		factors = append(factors, models.InfluenceFactor{
 			Type:            models.FactorDignity,
@@ -312,7 +367,7 @@ func calculateDignityFactorsV2(transitPositions []models.PlanetPosition, weight
 			Weight:          weight,
 			DimensionImpact: GetPlanetDimensionImpact(p.ID),
 			SourcePlanet:    p.ID,
-			IsPositive:      isPositive,
+			IsPositive:      score > 0,
 			AstroReason:     reason,
 		})
 	}
@@ -321,11 +376,18 @@ func calculateDignityFactorsV2(transitPositions []models.PlanetPosition, weight
 }
 
 // calculateRetrogradeFactorsV2 计算逆行因子（新版）
+// 生命周期取自真实的留与影区日期，峰值位于留：前影区逐渐增强至留逆，逆行中段最弱，再增强至留顺后于后影区消退
 func calculateRetrogradeFactorsV2(transitPositions []models.PlanetPosition, weight float64, date time.Time) []models.InfluenceFactor {
 	var factors []models.InfluenceFactor
 
+	jd := DateToJulianDay(date)
 	for _, p := range transitPositions {
-		if !p.Retrograde {
+		if !canRetrograde(p.ID) {
+			continue
+		}
+
+		cycle := RetrogradeCycleAt(p.ID, jd)
+		if cycle == nil && !p.Retrograde {
 			continue
 		}
 
@@ -352,16 +414,68 @@ func calculateRetrogradeFactorsV2(transitPositions []models.PlanetPosition, weig
 			reason = "Trans-Saturnian planet retrograde nearly half year, normal phenomenon, milder impact"
 		}
 
-		// 创建生命周期
-		durationDays := GetRetrogradeDuration(p.ID)
-		lifecycle := CreateLifecycle(date.AddDate(0, 0, -int(durationDays/2)), durationDays*24)
+		name := planetInfo.Name + " Retrograde"
+		description := planetInfo.Name + " is retrograde, related areas may need review and adjustment"
+
+		// 未能求出留（如星历范围外）时退回典型逆行时长
+		if cycle == nil {
+			durationDays := GetRetrogradeDuration(p.ID)
+			lifecycle := CreateLifecycle(date.AddDate(0, 0, -int(durationDays/2)), durationDays*24)
+			factors = append(factors, models.InfluenceFactor{
+				Type:            models.FactorRetrograde,
+				Name:            name,
+				Description:     description,
+				TimeLevel:       models.TimeLevelWeekly,
+				Lifecycle:       lifecycle,
+				Phase:           RetrogradePhaseRetrograde,
+				BaseValue:       value,
+				Weight:          weight,
+				DimensionImpact: GetPlanetDimensionImpact(p.ID),
+				SourcePlanet:    p.ID,
+				IsPositive:      false,
+				AstroReason:     reason,
+			})
+			continue
+		}
+
+		// 影区影响减半
+		fullValue := value
+		phase := cycle.Phase(date)
+		switch phase {
+		case RetrogradePhasePreShadow:
+			value *= 0.5
+			name = planetInfo.Name + " Pre-Retrograde Shadow"
+			description = planetInfo.Name + " is in its pre-retrograde shadow, stationing retrograde on " +
+				cycle.StationRetrograde.Time.Format("2006-01-02") + "; themes that will be revisited are emerging"
+		case RetrogradePhasePostShadow:
+			value *= 0.5
+			name = planetInfo.Name + " Post-Retrograde Shadow"
+			description = planetInfo.Name + " is in its post-retrograde shadow until " +
+				cycle.ShadowEnd.Format("2006-01-02") + "; matters reviewed during retrograde are being resolved"
+		default:
+			description = planetInfo.Name + " is retrograde until " + cycle.StationDirect.Time.Format("2006-01-02") +
+				", related areas may need review and adjustment"
+		}
+
+		// 留是逆行周期中最强烈的时刻
+		if station := cycle.NearStation(date); station != nil {
+			value = fullValue * 1.5
+			if station.Type == StationRetrograde {
+				name = planetInfo.Name + " Stationary Retrograde"
+			} else {
+				name = planetInfo.Name + " Stationary Direct"
+			}
+			description = planetInfo.Name + " is stationary (" + string(station.Type) + " on " +
+				station.Time.Format("2006-01-02 15:04") + " UTC), its influence is at peak intensity"
+		}
 
 		factors = append(factors, models.InfluenceFactor{
 			Type:            models.FactorRetrograde,
-			Name:            planetInfo.Name + " Retrograde",
-			Description:     planetInfo.Name + " is retrograde, related areas may need review and adjustment",
+			Name:            name,
+			Description:     description,
 			TimeLevel:       models.TimeLevelWeekly,
-			Lifecycle:       lifecycle,
+			Lifecycle:       cycle.Lifecycle(date),
+			Phase:           phase,
 			BaseValue:       value,
 			Weight:          weight,
 			DimensionImpact: GetPlanetDimensionImpact(p.ID),
@@ -378,7 +492,8 @@ func calculateRetrogradeFactorsV2(transitPositions []models.PlanetPosition, weig
 func calculateAspectFactorsV2(chart *models.NatalChart, transitPositions []models.PlanetPosition, weight float64, date time.Time) []models.InfluenceFactor {
 	var factors []models.InfluenceFactor
 
-	aspects := CalculateTransitToNatalAspects(transitPositions, chart.Planets)
+	natalPoints := NatalPoints(chart)
+	aspects := CalculateTransitToNatalAspects(transitPositions, natalPoints)
 
 	for _, asp := range aspects {
 		if asp.Strength < 0.5 {
@@ -396,19 +511,24 @@ func calculateAspectFactorsV2(chart *models.NatalChart, transitPositions []model
 			baseValue = -baseValue * 0.7
 		}
 
-		// 入相/离相调整
+		// 入相/离相调整：入相能量仍在累积，离相逐渐消退
+		phase := "applying"
 		if !asp.Applying {
 			baseValue *= 0.8
+			phase = "separating"
 		}
 
-		// 容许度强度
-		baseValue *= asp.Strength
-
-		// 创建生命周期（基于容许度）
-		lifecycle := CalculateAspectLifecycle(asp.Orb, date, asp.Applying)
+		// 生命周期以精确成相时刻为峰值，当前强度（容许度远近）由生命周期曲线给出
+		exactTime := date.Add(time.Duration(asp.TimeToExact * float64(time.Hour)))
+		var speed float64
+		if transit := findPosition(transitPositions, asp.Planet1); transit != nil {
+			speed = transit.Speed
+		}
+		lifecycle := CalculateAspectLifecycle(asp.AllowedOrb, speed, exactTime)
 
-		transitInfo := GetPlanetInfo(asp.Planet1)
-		natalInfo := GetPlanetInfo(asp.Planet2)
+		// 名称取自位置本身：已从点目录删除的阿拉伯点仍保留本命盘计算时的名称
+		transitName := pointName(transitPositions, asp.Planet1)
+		natalName := pointName(natalPoints, asp.Planet2)
 
 		// 合并两颗行星的维度影响
 		transitImpact := GetPlanetDimensionImpact(asp.Planet1)
@@ -431,16 +551,17 @@ func calculateAspectFactorsV2(chart *models.NatalChart, transitPositions []model
 
 		factors = append(factors, models.InfluenceFactor{
 			Type:            models.FactorAspectPhase,
-			Name:            transitInfo.Name + " " + aspectDef.Name + " " + natalInfo.Name,
-			Description:     asp.Interpretation,
+			Name:            transitName + " " + aspectDef.Name + " " + natalName,
+			Description:     asp.Interpretation + " (" + describeAspectTiming(asp) + ")",
 			TimeLevel:       models.TimeLevelDaily,
 			Lifecycle:       lifecycle,
+			Phase:           phase,
 			BaseValue:       baseValue,
 			Weight:          weight,
 			DimensionImpact: combinedImpact,
 			SourcePlanet:    asp.Planet1,
 			IsPositive:      isPositive,
-			AstroReason:     "Transit " + transitInfo.Name + " forms " + aspectDef.Name + " with natal " + natalInfo.Name,
+			AstroReason:     "Transit " + transitName + " forms " + aspectDef.Name + " with natal " + natalName,
 		})
 	}
 
@@ -451,18 +572,9 @@ func calculateAspectFactorsV2(chart *models.NatalChart, transitPositions []model
 func calculateLunarPhaseFactorsV2(transitPositions []models.PlanetPosition, weight float64, date time.Time) []models.InfluenceFactor {
 	var factors []models.InfluenceFactor
 
-	var sunLon, moonLon float64
-	for _, p := range transitPositions {
-		if p.ID == models.Sun {
-			sunLon = p.Longitude
-		}
-		if p.ID == models.Moon {
-			moonLon = p.Longitude
-		}
-	}
-
-	angle := NormalizeAngle(moonLon - sunLon)
-	phaseInfo := GetLunarPhase(angle)
+	// 月相区段的起止取自真实的距角边界（主要月相起点即精确朔望时刻）
+	span := LunarPhaseSpanAt(DateToJulianDay(date))
+	phaseInfo := span.Phase
 
 	phaseValues := map[string]float64{
 		"new":          1.0,
@@ -477,8 +589,12 @@ func calculateLunarPhaseFactorsV2(transitPositions []models.PlanetPosition, weig
 
 	value := phaseValues[phaseInfo.Phase]
 
-	// 月相周期约3.5天
-	lifecycle := CreateLifecycle(date.AddDate(0, 0, -1), 3.5*24)
+	lifecycle := span.Lifecycle()
+
+	description := "Current lunar phase: " + phaseInfo.Name + ", " + phaseInfo.Keywords[0]
+	if span.Principal() {
+		description += ", exact at " + span.Start.Format("2006-01-02 15:04") + " UTC"
+	}
 
 	// 月相主要影响情感和健康
 	moonImpact := GetPlanetDimensionImpact(models.Moon)
@@ -486,7 +602,7 @@ func calculateLunarPhaseFactorsV2(transitPositions []models.PlanetPosition, weig
 	factors = append(factors, models.InfluenceFactor{
 		Type:            models.FactorLunarPhase,
 		Name:            phaseInfo.Name,
-		Description:     "Current lunar phase: " + phaseInfo.Name + ", " + phaseInfo.Keywords[0],
+		Description:     description,
 		TimeLevel:       models.TimeLevelDaily,
 		Lifecycle:       lifecycle,
 		BaseValue:       value,
@@ -504,12 +620,8 @@ func calculateLunarPhaseFactorsV2(transitPositions []models.PlanetPosition, weig
 func calculatePlanetaryHourFactorsV2(chart *models.NatalChart, date time.Time, weight float64) []models.InfluenceFactor {
 	var factors []models.InfluenceFactor
 
-	lat, lon := 0.0, 0.0
-	if chart.BirthData.Latitude != 0 {
-		lat = chart.BirthData.Latitude
-		lon = chart.BirthData.Longitude
-	}
-
+	// 行星时取决于当前所在地的日出日落
+	lat, lon := ObserverLocation(chart)
 	hourInfo := CalculatePlanetaryHourEnhanced(date, lat, lon)
 	hourRulerInfo := GetPlanetInfo(hourInfo.Ruler)
 	dayRulerInfo := GetPlanetInfo(hourInfo.DayRuler)
@@ -524,8 +636,8 @@ func calculatePlanetaryHourFactorsV2(chart *models.NatalChart, date time.Time, w
 		value += 1.0
 	}
 
-	// 行星时持续约1-1.5小时
-	lifecycle := CreateLifecycle(date.Add(-30*time.Minute), 1.5)
+	// 生命周期即该行星时的真实起止（日间/夜间不等长）
+	lifecycle := CreateLifecycleWithPeak(hourInfo.Start, hourInfo.Start.Add(hourInfo.End.Sub(hourInfo.Start)/2), hourInfo.End)
 
 	factors = append(factors, models.InfluenceFactor{
 		Type:            models.FactorPlanetaryHour,
@@ -569,8 +681,7 @@ func calculateProfectionLordFactorsV2(chart *models.NatalChart, date time.Time,
 	value := 0.0
 
 	// 尊贵度影响
-	dignity := GetDignity(lordTransit.ID, lordTransit.Sign)
-	value += GetDignityScore(dignity)
+	value += dignityValue(TransitDignityScore(EssentialDignities(lordTransit.ID, lordTransit.Longitude, "", chart.BirthData.Terms), chart.BirthData.Terms))
 
 	// 逆行影响
 	if lordTransit.Retrograde {
@@ -582,31 +693,8 @@ func calculateProfectionLordFactorsV2(chart *models.NatalChart, date time.Time,
 
 	// 年主星影响对应宫位的维度
 	houseDimension := GetDimensionForHouseV2(profection.House)
-	impact := GetPlanetDimensionImpact(profection.LordOfYear)
-
 	// 增强对应宫位维度的影响
-	switch houseDimension {
-	case "career":
-		impact.Career += 0.2
-	case "relationship":
-		impact.Relationship += 0.2
-	case "health":
-		impact.Health += 0.2
-	case "finance":
-		impact.Finance += 0.2
-	case "spiritual":
-		impact.Spiritual += 0.2
-	}
-
-	// 归一化
-	total := impact.Career + impact.Relationship + impact.Health + impact.Finance + impact.Spiritual
-	if total > 0 {
-		impact.Career /= total
-		impact.Relationship /= total
-		impact.Health /= total
-		impact.Finance /= total
-		impact.Spiritual /= total
-	}
+	impact := EmphasizeDimension(GetPlanetDimensionImpact(profection.LordOfYear), houseDimension, 0.2)
 
 	factors = append(factors, models.InfluenceFactor{
 		Type:            models.FactorProfectionLord,
@@ -625,20 +713,27 @@ func calculateProfectionLordFactorsV2(chart *models.NatalChart, date time.Time,
 	return factors
 }
 
-// calculateVoidOfCourseFactorsV2 计算月亮空亡因子（新版）
-func calculateVoidOfCourseFactorsV2(jd float64, weight float64, date time.Time) []models.InfluenceFactor {
+// calculateVoidOfCourseFactorsV2 计算月亮空亡因子（新版），月亮换座按本命盘所用黄道
+func calculateVoidOfCourseFactorsV2(chart *models.NatalChart, jd float64, weight float64, date time.Time) []models.InfluenceFactor {
 	var factors []models.InfluenceFactor
 
-	vocInfo := CalculateVoidOfCourse(jd, nil)
+	vocInfo := CalculateVoidOfCourse(jd, chart.Zodiac, chart.Ayanamsa)
 
 	if vocInfo.IsVoid {
-		// 创建生命周期
-		lifecycle := CreateLifecycle(date, vocInfo.Duration)
+		// 生命周期覆盖真实的空亡窗口：最后相位精确时刻至月亮换座
+		window := VoidOfCourseWindowAt(jd, chart.Zodiac, chart.Ayanamsa)
+		lifecycle := CreateLifecycleWithPeak(window.Start, window.Start.Add(window.End.Sub(window.Start)/2), window.End)
+
+		description := "Moon is void of course from " + window.Start.Format("Jan 2 15:04") + " to " + window.End.Format("Jan 2 15:04") + " UTC (" + formatDuration(vocInfo.Duration) + ") before entering " + vocInfo.NextSign
+		if vocInfo.LastAspect != "" {
+			description += ", after its last aspect " + vocInfo.LastAspect
+		}
+		description += ". Not ideal for starting new matters"
 
 		factors = append(factors, models.InfluenceFactor{
 			Type:            models.FactorVoidOfCourse,
 			Name:            "Moon Void of Course",
-			Description:     "Moon is void of course, lasting " + formatDuration(vocInfo.Duration) + " before entering " + vocInfo.NextSign + ". Not ideal for starting new matters",
+			Description:     description,
 			TimeLevel:       models.TimeLevelHourly,
 			Lifecycle:       lifecycle,
 			BaseValue:       vocInfo.Influence,
@@ -653,6 +748,133 @@ func calculateVoidOfCourseFactorsV2(jd float64, weight float64, date time.Time)
 	return factors
 }
 
+// calculateParallelFactorsV2 计算行运与本命的赤纬平行/反平行因子
+func calculateParallelFactorsV2(chart *models.NatalChart, transitPositions []models.PlanetPosition, weight float64, date time.Time) []models.InfluenceFactor {
+	var factors []models.InfluenceFactor
+
+	parallels := CalculateTransitParallels(transitPositions, chart.Planets)
+
+	for _, asp := range parallels {
+		if asp.Strength < 0.5 {
+			continue
+		}
+
+		def := GetAspectDefinition(asp.AspectType)
+		if def == nil {
+			continue
+		}
+
+		// 平行如合相，性质取决于行星；反平行如对分相，偏紧张
+		baseValue := asp.Weight
+		if def.Nature == "tense" || (def.Nature == "neutral" && isMalefic(asp.Planet1, asp.Planet2)) {
+			baseValue = -baseValue * 0.7
+		}
+
+		phase := "applying"
+		if !asp.Applying {
+			baseValue *= 0.8
+			phase = "separating"
+		}
+
+		// 生命周期以赤纬精确相等的时刻为峰值
+		exactTime := date.Add(time.Duration(asp.TimeToExact * float64(time.Hour)))
+		var speed float64
+		if transit := findPosition(transitPositions, asp.Planet1); transit != nil {
+			speed = transit.DeclinationSpeed
+		}
+		lifecycle := CalculateAspectLifecycle(asp.AllowedOrb, speed, exactTime)
+
+		transitName := pointName(transitPositions, asp.Planet1)
+		natalName := pointName(chart.Planets, asp.Planet2)
+		transitImpact := GetPlanetDimensionImpact(asp.Planet1)
+		natalImpact := GetPlanetDimensionImpact(asp.Planet2)
+
+		factors = append(factors, models.InfluenceFactor{
+			Type:        models.FactorParallel,
+			Name:        transitName + " " + def.Name + " " + natalName,
+			Description: asp.Interpretation + " (" + describeAspectTiming(asp) + ")",
+			TimeLevel:   models.TimeLevelDaily,
+			Lifecycle:   lifecycle,
+			Phase:       phase,
+			BaseValue:   baseValue,
+			Weight:      weight,
+			DimensionImpact: models.DimensionImpact{
+				Career:       (transitImpact.Career + natalImpact.Career) / 2,
+				Relationship: (transitImpact.Relationship + natalImpact.Relationship) / 2,
+				Health:       (transitImpact.Health + natalImpact.Health) / 2,
+				Finance:      (transitImpact.Finance + natalImpact.Finance) / 2,
+				Spiritual:    (transitImpact.Spiritual + natalImpact.Spiritual) / 2,
+			},
+			SourcePlanet: asp.Planet1,
+			IsPositive:   baseValue > 0,
+			AstroReason:  "Declination aspects link planets by their distance from the celestial equator; a " + def.Name + " acts like a " + declinationAnalogue(def.Type),
+		})
+	}
+
+	return factors
+}
+
+// declinationAnalogue 赤纬相位对应的黄经相位
+func declinationAnalogue(t models.AspectType) string {
+	if t == models.Contraparallel {
+		return "mild opposition"
+	}
+	return "mild conjunction"
+}
+
+// isMalefic 是否涉及凶星（火星、土星）
+func isMalefic(planets ...models.PlanetID) bool {
+	for _, p := range planets {
+		if p == models.Mars || p == models.Saturn {
+			return true
+		}
+	}
+	return false
+}
+
+// calculateOutOfBoundsFactorsV2 计算行运行星出界因子
+func calculateOutOfBoundsFactorsV2(transitPositions []models.PlanetPosition, weight float64, jd float64) []models.InfluenceFactor {
+	var factors []models.InfluenceFactor
+
+	for _, p := range transitPositions {
+		if !p.OutOfBounds {
+			continue
+		}
+		period := FindOutOfBoundsPeriod(p.ID, jd)
+		if period == nil {
+			continue
+		}
+
+		// 出界行星脱离常规约束：吉星表现为额外的机会，其余行星表现为难以预料的极端
+		value := -1.5
+		if p.ID == models.Venus || p.ID == models.Jupiter {
+			value = 1.0
+		}
+
+		level := models.TimeLevelWeekly
+		if p.ID == models.Moon {
+			level = models.TimeLevelDaily
+		}
+
+		planetInfo := GetPlanetInfo(p.ID)
+		factors = append(factors, models.InfluenceFactor{
+			Type:            models.FactorOutOfBounds,
+			Name:            planetInfo.Name + " Out of Bounds",
+			Description:     fmt.Sprintf("%s is out of bounds at declination %.2f° (until %s)", planetInfo.Name, p.Declination, period.End.Format("2006-01-02")),
+			TimeLevel:       level,
+			Lifecycle:       CreateLifecycleWithPeak(period.Start, period.Peak, period.End),
+			BaseValue:       value,
+			Weight:          weight,
+			DimensionImpact: GetPlanetDimensionImpact(p.ID),
+			SourcePlanet:    p.ID,
+			IsPositive:      value > 0,
+			AstroReason:     "A planet beyond the Sun's maximum declination operates outside conventional limits, expressing itself in unusual or extreme ways",
+		})
+	}
+
+	return factors
+}
+
 // formatDuration 格式化持续时间
 func formatDuration(hours float64) string {
 	if hours < 1 {
@@ -245,6 +245,18 @@ func getFactorCategory(factorType string) string {
 		return "Planetary Hour"
 	case "voidOfCourse":
 		return "Moon Void of Course"
+	case "parallel":
+		return "Declination Aspect"
+	case "outOfBounds":
+		return "Out of Bounds"
+	case "ingress":
+		return "Sign Ingress"
+	case "eclipse":
+		return "Eclipse"
+	case "fixedStar":
+		return "Fixed Star"
+	case "accidentalDignity":
+		return "Accidental Dignity"
 	case "custom":
 		return "Personal Factor"
 	default:
@@ -267,6 +279,18 @@ func getFactorIcon(f FactorContribution) string {
 		return "⏰"
 	case "voidOfCourse":
 		return "🌑"
+	case "parallel":
+		return "∥"
+	case "outOfBounds":
+		return "⇕"
+	case "ingress":
+		return "➜"
+	case "eclipse":
+		return "◐"
+	case "fixedStar":
+		return "★"
+	case "accidentalDignity":
+		return "⚖"
 	case "custom":
 		return "⚙️"
 	default:
@@ -317,14 +341,55 @@ func getFactorDescription(f FactorContribution) string {
 	case "retrograde":
 		return "During planetary retrograde, related areas need review and reflection"
 	case "aspectPhase":
+		description := "Planets form tense angle, bringing challenges"
 		if f.IsPositive {
-			return "Planets form harmonious angle, bringing positive energy"
+			description = "Planets form harmonious angle, bringing positive energy"
 		}
-		return "Planets form tense angle, bringing challenges"
+		switch f.Phase {
+		case "applying":
+			description += "; the aspect is applying, so its influence is still building"
+		case "separating":
+			description += "; the aspect is separating, so its influence is fading"
+		}
+		return description
 	case "lunarPhase":
 		return getLunarPhaseDescription(f.Name)
 	case "planetaryHour":
 		return "Current planetary hour energy influence"
+	case "parallel":
+		description := "Planets share the same declination, quietly reinforcing each other"
+		if !f.IsPositive {
+			description = "Planets mirror each other's declination, adding subtle tension"
+		}
+		switch f.Phase {
+		case "applying":
+			description += "; the declinations are converging"
+		case "separating":
+			description += "; the declinations are drifting apart"
+		}
+		return description
+	case "outOfBounds":
+		return "Planet has moved beyond the Sun's path, acting in unconventional and unpredictable ways"
+	case "ingress":
+		if f.IsPositive {
+			return "Planet has entered a sign where it works well, opening a fresh chapter"
+		}
+		return "Planet has entered a sign where it struggles, shifting the focus toward adjustment"
+	case "eclipse":
+		if f.IsPositive {
+			return "An eclipse touches a benefic in your chart, opening an unexpected door in the months ahead"
+		}
+		return "An eclipse touches a sensitive point in your chart, bringing change and endings in the months ahead"
+	case "fixedStar":
+		if f.IsPositive {
+			return "A slow planet sits on a benefic fixed star, lending distinction and good fortune to the period"
+		}
+		return "A slow planet sits on a malefic fixed star, bringing a testing and sometimes extreme period"
+	case "accidentalDignity":
+		if f.IsPositive {
+			return "Planet is well placed in today's sky, strong and free to deliver its promise"
+		}
+		return "Planet is poorly placed in today's sky, hidden or slowed and unable to act fully"
 	case "custom":
 		return "Personal adjustment factor"
 	default:
@@ -357,11 +422,23 @@ func getAstroExplanation(f FactorContribution) string {
 	case "retrograde":
 		return "From Earth's perspective, planet appears to move backward, symbolizing introspection and reassessment"
 	case "aspectPhase":
-		return "Angular relationships between planets determine how energies interact"
+		return "Angular relationships between planets determine how energies interact; applying aspects (moving toward exact) are stronger than separating ones"
 	case "lunarPhase":
 		return "Lunar cycle influences mood, body rhythms, and daily affairs"
 	case "planetaryHour":
 		return "Classical astrology's planetary hour system, each period ruled by a different planet"
+	case "parallel":
+		return "Parallels (same declination, same side of the equator) act like conjunctions; contraparallels (opposite sides) act like oppositions"
+	case "ingress":
+		return "Each sign colours how a planet expresses itself; slow planets changing sign mark collective and personal turning points"
+	case "eclipse":
+		return "Eclipses are New and Full Moons near the lunar nodes; where they fall on a natal planet or angle they act as turning points for up to six months"
+	case "fixedStar":
+		return "Since Ptolemy each bright star carries the nature of one or two planets; a planet conjunct or parallel a star within a degree takes on that star's character"
+	case "accidentalDignity":
+		return "Accidental dignity measures a planet's condition in the sky rather than by sign: angularity, distance from the Sun, speed, orientation and sect"
+	case "outOfBounds":
+		return "A planet whose declination exceeds the obliquity of the ecliptic travels outside the Sun's boundaries and escapes its usual rules"
 	default:
 		return ""
 	}
@@ -0,0 +1,77 @@
+package astro
+
+import (
+	"star/models"
+	"sync"
+	"sync/atomic"
+)
+
+// ==================== 评分配置快照 ====================
+// 运营可调整的权重、抖动与自定义因子保存在不可变的版本化快照中。
+// 读取方在一次请求开始时调用 CurrentScoringConfig 获取快照并传递给整个计算过程，
+// 因此并发的管理接口更新不会让一次计算看到一半新、一半旧的配置。
+// 更新方复制当前快照、修改副本并原子替换（写操作之间由互斥锁串行化）
+
+// ScoringConfig 评分配置快照（发布后不可修改）
+type ScoringConfig struct {
+	Version          int64                   `json:"version"`
+	FactorWeights    models.FactorWeights    `json:"factorWeights"`
+	DimensionWeights models.DimensionWeights `json:"dimensionWeights"`
+	Jitter           JitterConfig            `json:"jitter"`
+
+	customFactors map[string][]CustomFactorDefinition // userID -> factors
+}
+
+var (
+	scoringConfig   atomic.Pointer[ScoringConfig]
+	scoringConfigMu sync.Mutex // 串行化写操作
+)
+
+func init() {
+	scoringConfig.Store(&ScoringConfig{
+		Version:          1,
+		FactorWeights:    DefaultFactorWeights,
+		DimensionWeights: DefaultDimensionWeights,
+		Jitter:           DefaultJitterConfig,
+		customFactors:    make(map[string][]CustomFactorDefinition),
+	})
+}
+
+// CurrentScoringConfig 获取当前配置快照
+// 一次请求内应只调用一次，并把快照传递给后续计算
+func CurrentScoringConfig() *ScoringConfig {
+	return scoringConfig.Load()
+}
+
+// updateScoringConfig 复制当前快照、应用修改并发布新版本
+func updateScoringConfig(mutate func(cfg *ScoringConfig)) *ScoringConfig {
+	scoringConfigMu.Lock()
+	defer scoringConfigMu.Unlock()
+
+	current := scoringConfig.Load()
+	next := *current
+	next.customFactors = make(map[string][]CustomFactorDefinition, len(current.customFactors))
+	for userID, factors := range current.customFactors {
+		next.customFactors[userID] = factors
+	}
+	mutate(&next)
+	next.Version = current.Version + 1
+
+	scoringConfig.Store(&next)
+	return &next
+}
+
+// CustomFactors 返回快照中用户的所有自定义因子
+func (cfg *ScoringConfig) CustomFactors(userID string) []CustomFactorDefinition {
+	return cfg.customFactors[userID]
+}
+
+// ResetScoringConfig 恢复默认权重与抖动配置并清空自定义因子
+func ResetScoringConfig() *ScoringConfig {
+	return updateScoringConfig(func(cfg *ScoringConfig) {
+		cfg.FactorWeights = DefaultFactorWeights
+		cfg.DimensionWeights = DefaultDimensionWeights
+		cfg.Jitter = DefaultJitterConfig
+		cfg.customFactors = make(map[string][]CustomFactorDefinition)
+	})
+}
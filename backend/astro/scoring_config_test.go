@@ -0,0 +1,107 @@
+package astro
+
+import (
+	"star/models"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestScoringConfigSnapshot 测试配置快照的不可变性与版本号
+func TestScoringConfigSnapshot(t *testing.T) {
+	t.Cleanup(func() { ResetScoringConfig() })
+
+	before := CurrentScoringConfig()
+	weights := before.FactorWeights
+	weights.Dignity = 3
+
+	after := UpdateFactorWeights(weights)
+	if after.Version != before.Version+1 {
+		t.Errorf("更新后版本号应加一: %d -> %d", before.Version, after.Version)
+	}
+	if before.FactorWeights.Dignity != DefaultFactorWeights.Dignity {
+		t.Errorf("已发布的快照不应被修改")
+	}
+	if CurrentScoringConfig().FactorWeights.Dignity != 3 {
+		t.Errorf("新快照应包含更新后的权重")
+	}
+
+	const userID = "snapshot_test_user"
+	if _, err := AddCustomFactor(userID, "AddScore=(10*career,2,202601051100)"); err != nil {
+		t.Fatalf("添加自定义因子失败: %v", err)
+	}
+	withFactor := CurrentScoringConfig()
+	ClearCustomFactors(userID)
+	if len(withFactor.CustomFactors(userID)) != 1 {
+		t.Errorf("清除自定义因子不应影响已取得的快照")
+	}
+	if len(GetAllCustomFactors(userID)) != 0 {
+		t.Errorf("当前快照中的自定义因子应已清除")
+	}
+}
+
+// TestScoringConfigConcurrency 并发计算与配置更新（配合 go test -race）
+func TestScoringConfigConcurrency(t *testing.T) {
+	useProvider(t, NewPreciseProvider())
+	t.Cleanup(func() { ResetScoringConfig() })
+
+	chart := CalculateNatalChart(models.BirthData{
+		Year: 1990, Month: 6, Day: 15, Hour: 14, Minute: 30,
+		Latitude: 39.9042, Longitude: 116.4074, Timezone: 8,
+	})
+	date := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+
+	// 管理端：不断切换权重、抖动与自定义因子
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			weights := DefaultFactorWeights
+			weights.AspectPhase = float64(i%5) * 0.5
+			UpdateFactorWeights(weights)
+			UpdateJitterConfig(JitterConfig{Enabled: i%2 == 0, Magnitude: 0.5})
+			AddCustomFactor("concurrency_test_user", "AddScore=(5*health,2,202503011100)")
+			if i%3 == 0 {
+				ClearCustomFactors("concurrency_test_user")
+			}
+		}
+	}()
+
+	// 计算端：同一快照上的两次计算必须完全一致
+	errs := make(chan string, 8)
+	var workers sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		workers.Add(1)
+		go func(w int) {
+			defer workers.Done()
+			for i := 0; i < 10; i++ {
+				cfg := CurrentScoringConfig()
+				at := date.Add(time.Duration(w*10+i) * time.Hour)
+				first := cfg.Scores(chart, at)
+				second := cfg.Scores(chart, at)
+				if first.Overall != second.Overall {
+					errs <- "同一快照的计算结果不一致"
+					return
+				}
+				unifiedHourlyScore(cfg, chart, at, "concurrency_test_user")
+			}
+		}(w)
+	}
+	workers.Wait()
+	close(stop)
+	wg.Wait()
+	close(errs)
+
+	for msg := range errs {
+		t.Error(msg)
+	}
+	t.Logf("最终配置版本: %d", CurrentScoringConfig().Version)
+}
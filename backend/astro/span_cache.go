@@ -0,0 +1,65 @@
+package astro
+
+import (
+	"sync"
+)
+
+// ==================== 区间缓存 ====================
+// 月相区段、空亡窗口、行星日、逆行周期、出界区间、恒星接触窗口与食相列表都是覆盖一段时间的求解结果，
+// 逐时评分时同一区间会被反复查询。spanCache 按键（星历源及天体、地点等）保存最近使用的若干区间，
+// 由调用方判断区间是否覆盖查询时刻；超过容量时淘汰最久未使用的区间，
+// 不同日期的并发请求各自保留区间而不会互相覆盖，任意输入也不会使缓存无限增长
+
+// spanCache 容量固定的 LRU 区间缓存
+type spanCache[K comparable, V any] struct {
+	mu      sync.Mutex
+	limit   int
+	entries []spanEntry[K, V] // 最近使用的在前
+}
+
+// spanEntry 一个缓存的区间
+type spanEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// newSpanCache 创建最多保存 limit 个区间的缓存
+func newSpanCache[K comparable, V any](limit int) *spanCache[K, V] {
+	return &spanCache[K, V]{limit: limit}
+}
+
+// get 查找键相同且 covers 为真的区间，命中时移到最前
+func (c *spanCache[K, V]) get(key K, covers func(V) bool) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, e := range c.entries {
+		if e.key != key || !covers(e.value) {
+			continue
+		}
+		copy(c.entries[1:i+1], c.entries[:i])
+		c.entries[0] = e
+		return e.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// put 保存区间，超过容量时淘汰最久未使用的区间
+func (c *spanCache[K, V]) put(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) < c.limit {
+		c.entries = append(c.entries, spanEntry[K, V]{})
+	}
+	copy(c.entries[1:], c.entries)
+	c.entries[0] = spanEntry[K, V]{key, value}
+}
+
+// len 当前缓存的区间数
+func (c *spanCache[K, V]) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
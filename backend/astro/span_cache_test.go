@@ -0,0 +1,35 @@
+package astro
+
+import (
+	"testing"
+)
+
+// TestSpanCache 测试区间缓存的覆盖查找与 LRU 淘汰
+func TestSpanCache(t *testing.T) {
+	type span struct{ from, to float64 }
+	cache := newSpanCache[string, span](2)
+	covers := func(jd float64) func(span) bool {
+		return func(s span) bool { return jd >= s.from && jd < s.to }
+	}
+
+	cache.put("swiss", span{0, 10})
+	cache.put("swiss", span{10, 20})
+	if s, ok := cache.get("swiss", covers(5)); !ok || s.from != 0 {
+		t.Fatalf("两个日期的区间应同时保留: %+v %v", s, ok)
+	}
+	if _, ok := cache.get("builtin", covers(5)); ok {
+		t.Error("不同星历源不应共享区间")
+	}
+
+	// 刚访问过 [0, 10)，写入第三个区间时淘汰 [10, 20)
+	cache.put("swiss", span{20, 30})
+	if _, ok := cache.get("swiss", covers(15)); ok {
+		t.Error("最久未使用的区间应被淘汰")
+	}
+	if _, ok := cache.get("swiss", covers(5)); !ok {
+		t.Error("最近使用的区间应保留")
+	}
+	if cache.len() != 2 {
+		t.Errorf("缓存不应超过容量，实际 %d", cache.len())
+	}
+}
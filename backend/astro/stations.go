@@ -0,0 +1,306 @@
+package astro
+
+import (
+	"math"
+	"sort"
+	"star/models"
+	"time"
+)
+
+// ==================== 留与逆行影区 ====================
+// 留（停滞）：黄经速度过零的时刻。留逆为顺行转逆行，留顺为逆行转顺行。
+// 前影区：行星顺行首次到达留顺度数起，至留逆为止（之后逆行将重走这段黄经）
+// 后影区：留顺起，至行星重新回到留逆度数为止
+
+// StationType 留的类型
+type StationType string
+
+const (
+	StationRetrograde StationType = "retrograde" // 留逆：顺行转逆行
+	StationDirect     StationType = "direct"     // 留顺：逆行转顺行
+)
+
+// Station 一次留的时刻与位置（回归黄道）
+type Station struct {
+	Planet     models.PlanetID `json:"planet"`
+	Type       StationType     `json:"type"`
+	Time       time.Time       `json:"time"`
+	Longitude  float64         `json:"longitude"`
+	Sign       models.ZodiacID `json:"sign"`
+	SignDegree float64         `json:"signDegree"`
+}
+
+// RetrogradeCycle 一次完整的逆行周期（前影区 → 逆行 → 后影区）
+type RetrogradeCycle struct {
+	Planet            models.PlanetID `json:"planet"`
+	ShadowStart       time.Time       `json:"shadowStart"` // 进入前影区
+	StationRetrograde Station         `json:"stationRetrograde"`
+	StationDirect     Station         `json:"stationDirect"`
+	ShadowEnd         time.Time       `json:"shadowEnd"` // 离开后影区
+}
+
+// 逆行周期阶段
+const (
+	RetrogradePhasePreShadow  = "preShadow"
+	RetrogradePhaseRetrograde = "retrograde"
+	RetrogradePhasePostShadow = "postShadow"
+)
+
+// RetrogradeBodies 会出现逆行的天体
+var RetrogradeBodies = []models.PlanetID{
+	models.Mercury, models.Venus, models.Mars,
+	models.Jupiter, models.Saturn, models.Uranus, models.Neptune, models.Pluto,
+	models.Chiron,
+}
+
+// stationSearchSteps 各天体搜索留的采样步长（天），小于其最短逆行期的一半
+var stationSearchSteps = map[models.PlanetID]float64{
+	models.Mercury: 2,
+	models.Venus:   4,
+	models.Mars:    4,
+	models.Jupiter: 8,
+	models.Saturn:  8,
+	models.Uranus:  8,
+	models.Neptune: 8,
+	models.Pluto:   8,
+	models.Chiron:  8,
+}
+
+// retrogradeCycleLead 逆行周期从进入前影区到离开后影区的最长跨度（天）
+// 前影区、逆行与后影区各约一个逆行期，另留一个月余量
+func retrogradeCycleLead(planet models.PlanetID) float64 {
+	return 3*GetRetrogradeDuration(planet) + 30
+}
+
+// maxRetrogradeDays 逆行期最长天数（用于为留逆配对留顺）
+const maxRetrogradeDays = 200.0
+
+// Phase 给定时刻所处的阶段，不在周期内时返回空字符串
+func (c RetrogradeCycle) Phase(t time.Time) string {
+	switch {
+	case t.Before(c.ShadowStart) || t.After(c.ShadowEnd):
+		return ""
+	case t.Before(c.StationRetrograde.Time):
+		return RetrogradePhasePreShadow
+	case !t.After(c.StationDirect.Time):
+		return RetrogradePhaseRetrograde
+	default:
+		return RetrogradePhasePostShadow
+	}
+}
+
+// canRetrograde 天体是否会逆行
+func canRetrograde(planet models.PlanetID) bool {
+	_, ok := stationSearchSteps[planet]
+	return ok
+}
+
+// FindStations 求 [start, end) 内各天体的留，按时间排序；planets 为空时使用 RetrogradeBodies
+func FindStations(start, end time.Time, planets ...models.PlanetID) []Station {
+	if len(planets) == 0 {
+		planets = RetrogradeBodies
+	}
+	provider := CurrentEphemerisProvider()
+	from, to := DateToJulianDay(start.UTC()), DateToJulianDay(end.UTC())
+
+	var stations []Station
+	for _, planet := range planets {
+		if !canRetrograde(planet) {
+			continue
+		}
+		stations = append(stations, findStations(provider, planet, from, to)...)
+	}
+	sort.Slice(stations, func(i, j int) bool {
+		return stations[i].Time.Before(stations[j].Time)
+	})
+	return stations
+}
+
+// findStations 在 [from, to) 内扫描黄经速度的变号并二分求出留的时刻
+func findStations(provider EphemerisProvider, planet models.PlanetID, from, to float64) []Station {
+	var stations []Station
+
+	step := stationSearchSteps[planet]
+	speed := func(x float64) float64 {
+		return planetPositionFrom(provider, planet, x).Speed
+	}
+
+	// 采样点对齐到以 J2000 为原点的固定网格，从不同起点搜索得到的留时刻完全一致（并发评分共享缓存时结果可复现）
+	start := J2000 + math.Floor((from-J2000)/step)*step
+	prevX, prevV := start, speed(start)
+	for prevX < to {
+		x := math.Min(prevX+step, to)
+		v := speed(x)
+		if (prevV < 0) != (v < 0) {
+			root := bisect(speed, prevX, x)
+			// 首个网格区间可能包含 from 之前的留
+			if root >= from && root < to {
+				stationType := StationDirect
+				if prevV >= 0 {
+					stationType = StationRetrograde
+				}
+				stations = append(stations, newStation(provider, planet, stationType, root))
+			}
+		}
+		prevX, prevV = x, v
+	}
+
+	return stations
+}
+
+// newStation 构造留的记录
+func newStation(provider EphemerisProvider, planet models.PlanetID, stationType StationType, jd float64) Station {
+	pos := planetPositionFrom(provider, planet, jd)
+	return Station{
+		Planet:     planet,
+		Type:       stationType,
+		Time:       julianDayToTime(jd),
+		Longitude:  pos.Longitude,
+		Sign:       pos.Sign,
+		SignDegree: pos.SignDegree,
+	}
+}
+
+// FindRetrogradeCycles 求与 [start, end) 有重叠的逆行周期（含前后影区）
+func FindRetrogradeCycles(start, end time.Time, planets ...models.PlanetID) []RetrogradeCycle {
+	if len(planets) == 0 {
+		planets = RetrogradeBodies
+	}
+	provider := CurrentEphemerisProvider()
+	from, to := DateToJulianDay(start.UTC()), DateToJulianDay(end.UTC())
+
+	var cycles []RetrogradeCycle
+	for _, planet := range planets {
+		if !canRetrograde(planet) {
+			continue
+		}
+		for _, cycle := range findRetrogradeCycles(provider, planet, from-retrogradeCycleLead(planet), to) {
+			if cycle.ShadowEnd.Before(start) || !cycle.ShadowStart.Before(end) {
+				continue
+			}
+			cycles = append(cycles, cycle)
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool {
+		return cycles[i].ShadowStart.Before(cycles[j].ShadowStart)
+	})
+	return cycles
+}
+
+// findRetrogradeCycles 留逆落在 [from, to) 内的逆行周期
+func findRetrogradeCycles(provider EphemerisProvider, planet models.PlanetID, from, to float64) []RetrogradeCycle {
+	var cycles []RetrogradeCycle
+
+	// 多搜索一段以确保区间末尾的留逆能配对到留顺
+	stations := findStations(provider, planet, from, to+maxRetrogradeDays)
+	step := stationSearchSteps[planet]
+	for i := 0; i+1 < len(stations); i++ {
+		sr, sd := stations[i], stations[i+1]
+		if sr.Type != StationRetrograde || sd.Type != StationDirect {
+			continue
+		}
+		srJD, sdJD := DateToJulianDay(sr.Time), DateToJulianDay(sd.Time)
+		if srJD >= to {
+			break
+		}
+		cycles = append(cycles, RetrogradeCycle{
+			Planet:            planet,
+			ShadowStart:       julianDayToTime(longitudeCrossing(provider, planet, srJD, sd.Longitude, -1, step)),
+			StationRetrograde: sr,
+			StationDirect:     sd,
+			ShadowEnd:         julianDayToTime(longitudeCrossing(provider, planet, sdJD, sr.Longitude, 1, step)),
+		})
+	}
+
+	return cycles
+}
+
+// longitudeCrossing 自 jd 起沿 dir 方向（±1）寻找天体黄经经过 target 的时刻
+func longitudeCrossing(provider EphemerisProvider, planet models.PlanetID, jd, target, dir, step float64) float64 {
+	offset := func(x float64) float64 {
+		return normalizeSigned(planetPositionFrom(provider, planet, x).Longitude - target)
+	}
+
+	horizon := retrogradeCycleLead(planet)
+	prev, prevOffset := jd, offset(jd)
+	for d := step; d <= horizon; d += step {
+		x := jd + dir*d
+		cur := offset(x)
+		if crossesZero(prevOffset, cur) {
+			return bisect(offset, math.Min(prev, x), math.Max(prev, x))
+		}
+		prev, prevOffset = x, cur
+	}
+	return jd + dir*horizon
+}
+
+// ==================== 评分用逆行周期缓存 ====================
+
+// retrogradeCycleSpan 一次缓存的逆行周期覆盖的留逆范围
+type retrogradeCycleSpan struct {
+	from, to float64
+	cycles   []RetrogradeCycle
+}
+
+// retrogradeCycles 按星历源与行星缓存的逆行周期
+var retrogradeCycles = newSpanCache[providerBodyKey, retrogradeCycleSpan](64)
+
+// RetrogradeCycleAt 求 jd 时刻所处的逆行周期（含前后影区），不在周期内时返回 nil
+func RetrogradeCycleAt(planet models.PlanetID, jd float64) *RetrogradeCycle {
+	if !canRetrograde(planet) {
+		return nil
+	}
+	provider := CurrentEphemerisProvider()
+	key := providerBodyKey{provider.Name(), planet}
+
+	// 包含 jd 的周期，其留逆必在 [jd - lead, jd] 内
+	lead := retrogradeCycleLead(planet)
+	span, ok := retrogradeCycles.get(key, func(s retrogradeCycleSpan) bool {
+		return jd-lead >= s.from && jd <= s.to
+	})
+	if !ok {
+		// 一次搜索多覆盖之后一个月，按时间顺序评分时无需重复搜索
+		span = retrogradeCycleSpan{from: jd - lead, to: jd + 30}
+		span.cycles = findRetrogradeCycles(provider, planet, span.from, span.to)
+		retrogradeCycles.put(key, span)
+	}
+
+	t := julianDayToTime(jd)
+	for _, cycle := range span.cycles {
+		if cycle.Phase(t) != "" {
+			return &cycle
+		}
+	}
+	return nil
+}
+
+// stationWindow 留前后被视为"停滞"的时长：逆行期的 10%，至少一天
+func (c RetrogradeCycle) stationWindow() time.Duration {
+	window := c.StationDirect.Time.Sub(c.StationRetrograde.Time) / 10
+	if window < 24*time.Hour {
+		window = 24 * time.Hour
+	}
+	return window
+}
+
+// Lifecycle 给定时刻所在半个周期的生命周期，峰值位于最近的留
+// 前半：进入前影区 → 留逆（峰值）→ 逆行中点；后半：逆行中点 → 留顺（峰值）→ 离开后影区
+func (c RetrogradeCycle) Lifecycle(t time.Time) *models.FactorLifecycle {
+	sr, sd := c.StationRetrograde.Time, c.StationDirect.Time
+	mid := sr.Add(sd.Sub(sr) / 2)
+	if t.Before(mid) {
+		return CreateLifecycleWithPeak(c.ShadowStart, sr, mid)
+	}
+	return CreateLifecycleWithPeak(mid, sd, c.ShadowEnd)
+}
+
+// NearStation 给定时刻是否处于留的停滞窗口内，返回对应的留
+func (c RetrogradeCycle) NearStation(t time.Time) *Station {
+	window := c.stationWindow()
+	for _, station := range []Station{c.StationRetrograde, c.StationDirect} {
+		if d := t.Sub(station.Time); d > -window && d < window {
+			return &station
+		}
+	}
+	return nil
+}
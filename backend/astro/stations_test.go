@@ -0,0 +1,122 @@
+package astro
+
+import (
+	"star/models"
+	"testing"
+	"time"
+)
+
+// TestMercuryStations2025 测试 2025 年 3-4 月水星逆行的留与影区
+// 参考：留逆 2025-03-15 06:46 UTC（白羊座 9°35′），留顺 2025-04-07 11:08 UTC（双鱼座 26°49′）
+func TestMercuryStations2025(t *testing.T) {
+	useProvider(t, NewPreciseProvider())
+
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	stations := FindStations(start, end, models.Mercury)
+	if len(stations) != 2 {
+		t.Fatalf("应找到 2 个留，实际 %d: %+v", len(stations), stations)
+	}
+
+	expected := []struct {
+		stationType StationType
+		at          time.Time
+		sign        models.ZodiacID
+		degree      float64
+	}{
+		{StationRetrograde, time.Date(2025, 3, 15, 6, 46, 0, 0, time.UTC), models.Aries, 9.58},
+		{StationDirect, time.Date(2025, 4, 7, 11, 8, 0, 0, time.UTC), models.Pisces, 26.82},
+	}
+	for i, want := range expected {
+		got := stations[i]
+		if got.Type != want.stationType || got.Sign != want.sign {
+			t.Errorf("第 %d 个留: %s %s，应为 %s %s", i+1, got.Type, got.Sign, want.stationType, want.sign)
+		}
+		if d := got.Time.Sub(want.at); d < -time.Hour || d > time.Hour {
+			t.Errorf("第 %d 个留时刻 %s 与参考 %s 相差 %s", i+1, got.Time, want.at, d)
+		}
+		if d := got.SignDegree - want.degree; d < -0.05 || d > 0.05 {
+			t.Errorf("第 %d 个留度数 %.2f 应为 %.2f", i+1, got.SignDegree, want.degree)
+		}
+	}
+
+	// 起点落在留之后（同一搜索网格区间内）时不应返回起点之前的留
+	later := FindStations(stations[0].Time.Add(time.Hour), end, models.Mercury)
+	if len(later) != 1 || later[0].Type != StationDirect {
+		t.Errorf("从留逆之后开始搜索应只找到留顺: %+v", later)
+	}
+
+	cycles := FindRetrogradeCycles(start, end, models.Mercury)
+	if len(cycles) != 1 {
+		t.Fatalf("应找到 1 个逆行周期，实际 %d", len(cycles))
+	}
+	cycle := cycles[0]
+	t.Logf("前影区 %s，留逆 %s，留顺 %s，后影区结束 %s",
+		cycle.ShadowStart, cycle.StationRetrograde.Time, cycle.StationDirect.Time, cycle.ShadowEnd)
+
+	// 前影区约 3 月 1 日开始，后影区约 4 月 26 日结束
+	if cycle.ShadowStart.Before(time.Date(2025, 2, 27, 0, 0, 0, 0, time.UTC)) ||
+		cycle.ShadowStart.After(time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("前影区开始时间异常: %s", cycle.ShadowStart)
+	}
+	if cycle.ShadowEnd.Before(time.Date(2025, 4, 24, 0, 0, 0, 0, time.UTC)) ||
+		cycle.ShadowEnd.After(time.Date(2025, 4, 29, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("后影区结束时间异常: %s", cycle.ShadowEnd)
+	}
+
+	phases := map[time.Time]string{
+		time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC):  RetrogradePhasePreShadow,
+		time.Date(2025, 3, 25, 0, 0, 0, 0, time.UTC): RetrogradePhaseRetrograde,
+		time.Date(2025, 4, 15, 0, 0, 0, 0, time.UTC): RetrogradePhasePostShadow,
+		time.Date(2025, 5, 15, 0, 0, 0, 0, time.UTC): "",
+	}
+	for at, want := range phases {
+		if got := cycle.Phase(at); got != want {
+			t.Errorf("%s 阶段应为 %q，实际 %q", at.Format("2006-01-02"), want, got)
+		}
+	}
+}
+
+// TestRetrogradeFactorPeaksAtStation 测试逆行因子在留时强度最大
+func TestRetrogradeFactorPeaksAtStation(t *testing.T) {
+	useProvider(t, NewPreciseProvider())
+
+	strengthAt := func(at time.Time) (models.InfluenceFactor, bool) {
+		jd := DateToJulianDay(at)
+		positions := []models.PlanetPosition{CalculatePlanetPositionUnified(models.Mercury, jd)}
+		result := buildFactorResult(calculateRetrogradeFactorsV2(positions, 1, at), at)
+		if len(result.Factors) != 1 {
+			return models.InfluenceFactor{}, false
+		}
+		return result.Factors[0], true
+	}
+
+	station, ok := strengthAt(time.Date(2025, 3, 15, 6, 46, 0, 0, time.UTC))
+	if !ok {
+		t.Fatal("留逆时应有逆行因子")
+	}
+	if station.Name != "Mercury Stationary Retrograde" || station.CurrentStrength < 0.99 {
+		t.Errorf("留逆时应为满强度的停滞因子: %s %.3f", station.Name, station.CurrentStrength)
+	}
+
+	middle, ok := strengthAt(time.Date(2025, 3, 26, 0, 0, 0, 0, time.UTC))
+	if !ok {
+		t.Fatal("逆行中段应有逆行因子")
+	}
+	if middle.Phase != RetrogradePhaseRetrograde || middle.CurrentStrength >= station.CurrentStrength {
+		t.Errorf("逆行中段强度应低于留: %s %.3f", middle.Phase, middle.CurrentStrength)
+	}
+	if station.BaseValue >= middle.BaseValue {
+		t.Errorf("留的基础值应更强: %.2f vs %.2f", station.BaseValue, middle.BaseValue)
+	}
+
+	shadow, ok := strengthAt(time.Date(2025, 4, 20, 0, 0, 0, 0, time.UTC))
+	if !ok || shadow.Phase != RetrogradePhasePostShadow {
+		t.Fatalf("4 月 20 日应处于后影区: %+v", shadow)
+	}
+
+	if _, ok := strengthAt(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)); ok {
+		t.Error("周期结束后不应再有逆行因子")
+	}
+}
@@ -4,30 +4,44 @@
 package astro
 
 import (
-	"math"
+	"fmt"
 	"star/models"
+	"strings"
+	"sync"
 
 	"github.com/mshafiee/swephgo"
 )
 
 // ==================== Swiss Ephemeris 初始化 ====================
+// Swiss Ephemeris C 库在全局状态中保存星历文件句柄与中间结果，不是线程安全的。
+// 所有 swephgo 调用都必须持有 sweMu，并发请求在此串行化
+
+var sweMu sync.Mutex
 
-var sweInitialized bool
 var sweAvailable = true // 标记 Swiss Ephemeris 是否可用
 
+func init() {
+	RegisterEphemerisProvider(ProviderSwiss, func() (EphemerisProvider, error) {
+		InitSwissEphemeris("")
+		return &SwissProvider{}, nil
+	})
+}
+
 // InitSwissEphemeris 初始化 Swiss Ephemeris
 // ephePath: 星历表文件路径，如果为空则使用内置 Moshier 算法
 func InitSwissEphemeris(ephePath string) {
+	sweMu.Lock()
+	defer sweMu.Unlock()
 	if ephePath != "" {
 		swephgo.SetEphePath([]byte(ephePath))
 	}
-	sweInitialized = true
 }
 
 // CloseSwissEphemeris 关闭 Swiss Ephemeris
 func CloseSwissEphemeris() {
+	sweMu.Lock()
+	defer sweMu.Unlock()
 	swephgo.Close()
-	sweInitialized = false
 }
 
 // IsSweAvailable 返回 Swiss Ephemeris 是否可用
@@ -51,65 +65,27 @@ var sweBodyMap = map[models.PlanetID]int{
 	models.Pluto:     swephgo.SePluto,
 	models.NorthNode: swephgo.SeTrueNode,
 	models.Chiron:    swephgo.SeChiron,
+	models.MeanNode:  swephgo.SeMeanNode,
+	models.Lilith:    swephgo.SeMeanApog,
+	models.OscLilith: swephgo.SeOscuApog,
+	models.Ceres:     swephgo.SeCeres, // 小行星需要 seas_*.se1 星历文件，缺失时回退到二体轨道
+	models.Pallas:    swephgo.SePallas,
+	models.Juno:      swephgo.SeJuno,
+	models.Vesta:     swephgo.SeVesta,
+	models.Eris:      swephgo.SeAstOffset + 136199, // 需要 se136199.se1
 }
 
 // ==================== 高精度行星位置计算 ====================
 
-// CalculatePlanetPositionSwe 使用 Swiss Ephemeris 计算行星位置
+// CalculatePlanetPositionSwe 使用 Swiss Ephemeris 计算行星位置，失败时记录错误并回退到内置算法
 func CalculatePlanetPositionSwe(planet models.PlanetID, jd float64) models.PlanetPosition {
-	if !sweInitialized {
-		InitSwissEphemeris("")
-	}
-
-	sweBody, ok := sweBodyMap[planet]
-	if !ok {
-		// 回退到内置算法
-		return CalculatePlanetPosition(planet, jd)
-	}
-
-	// 计算标志：使用 Swiss Ephemeris + 速度
-	flag := swephgo.SeflgSwieph | swephgo.SeflgSpeed
-
-	// 准备输出缓冲区
-	xx := make([]float64, 6)
-	serr := make([]byte, 256)
-
-	// 计算行星位置
-	ret := swephgo.Calc(jd, sweBody, flag, xx, serr)
-	if ret < 0 {
-		// 如果失败，回退到内置算法
-		return CalculatePlanetPosition(planet, jd)
-	}
-
-	longitude := NormalizeAngle(xx[0])
-	latitude := xx[1]
-	speed := xx[3]
-	retrograde := speed < 0
-
-	// 获取星座信息
-	zodiac := GetZodiacByLongitude(longitude)
-	signDegree := math.Mod(longitude, 30)
-
-	// 计算尊贵度
-	dignity := GetDignity(planet, zodiac.ID)
-	dignityScore := GetDignityScore(dignity)
-
-	planetInfo := GetPlanetInfo(planet)
-
-	return models.PlanetPosition{
-		ID:           planet,
-		Name:         planetInfo.Name,
-		Symbol:       planetInfo.Symbol,
-		Longitude:    longitude,
-		Latitude:     latitude,
-		Sign:         zodiac.ID,
-		SignName:     zodiac.Name,
-		SignSymbol:   zodiac.Symbol,
-		SignDegree:   signDegree,
-		Retrograde:   retrograde,
-		House:        0, // 需要额外计算
-		DignityScore: dignityScore,
+	provider := &SwissProvider{}
+	pos, err := provider.PlanetPosition(planet, jd)
+	if err != nil {
+		reportProviderFailure(provider, string(planet), jd, err)
+		return builtinPlanetPosition(planet, jd)
 	}
+	return pos
 }
 
 // GetAllPlanetPositionsSwe 使用 Swiss Ephemeris 获取所有行星位置
@@ -129,20 +105,92 @@ func GetAllPlanetPositionsSwe(jd float64) []models.PlanetPosition {
 
 // ==================== 高精度宫位计算 ====================
 
-// CalculateHousesSwe 使用 Swiss Ephemeris 计算宫位
-func CalculateHousesSwe(jd float64, lat, lon float64) ([]models.HouseCusp, float64, float64) {
-	if !sweInitialized {
-		InitSwissEphemeris("")
+// CalculateHousesSwe 使用 Swiss Ephemeris 计算宫位，失败时记录错误并回退到内置算法
+func CalculateHousesSwe(jd float64, lat, lon float64, system models.HouseSystem) ([]models.HouseCusp, float64, float64) {
+	provider := &SwissProvider{}
+	houses, asc, mc, err := provider.Houses(jd, lat, lon, system)
+	if err != nil {
+		reportProviderFailure(provider, "houses", jd, err)
+		return CalculateHouses(jd, lat, lon, system)
+	}
+	return houses, asc, mc
+}
+
+// ==================== Swiss Ephemeris 提供者 ====================
+
+// SwissProvider 基于 Swiss Ephemeris 的星历提供者
+type SwissProvider struct{}
+
+// Name 提供者标识
+func (p *SwissProvider) Name() string {
+	return ProviderSwiss
+}
+
+// Description 数据源描述
+func (p *SwissProvider) Description() string {
+	return "Swiss Ephemeris (High Precision)"
+}
+
+// sweError 将 Swiss Ephemeris 错误缓冲区转换为 error
+func sweError(serr []byte) error {
+	msg := strings.TrimRight(string(serr), "\x00")
+	if msg == "" {
+		msg = "未知错误"
+	}
+	return fmt.Errorf("Swiss Ephemeris 计算失败: %s", msg)
+}
+
+// Cacheable 经由星历缓存读取，避免逐小时重复调用 C 库
+func (p *SwissProvider) Cacheable() bool {
+	return true
+}
+
+// PlanetPosition 计算天体位置（jd 为世界时）
+func (p *SwissProvider) PlanetPosition(planet models.PlanetID, jd float64) (models.PlanetPosition, error) {
+	sweBody, ok := sweBodyMap[planet]
+	if !ok {
+		return models.PlanetPosition{}, fmt.Errorf("Swiss Ephemeris 不支持天体: %s", planet)
+	}
+
+	// 计算标志：使用 Swiss Ephemeris + 速度
+	flag := swephgo.SeflgSwieph | swephgo.SeflgSpeed
+
+	xx := make([]float64, 6)
+	serr := make([]byte, 256)
+	sweMu.Lock()
+	ret := swephgo.CalcUt(jd, sweBody, flag, xx, serr)
+	sweMu.Unlock()
+	if ret < 0 {
+		return models.PlanetPosition{}, sweError(serr)
 	}
 
-	// Placidus 分宫制 = 'P'
-	cusps := make([]float64, 13)   // 13 个宫位尖端 (0 未使用)
-	ascmc := make([]float64, 10)   // ASC, MC 等
+	// xx: 黄经、黄纬、距离（AU）及其各自的日速度
+	return newMovingPosition(planet, jd, xx[0], xx[1], planetMotion{
+		Distance:      xx[2],
+		Speed:         xx[3],
+		LatitudeSpeed: xx[4],
+	}), nil
+}
+
+// Houses 使用 swe_houses_ex2 计算宫位，失败时返回 Swiss Ephemeris 的错误信息，由调用方决定是否回退
+func (p *SwissProvider) Houses(jd, lat, lon float64, system models.HouseSystem) ([]models.HouseCusp, float64, float64, error) {
+	// 分宫制代码，默认 Placidus = 'P'
+	hsys := int('P')
+	if info := GetHouseSystemInfo(ResolveHouseSystem(system)); info != nil {
+		hsys = int(info.Code)
+	}
+
+	cusps := make([]float64, 13) // 13 个宫位尖端 (0 未使用)
+	ascmc := make([]float64, 10) // ASC, MC 等
+	cuspSpeed := make([]float64, 13)
+	ascmcSpeed := make([]float64, 10)
+	serr := make([]byte, 256)
 
-	ret := swephgo.Houses(jd, lat, lon, 'P', cusps, ascmc)
+	sweMu.Lock()
+	ret := swephgo.HousesEx2(jd, 0, lat, lon, hsys, cusps, ascmc, cuspSpeed, ascmcSpeed, serr)
+	sweMu.Unlock()
 	if ret < 0 {
-		// 回退到内置算法
-		return CalculateHouses(jd, lat, lon)
+		return nil, 0, 0, sweError(serr)
 	}
 
 	houses := make([]models.HouseCusp, 12)
@@ -160,5 +208,85 @@ func CalculateHousesSwe(jd float64, lat, lon float64) ([]models.HouseCusp, float
 	asc := NormalizeAngle(ascmc[0]) // ASC
 	mc := NormalizeAngle(ascmc[1])  // MC
 
-	return houses, asc, mc
+	return houses, asc, mc, nil
+}
+
+// RiseSet 使用 swe_rise_trans 计算升落（上边缘，含标准大气折射）
+func (p *SwissProvider) RiseSet(body models.PlanetID, jd, lat, lon float64, event RiseSetEvent) (float64, error) {
+	sweBody, ok := sweBodyMap[body]
+	if !ok {
+		return 0, fmt.Errorf("Swiss Ephemeris 不支持天体: %s", body)
+	}
+
+	rsmi := swephgo.SeCalcRise
+	if event == EventSet {
+		rsmi = swephgo.SeCalcSet
+	}
+
+	geopos := []float64{lon, lat, 0}
+	tret := make([]float64, 10)
+	serr := make([]byte, 256)
+	sweMu.Lock()
+	ret := swephgo.RiseTrans(jd, sweBody, nil, swephgo.SeflgSwieph, rsmi, geopos, 0, 0, tret, serr)
+	sweMu.Unlock()
+	switch ret {
+	case -2:
+		return 0, ErrNoRiseSet
+	case -1:
+		return 0, sweError(serr)
+	}
+	return tret[0], nil
+}
+
+// NextEclipse 使用 swe_sol_eclipse_when_glob / swe_lun_eclipse_when 查找日月食
+func (p *SwissProvider) NextEclipse(jd float64, kind EclipseKind, backward bool) (EclipseInfo, error) {
+	back := 0
+	if backward {
+		back = 1
+	}
+
+	tret := make([]float64, 10)
+	attr := make([]float64, 20)
+	geopos := make([]float64, 10)
+	serr := make([]byte, 256)
+
+	if kind == SolarEclipse {
+		sweMu.Lock()
+		ret := swephgo.SolEclipseWhenGlob(jd, swephgo.SeflgSwieph, 0, tret, back, serr)
+		if ret >= 0 {
+			swephgo.SolEclipseWhere(tret[0], swephgo.SeflgSwieph, geopos, attr, serr)
+		}
+		sweMu.Unlock()
+		if ret < 0 {
+			return EclipseInfo{}, sweError(serr)
+		}
+		eclipseType := EclipsePartial
+		switch {
+		case ret&swephgo.SeEclTotal != 0:
+			eclipseType = EclipseTotal
+		case ret&swephgo.SeEclAnnularTotal != 0:
+			eclipseType = EclipseHybrid
+		case ret&swephgo.SeEclAnnular != 0:
+			eclipseType = EclipseAnnular
+		}
+		return newEclipseInfo(p, kind, eclipseType, tret[0], attr[0]), nil
+	}
+
+	sweMu.Lock()
+	ret := swephgo.LunEclipseWhen(jd, swephgo.SeflgSwieph, 0, tret, back, serr)
+	if ret >= 0 {
+		swephgo.LunEclipseHow(tret[0], swephgo.SeflgSwieph, geopos, attr, serr)
+	}
+	sweMu.Unlock()
+	if ret < 0 {
+		return EclipseInfo{}, sweError(serr)
+	}
+	eclipseType, magnitude := EclipsePenumbral, attr[1]
+	switch {
+	case ret&swephgo.SeEclTotal != 0:
+		eclipseType, magnitude = EclipseTotal, attr[0]
+	case ret&swephgo.SeEclPartial != 0:
+		eclipseType, magnitude = EclipsePartial, attr[0]
+	}
+	return newEclipseInfo(p, kind, eclipseType, tret[0], magnitude), nil
 }
@@ -29,7 +29,7 @@ func IsSweAvailable() bool {
 // CalculatePlanetPositionSwe 使用内置算法计算行星位置（回退实现）
 func CalculatePlanetPositionSwe(planet models.PlanetID, jd float64) models.PlanetPosition {
 	// 回退到内置算法
-	return CalculatePlanetPosition(planet, jd)
+	return builtinPlanetPosition(planet, jd)
 }
 
 // GetAllPlanetPositionsSwe 使用内置算法获取所有行星位置（回退实现）
@@ -38,7 +38,7 @@ func GetAllPlanetPositionsSwe(jd float64) []models.PlanetPosition {
 }
 
 // CalculateHousesSwe 使用内置算法计算宫位（回退实现）
-func CalculateHousesSwe(jd float64, lat, lon float64) ([]models.HouseCusp, float64, float64) {
-	return CalculateHouses(jd, lat, lon)
+func CalculateHousesSwe(jd float64, lat, lon float64, system models.HouseSystem) ([]models.HouseCusp, float64, float64) {
+	return CalculateHouses(jd, lat, lon, system)
 }
 
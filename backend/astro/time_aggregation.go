@@ -45,7 +45,7 @@ type ScoreFeatures struct {
 
 // CalculateHourlyScore 计算单个小时的分数
 func CalculateHourlyScore(chart *models.NatalChart, hourTime time.Time) *ScoreResult {
-	return CalculateScoresV2(chart, hourTime)
+	return CurrentScoringConfig().Scores(chart, hourTime)
 }
 
 // ==================== 日分聚合 ====================
@@ -53,13 +53,18 @@ func CalculateHourlyScore(chart *models.NatalChart, hourTime time.Time) *ScoreRe
 // AggregateDailyFromHourly 从小时分聚合成日分
 // 采用特征提取法
 func AggregateDailyFromHourly(chart *models.NatalChart, date time.Time) *AggregatedScore {
+	return aggregateDaily(CurrentScoringConfig(), chart, date)
+}
+
+// aggregateDaily 按配置快照聚合日分
+func aggregateDaily(cfg *ScoringConfig, chart *models.NatalChart, date time.Time) *AggregatedScore {
 	// 生成24个小时的分数
 	hourlyScores := make([]*ScoreResult, 24)
 	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
 
 	for hour := 0; hour < 24; hour++ {
 		hourTime := startOfDay.Add(time.Duration(hour) * time.Hour)
-		hourlyScores[hour] = CalculateHourlyScore(chart, hourTime)
+		hourlyScores[hour] = cfg.Scores(chart, hourTime)
 	}
 
 	// 提取综合分特征
@@ -110,12 +115,17 @@ func AggregateDailyFromHourly(chart *models.NatalChart, date time.Time) *Aggrega
 
 // AggregateWeeklyFromDaily 从日分聚合成周分
 func AggregateWeeklyFromDaily(chart *models.NatalChart, weekStart time.Time) *AggregatedScore {
+	return aggregateWeekly(CurrentScoringConfig(), chart, weekStart)
+}
+
+// aggregateWeekly 按配置快照聚合周分
+func aggregateWeekly(cfg *ScoringConfig, chart *models.NatalChart, weekStart time.Time) *AggregatedScore {
 	// 生成7天的日分
 	dailyScores := make([]*AggregatedScore, 7)
 
 	for day := 0; day < 7; day++ {
 		dayTime := weekStart.AddDate(0, 0, day)
-		dailyScores[day] = AggregateDailyFromHourly(chart, dayTime)
+		dailyScores[day] = aggregateDaily(cfg, chart, dayTime)
 	}
 
 	// 提取特征
@@ -171,6 +181,11 @@ func AggregateWeeklyFromDaily(chart *models.NatalChart, weekStart time.Time) *Ag
 
 // AggregateMonthlyFromDaily 从日分聚合成月分
 func AggregateMonthlyFromDaily(chart *models.NatalChart, year int, month time.Month) *AggregatedScore {
+	return aggregateMonthly(CurrentScoringConfig(), chart, year, month)
+}
+
+// aggregateMonthly 按配置快照聚合月分
+func aggregateMonthly(cfg *ScoringConfig, chart *models.NatalChart, year int, month time.Month) *AggregatedScore {
 	// 获取该月的天数
 	firstOfMonth := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
 	lastOfMonth := firstOfMonth.AddDate(0, 1, -1)
@@ -180,7 +195,7 @@ func AggregateMonthlyFromDaily(chart *models.NatalChart, year int, month time.Mo
 	dailyScores := make([]*AggregatedScore, daysInMonth)
 	for day := 0; day < daysInMonth; day++ {
 		dayTime := firstOfMonth.AddDate(0, 0, day)
-		dailyScores[day] = AggregateDailyFromHourly(chart, dayTime)
+		dailyScores[day] = aggregateDaily(cfg, chart, dayTime)
 	}
 
 	// 提取特征
@@ -229,12 +244,17 @@ func AggregateMonthlyFromDaily(chart *models.NatalChart, year int, month time.Mo
 
 // AggregateYearlyFromMonthly 从月分聚合成年分
 func AggregateYearlyFromMonthly(chart *models.NatalChart, year int) *AggregatedScore {
+	return aggregateYearly(CurrentScoringConfig(), chart, year)
+}
+
+// aggregateYearly 按配置快照聚合年分
+func aggregateYearly(cfg *ScoringConfig, chart *models.NatalChart, year int) *AggregatedScore {
 	// 生成12个月的月分
 	monthlyScores := make([]*AggregatedScore, 12)
 
 	for m := 0; m < 12; m++ {
 		month := time.Month(m + 1)
-		monthlyScores[m] = AggregateMonthlyFromDaily(chart, year, month)
+		monthlyScores[m] = aggregateMonthly(cfg, chart, year, month)
 	}
 
 	// 提取特征
@@ -379,9 +399,10 @@ func aggregateWithFeatures(values []float64) float64 {
 
 // GetScoreAtTime 获取某个时间点的分数（自动选择合适的粒度）
 func GetScoreAtTime(chart *models.NatalChart, t time.Time, granularity string) *AggregatedScore {
+	cfg := CurrentScoringConfig()
 	switch granularity {
 	case "hourly":
-		result := CalculateHourlyScore(chart, t)
+		result := cfg.Scores(chart, t)
 		return &AggregatedScore{
 			Overall:     result.Overall,
 			Dimensions:  result.Dimensions,
@@ -390,7 +411,7 @@ func GetScoreAtTime(chart *models.NatalChart, t time.Time, granularity string) *
 			Granularity: "hourly",
 		}
 	case "daily":
-		return AggregateDailyFromHourly(chart, t)
+		return aggregateDaily(cfg, chart, t)
 	case "weekly":
 		// 找到周一
 		weekday := int(t.Weekday())
@@ -398,13 +419,13 @@ func GetScoreAtTime(chart *models.NatalChart, t time.Time, granularity string) *
 			weekday = 7
 		}
 		weekStart := t.AddDate(0, 0, -(weekday - 1))
-		return AggregateWeeklyFromDaily(chart, weekStart)
+		return aggregateWeekly(cfg, chart, weekStart)
 	case "monthly":
-		return AggregateMonthlyFromDaily(chart, t.Year(), t.Month())
+		return aggregateMonthly(cfg, chart, t.Year(), t.Month())
 	case "yearly":
-		return AggregateYearlyFromMonthly(chart, t.Year())
+		return aggregateYearly(cfg, chart, t.Year())
 	default:
-		return AggregateDailyFromHourly(chart, t)
+		return aggregateDaily(cfg, chart, t)
 	}
 }
 
@@ -66,6 +66,7 @@ func CalculateTimeSeries(chart *models.NatalChart, startStr, endStr, granularity
 func generateUnifiedTimeSeriesPoints(chart *models.NatalChart, start, end time.Time, granularity models.TimeGranularity) []models.TimeSeriesPoint {
 	var points []models.TimeSeriesPoint
 	current := start
+	cfg := CurrentScoringConfig() // 整个序列使用同一份配置快照
 
 	for current.Before(end) || current.Equal(end) {
 		var score UnifiedScore
@@ -73,23 +74,23 @@ func generateUnifiedTimeSeriesPoints(chart *models.NatalChart, start, end time.T
 
 		switch granularity {
 		case models.GranularityHour:
-			score = CalculateUnifiedHourlyScore(chart, current)
+			score = unifiedHourlyScore(cfg, chart, current, "")
 			label = current.Format("15:00")
 
 		case models.GranularityDay:
-			score = CalculateDailyScore(chart, current)
+			score = dailyUnifiedScore(cfg, chart, current)
 			label = current.Format("01-02")
 
 		case models.GranularityWeek:
-			score = CalculateWeeklyScore(chart, current)
+			score = weeklyUnifiedScore(cfg, chart, current)
 			label = current.Format("01-02") + " Week"
 
 		case models.GranularityMonth:
-			score = CalculateMonthlyScore(chart, current.Year(), current.Month())
+			score = monthlyUnifiedScore(cfg, chart, current.Year(), current.Month())
 			label = current.Format("2006-01")
 
 		case models.GranularityYear:
-			score = CalculateYearlyScore(chart, current.Year())
+			score = yearlyUnifiedScore(cfg, chart, current.Year())
 			label = current.Format("2006")
 		}
 
@@ -214,10 +215,11 @@ func calculateTimeSeriesStats(points []models.TimeSeriesPoint) models.TimeSeries
 // CalculateHourlyTimeSeries 计算小时级时间序列
 func CalculateHourlyTimeSeries(chart *models.NatalChart, date time.Time) []models.TimeSeriesPoint {
 	var points []models.TimeSeriesPoint
+	cfg := CurrentScoringConfig()
 
 	for hour := 0; hour < 24; hour++ {
 		t := time.Date(date.Year(), date.Month(), date.Day(), hour, 0, 0, 0, date.Location())
-		score := CalculateUnifiedHourlyScore(chart, t)
+		score := unifiedHourlyScore(cfg, chart, t, "")
 
 		point := models.TimeSeriesPoint{
 			Time:        t,
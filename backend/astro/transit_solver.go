@@ -0,0 +1,351 @@
+package astro
+
+import (
+	"math"
+	"sort"
+	"star/models"
+	"time"
+)
+
+// ==================== 行运精确时刻求解 ====================
+// 行运天体与本命点的角距 g(t) = 行运黄经 - 本命黄经 - 目标角距（归一化到 ±180°）。
+// 按天体速度选取步长采样 g(t)：符号变化处二分求根得到精确成相时刻；
+// 采样点中 |g| 的局部极小（行星停滞附近一步内的两次穿越）再用黄金分割确认。
+// 以精确时刻为中心向两侧步进到 |g| 超出容许度（相位目录的行运情境），二分得到进入/离开时刻
+
+// transitSolverTolerance 求根精度（儒略日，1 秒）
+const transitSolverTolerance = 1.0 / 86400
+
+// transitWindowHorizon 容许度窗口向两侧搜索的最大天数
+const transitWindowHorizon = 3650.0
+
+// transitSearchSteps 各行运天体的采样步长（天），保证一步内移动不超过约 1.5°
+var transitSearchSteps = map[models.PlanetID]float64{
+	models.Sun:       1,
+	models.Moon:      0.05,
+	models.Mercury:   0.5,
+	models.Venus:     1,
+	models.Mars:      1,
+	models.Jupiter:   2,
+	models.Saturn:    2,
+	models.Uranus:    4,
+	models.Neptune:   4,
+	models.Pluto:     4,
+	models.NorthNode: 0.5,
+	models.Chiron:    2,
+}
+
+// TransitBodies 行运事件搜索的天体（月亮每天都会形成相位，不作为行运事件）
+var TransitBodies = []models.PlanetID{
+	models.Sun, models.Mercury, models.Venus, models.Mars,
+	models.Jupiter, models.Saturn, models.Uranus, models.Neptune, models.Pluto,
+	models.NorthNode, models.Chiron,
+}
+
+// TransitWindow 一次行运相位的容许度窗口
+type TransitWindow struct {
+	TransitPlanet models.PlanetID
+	NatalPlanet   models.PlanetID
+	Aspect        AspectDefinition
+	Orb           float64     // 生效容许度
+	Entry         time.Time   // 进入容许度时刻（超出搜索范围时为零值）
+	Exit          time.Time   // 离开容许度时刻（超出搜索范围时为零值）
+	Exact         []time.Time // 精确成相时刻，逆行往返时可有三次
+}
+
+// ==================== 黄经采样 ====================
+
+// transitTrack 行运天体黄经的采样网格（以 origin 为原点、step 为步长）
+type transitTrack struct {
+	chart    *models.NatalChart
+	provider EphemerisProvider
+	planet   models.PlanetID
+	origin   float64
+	step     float64
+	memo     map[float64]float64
+}
+
+// newTransitTrack 创建行运天体的采样网格
+func newTransitTrack(chart *models.NatalChart, planet models.PlanetID, origin float64) *transitTrack {
+	step, ok := transitSearchSteps[planet]
+	if !ok {
+		step = 1
+	}
+	return &transitTrack{
+		chart:    chart,
+		provider: CurrentEphemerisProvider(),
+		planet:   planet,
+		origin:   origin,
+		step:     step,
+		memo:     make(map[float64]float64),
+	}
+}
+
+// at 第 k 个网格点的儒略日
+func (tr *transitTrack) at(k int) float64 {
+	return tr.origin + float64(k)*tr.step
+}
+
+// index jd 所在（不晚于 jd）的网格点序号
+func (tr *transitTrack) index(jd float64) int {
+	return int(math.Floor((jd - tr.origin) / tr.step))
+}
+
+// longitude 行运天体在星盘黄道下的黄经
+func (tr *transitTrack) longitude(jd float64) float64 {
+	if lon, ok := tr.memo[jd]; ok {
+		return lon
+	}
+	pos := planetPositionFrom(tr.provider, tr.planet, jd)
+	lon := NormalizeAngle(pos.Longitude - ChartZodiacOffset(tr.chart, jd))
+	tr.memo[jd] = lon
+	return lon
+}
+
+// position 行运天体在星盘黄道下的完整位置
+func (tr *transitTrack) position(jd float64) models.PlanetPosition {
+	return ApplyZodiacToPositions(
+		[]models.PlanetPosition{planetPositionFrom(tr.provider, tr.planet, jd)},
+		ChartZodiacOffset(tr.chart, jd),
+	)[0]
+}
+
+// ==================== 求根 ====================
+
+// transitSeeker 行运天体与本命点在某一目标角距上的求解器
+type transitSeeker struct {
+	track  *transitTrack
+	natal  float64
+	target float64
+}
+
+// aspectTargets 相位的带符号目标角距（合相与对分相只有一个）
+func aspectTargets(angle float64) []float64 {
+	if angle == 0 || angle == 180 {
+		return []float64{angle}
+	}
+	return []float64{angle, -angle}
+}
+
+// offset 当前角距与目标角距之差（-180, 180]
+func (s transitSeeker) offset(jd float64) float64 {
+	return normalizeSigned(s.track.longitude(jd) - s.natal - s.target)
+}
+
+// crossesZero 相邻采样是否跨过零点（排除 ±180° 处的回绕）
+func crossesZero(a, b float64) bool {
+	return (a < 0) != (b < 0) && math.Abs(a) < 90 && math.Abs(b) < 90
+}
+
+// bisect 在 f 变号的区间 [a, b] 内二分求根
+func bisect(f func(float64) float64, a, b float64) float64 {
+	fa := f(a)
+	for b-a > transitSolverTolerance {
+		m := (a + b) / 2
+		if (f(m) < 0) == (fa < 0) {
+			a = m
+		} else {
+			b = m
+		}
+	}
+	return (a + b) / 2
+}
+
+// exactHits 查找 [from, to] 内所有精确成相时刻
+func (s transitSeeker) exactHits(from, to float64) []float64 {
+	tr := s.track
+	k0, k1 := tr.index(from), tr.index(to)+1
+
+	var hits []float64
+	var beforeJD, before float64
+	prevJD := tr.at(k0)
+	prev := s.offset(prevJD)
+	for k := k0 + 1; k <= k1; k++ {
+		jd := tr.at(k)
+		cur := s.offset(jd)
+		switch {
+		case crossesZero(prev, cur):
+			hits = append(hits, bisect(s.offset, prevJD, jd))
+		case k > k0+1 && isNearDip(before, prev, cur):
+			hits = append(hits, s.doubleRoot(beforeJD, jd)...)
+		}
+		beforeJD, before = prevJD, prev
+		prevJD, prev = jd, cur
+	}
+
+	inRange := hits[:0]
+	for _, hit := range hits {
+		if hit >= from && hit <= to {
+			inRange = append(inRange, hit)
+		}
+	}
+	sort.Float64s(inRange)
+	return inRange
+}
+
+// isNearDip 三个同号采样中间点为 |g| 局部极小且足够接近零，可能在一步内两次穿越
+func isNearDip(a, b, c float64) bool {
+	if (a < 0) != (b < 0) || (b < 0) != (c < 0) {
+		return false
+	}
+	if math.Abs(b) > 90 || math.Abs(b) > math.Abs(a) || math.Abs(b) > math.Abs(c) {
+		return false
+	}
+	return math.Abs(b) <= math.Max(math.Abs(a-b), math.Abs(c-b))
+}
+
+// doubleRoot 黄金分割求 |g| 的极小值，若极小处已变号则两侧各有一个根
+func (s transitSeeker) doubleRoot(a, b float64) []float64 {
+	sign := 1.0
+	if s.offset(a) < 0 {
+		sign = -1
+	}
+	f := func(jd float64) float64 { return sign * s.offset(jd) }
+
+	const ratio = 0.6180339887498949
+	lo, hi := a, b
+	x1, x2 := hi-ratio*(hi-lo), lo+ratio*(hi-lo)
+	f1, f2 := f(x1), f(x2)
+	for hi-lo > transitSolverTolerance {
+		if f1 < f2 {
+			hi, x2, f2 = x2, x1, f1
+			x1 = hi - ratio*(hi-lo)
+			f1 = f(x1)
+		} else {
+			lo, x1, f1 = x1, x2, f2
+			x2 = lo + ratio*(hi-lo)
+			f2 = f(x2)
+		}
+	}
+	m := (lo + hi) / 2
+	if f(m) >= 0 {
+		return nil
+	}
+	return []float64{bisect(s.offset, a, m), bisect(s.offset, m, b)}
+}
+
+// orbEdge 从 hit 向一侧（dir = ±1）步进，返回 |g| 超出容许度的时刻
+func (s transitSeeker) orbEdge(hit, orb float64, dir int) (float64, bool) {
+	tr := s.track
+	outside := func(jd float64) float64 { return math.Abs(s.offset(jd)) - orb }
+
+	k := tr.index(hit)
+	if dir > 0 {
+		k++
+	}
+	last := hit
+	for {
+		jd := tr.at(k)
+		if math.Abs(jd-hit) > transitWindowHorizon {
+			return hit + float64(dir)*transitWindowHorizon, false
+		}
+		if outside(jd) > 0 {
+			if dir > 0 {
+				return bisect(outside, last, jd), true
+			}
+			return bisect(outside, jd, last), true
+		}
+		last = jd
+		k += dir
+	}
+}
+
+// window 以精确时刻 hit 为中心求容许度窗口及窗口内的全部精确时刻
+func (s transitSeeker) window(transit, natal models.PlanetID, def AspectDefinition, orb, hit float64) (TransitWindow, float64) {
+	entry, hasEntry := s.orbEdge(hit, orb, -1)
+	exit, hasExit := s.orbEdge(hit, orb, 1)
+
+	w := TransitWindow{
+		TransitPlanet: transit,
+		NatalPlanet:   natal,
+		Aspect:        def,
+		Orb:           orb,
+	}
+	if hasEntry {
+		w.Entry = julianDayToTime(entry)
+	}
+	if hasExit {
+		w.Exit = julianDayToTime(exit)
+	}
+	for _, jd := range s.exactHits(entry, exit) {
+		w.Exact = append(w.Exact, julianDayToTime(jd))
+	}
+	return w, exit
+}
+
+// julianDayToTime 儒略日转换为 UTC 时刻（取整到秒；JulianDayToDate 会截断秒）
+func julianDayToTime(jd float64) time.Time {
+	j2000 := time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)
+	return j2000.Add(time.Duration(math.Round((jd-J2000)*86400)) * time.Second)
+}
+
+// ==================== 对外接口 ====================
+
+// FindTransitWindows 查找 [start, end) 内精确成相的全部行运窗口，按首次精确时刻排序
+// 逆行往返形成的多次精确成相归入同一窗口（只要期间未离开容许度）
+func FindTransitWindows(chart *models.NatalChart, start, end time.Time) []TransitWindow {
+	from, to := DateToJulianDay(start.UTC()), DateToJulianDay(end.UTC())
+
+	catalogue := CurrentAspectCatalogue()
+	defs := catalogue.Definitions(AspectContextTransit)
+
+	var windows []TransitWindow
+	for _, body := range TransitBodies {
+		track := newTransitTrack(chart, body, from)
+		for _, natal := range NatalPoints(chart) {
+			for _, def := range defs {
+				orb := catalogue.Orb(def, body, natal.ID, AspectContextTransit)
+				for _, target := range aspectTargets(def.Angle) {
+					seeker := transitSeeker{track: track, natal: natal.Longitude, target: target}
+					covered := math.Inf(-1)
+					for _, hit := range seeker.exactHits(from, to) {
+						if hit <= covered {
+							continue
+						}
+						w, exit := seeker.window(body, natal.ID, def, orb, hit)
+						windows = append(windows, w)
+						covered = exit
+					}
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(windows, func(i, j int) bool {
+		return windows[i].Exact[0].Before(windows[j].Exact[0])
+	})
+	return windows
+}
+
+// FindTransitWindowAt 求 jd 时刻处于容许度 orb 内的行运相位所在窗口
+// 窗口内尚无精确成相（如行星在成相前停滞折返）时 Exact 为空
+func FindTransitWindowAt(chart *models.NatalChart, transit models.PlanetID, natal models.PlanetPosition, def AspectDefinition, orb, jd float64) TransitWindow {
+	track := newTransitTrack(chart, transit, jd)
+	seeker := transitSeeker{track: track, natal: natal.Longitude, target: def.Angle}
+	if def.Angle != 0 && def.Angle != 180 {
+		alt := transitSeeker{track: track, natal: natal.Longitude, target: -def.Angle}
+		if math.Abs(alt.offset(jd)) < math.Abs(seeker.offset(jd)) {
+			seeker = alt
+		}
+	}
+	w, _ := seeker.window(transit, natal.ID, def, orb, jd)
+	return w
+}
+
+// transitDuration 将窗口转换为行运持续时间（peak 为本次精确时刻）
+func transitDuration(w TransitWindow, peak time.Time) models.TransitDuration {
+	d := models.TransitDuration{}
+	if !w.Entry.IsZero() {
+		d.Start = w.Entry.Format(time.RFC3339)
+	}
+	if !peak.IsZero() {
+		d.Peak = peak.Format(time.RFC3339)
+	}
+	if !w.Exit.IsZero() {
+		d.End = w.Exit.Format(time.RFC3339)
+	}
+	for _, t := range w.Exact {
+		d.Exact = append(d.Exact, t.Format(time.RFC3339))
+	}
+	return d
+}
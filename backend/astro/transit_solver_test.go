@@ -0,0 +1,76 @@
+package astro
+
+import (
+	"math"
+	"star/models"
+	"testing"
+	"time"
+)
+
+// TestTransitSolverRetrogradeTriplePass 测试水星逆行往返对同一本命点的三次精确成相
+func TestTransitSolverRetrogradeTriplePass(t *testing.T) {
+	useProvider(t, NewPreciseProvider())
+
+	// 2025 年 3 月水星在白羊座 9° 逆行，回到双鱼座 26°，本命点取白羊座 3°
+	chart := &models.NatalChart{
+		Planets: []models.PlanetPosition{newPlanetPosition(models.Sun, 3, 0, false)},
+	}
+	start := time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 5, 15, 0, 0, 0, 0, time.UTC)
+
+	var window *TransitWindow
+	for _, w := range FindTransitWindows(chart, start, end) {
+		if w.TransitPlanet == models.Mercury && w.Aspect.Type == models.Conjunction {
+			w := w
+			window = &w
+		}
+	}
+	if window == nil {
+		t.Fatal("未找到水星合本命太阳的行运窗口")
+	}
+	if len(window.Exact) != 3 {
+		t.Fatalf("逆行往返应精确成相三次，实际 %d 次: %v", len(window.Exact), window.Exact)
+	}
+	if !window.Entry.Before(window.Exact[0]) || !window.Exit.After(window.Exact[2]) {
+		t.Errorf("进入/离开时刻应包住全部精确时刻: %v ~ %v", window.Entry, window.Exit)
+	}
+
+	provider := CurrentEphemerisProvider()
+	for i, exact := range window.Exact {
+		pos, _ := provider.PlanetPosition(models.Mercury, DateToJulianDay(exact))
+		if diff := math.Abs(normalizeSigned(pos.Longitude - 3)); diff > 1e-3 {
+			t.Errorf("第 %d 次精确时刻 %v 误差过大: %.6f°", i+1, exact, diff)
+		}
+		t.Logf("第 %d 次精确成相: %s", i+1, exact.Format(time.RFC3339))
+	}
+
+	for _, edge := range []time.Time{window.Entry, window.Exit} {
+		pos, _ := provider.PlanetPosition(models.Mercury, DateToJulianDay(edge))
+		if diff := math.Abs(normalizeSigned(pos.Longitude-3)) - window.Orb; math.Abs(diff) > 1e-3 {
+			t.Errorf("容许度边界 %v 处角距应等于容许度，偏差 %.6f°", edge, diff)
+		}
+	}
+}
+
+// TestCalculateTransitsExactEvents 测试行运事件使用精确时刻而非每周采样
+func TestCalculateTransitsExactEvents(t *testing.T) {
+	useProvider(t, NewPreciseProvider())
+
+	chart := CalculateNatalChart(models.BirthData{
+		Year: 1990, Month: 6, Day: 15, Hour: 14, Minute: 30,
+		Latitude: 39.9042, Longitude: 116.4074, Timezone: 8,
+	})
+	result := CalculateTransits(chart, "2025-01-01", "2025-03-01")
+	if len(result.Events) == 0 {
+		t.Fatal("两个月内应有行运事件")
+	}
+	for _, e := range result.Events {
+		if e.Aspect.Orb > 1e-3 {
+			t.Errorf("%s-%s 精确时刻容许度应接近零: %.6f°", e.TransitPlanet, e.NatalPlanet, e.Aspect.Orb)
+		}
+		if e.Duration.Peak != e.Date.Format(time.RFC3339) || e.Pass < 1 || e.Pass > len(e.Duration.Exact) {
+			t.Errorf("%s-%s 持续时间与精确时刻不一致: %+v", e.TransitPlanet, e.NatalPlanet, e.Duration)
+		}
+	}
+	t.Logf("行运事件数: %d", len(result.Events))
+}
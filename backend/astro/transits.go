@@ -1,6 +1,8 @@
 package astro
 
 import (
+	"math"
+	"sort"
 	"star/models"
 	"time"
 )
@@ -44,31 +46,42 @@ func CalculateTransits(chart *models.NatalChart, startDateStr, endDateStr string
 	var events []models.TransitEvent
 	var dominantThemes []string
 
-	// 遍历日期范围，检测行运事件
-	for date := startDate; date.Before(endDate); date = date.AddDate(0, 0, 7) { // 每周检查
-		transitPositions := GetTransitPositions(date)
-		aspects := CalculateTransitToNatalAspects(transitPositions, chart.Planets)
-
-		for _, aspect := range aspects {
-			if aspect.Strength > 0.8 { // 只记录强相位
-				event := models.TransitEvent{
-					Date:          date,
-					TransitPlanet: aspect.Planet1,
-					NatalPlanet:   aspect.Planet2,
-					Aspect:        aspect,
-					Phase:         "exact",
-					Intensity:     aspect.Strength * 100,
-					Duration: models.TransitDuration{
-						Start: date.AddDate(0, 0, -7).Format("2006-01-02"),
-						Peak:  date.Format("2006-01-02"),
-						End:   date.AddDate(0, 0, 7).Format("2006-01-02"),
-					},
-					Interpretation: generateTransitInterpretation(aspect),
-				}
-				events = append(events, event)
+	// 求解范围内每次精确成相的时刻，逆行往返的每一次都单独记录
+	from, to := DateToJulianDay(startDate.UTC()), DateToJulianDay(endDate.UTC())
+	tracks := make(map[models.PlanetID]*transitTrack)
+	for _, window := range FindTransitWindows(chart, startDate, endDate) {
+		natal := GetPlanetFromChart(chart, window.NatalPlanet)
+		track, ok := tracks[window.TransitPlanet]
+		if !ok {
+			track = newTransitTrack(chart, window.TransitPlanet, from)
+			tracks[window.TransitPlanet] = track
+		}
+
+		for i, exact := range window.Exact {
+			jd := DateToJulianDay(exact)
+			if jd < from || jd >= to {
+				continue
 			}
+			aspect, ok := newTransitAspect(track.position(jd), *natal, window.Aspect, window.Orb)
+			if !ok {
+				continue
+			}
+			events = append(events, models.TransitEvent{
+				Date:           exact,
+				TransitPlanet:  aspect.Planet1,
+				NatalPlanet:    aspect.Planet2,
+				Aspect:         aspect,
+				Phase:          "exact",
+				Pass:           i + 1,
+				Intensity:      math.Min(100, aspect.Weight*10),
+				Duration:       transitDuration(window, exact),
+				Interpretation: generateTransitInterpretation(aspect),
+			})
 		}
 	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Date.Before(events[j].Date)
+	})
 
 	// 计算整体分数
 	var totalScore float64
@@ -99,6 +112,7 @@ func CalculateTransits(chart *models.NatalChart, startDateStr, endDateStr string
 		Events:         events,
 		OverallScore:   totalScore,
 		DominantThemes: dominantThemes,
+		Patterns:       DetectTransitPatterns(chart, GetTransitPositionsForChart(chart, startDate)),
 	}
 }
 
@@ -137,6 +151,22 @@ func generateTransitInterpretation(aspect models.AspectData) models.TransitInter
 		theme = "Balance & Awareness"
 		keywords = []string{"polarity", "balance", "awakening"}
 		advice = "Seek balance, integrate opposites"
+	case models.Semisextile:
+		theme = "Subtle Adjustment"
+		keywords = []string{"growth", "small steps", "awareness"}
+		advice = "Notice small openings and build on them"
+	case models.Semisquare, models.Sesquiquadrate:
+		theme = "Friction & Irritation"
+		keywords = []string{"friction", "restlessness", "adjustment"}
+		advice = "Address minor tensions before they build up"
+	case models.Quintile, models.Biquintile:
+		theme = "Creativity & Talent"
+		keywords = []string{"creativity", "talent", "inspiration"}
+		advice = "Express your gifts in a creative way"
+	case models.Quincunx:
+		theme = "Adjustment & Realignment"
+		keywords = []string{"adjustment", "awkwardness", "recalibration"}
+		advice = "Adapt your approach rather than forcing the outcome"
 	}
 
 	return models.TransitInterpretation{
@@ -232,24 +262,57 @@ func generateMajorTransitDescription(config MajorTransitConfig, _ int) string {
 
 // GetCurrentTransits 获取当前活跃的行运
 func GetCurrentTransits(chart *models.NatalChart, date time.Time) []models.TransitEvent {
-	transitPositions := GetTransitPositions(date)
-	aspects := CalculateTransitToNatalAspects(transitPositions, chart.Planets)
+	transitPositions := GetTransitPositionsForChart(chart, date)
+	aspects := CalculateTransitToNatalAspects(transitPositions, NatalPoints(chart))
 
 	var events []models.TransitEvent
+	jd := DateToJulianDay(date.UTC())
 	for _, aspect := range aspects {
 		if aspect.Strength > 0.5 { // 中等强度以上
-			events = append(events, models.TransitEvent{
+			event := models.TransitEvent{
 				Date:           date,
 				TransitPlanet:  aspect.Planet1,
 				NatalPlanet:    aspect.Planet2,
 				Aspect:         aspect,
-				Phase:          "active",
+				Phase:          transitPhase(aspect),
 				Intensity:      aspect.Strength * 100,
 				Interpretation: generateTransitInterpretation(aspect),
-			})
+			}
+			if def := GetAspectDefinition(aspect.AspectType); def != nil {
+				natal := GetPlanetFromChart(chart, aspect.Planet2)
+				window := FindTransitWindowAt(chart, aspect.Planet1, *natal, *def, aspect.AllowedOrb, jd)
+				event.Duration = transitDuration(window, nearestExact(window.Exact, date))
+			}
+			events = append(events, event)
 		}
 	}
 
 	return events
 }
 
+// transitPhase 行运阶段：applying 入相 / separating 离相
+func transitPhase(aspect models.AspectData) string {
+	if aspect.Applying {
+		return "applying"
+	}
+	return "separating"
+}
+
+// nearestExact 距 date 最近的精确成相时刻（没有时为零值）
+func nearestExact(exact []time.Time, date time.Time) time.Time {
+	var nearest time.Time
+	for _, t := range exact {
+		if nearest.IsZero() || absDuration(t.Sub(date)) < absDuration(nearest.Sub(date)) {
+			nearest = t
+		}
+	}
+	return nearest
+}
+
+// absDuration 时间间隔的绝对值
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -1,59 +1,114 @@
 package astro
 
 import (
-	"fmt"
+	"log"
 	"star/models"
+	"sync"
 )
 
 // ==================== 统一天文计算入口 ====================
-// 所有天体数据的唯一来源是 Swiss Ephemeris
+// 所有天体数据都来自当前选择的星历提供者（见 ephemeris_provider.go）
 // 这些函数是整个系统的统一入口点
 
+// DefaultBodies 星盘默认包含的天体
+var DefaultBodies = []models.PlanetID{
+	models.Sun, models.Moon, models.Mercury, models.Venus, models.Mars,
+	models.Jupiter, models.Saturn, models.Uranus, models.Neptune, models.Pluto,
+	models.NorthNode, models.Chiron,
+}
+
 // GetPlanetPositionsUnified 统一获取所有行星位置
-// 强制使用 Swiss Ephemeris 作为唯一数据源
 func GetPlanetPositionsUnified(jd float64) []models.PlanetPosition {
-	if !IsSweAvailable() {
-		panic("Swiss Ephemeris is required but not available. Build with -tags swe")
+	provider := CurrentEphemerisProvider()
+	positions := make([]models.PlanetPosition, 0, len(DefaultBodies))
+	for _, planet := range DefaultBodies {
+		positions = append(positions, planetPositionFrom(provider, planet, jd))
 	}
-	return GetAllPlanetPositionsSwe(jd)
+	return positions
 }
 
 // CalculatePlanetPositionUnified 统一计算单个行星位置
-// 强制使用 Swiss Ephemeris 作为唯一数据源
 func CalculatePlanetPositionUnified(planet models.PlanetID, jd float64) models.PlanetPosition {
-	if !IsSweAvailable() {
-		panic("Swiss Ephemeris is required but not available. Build with -tags swe")
+	return planetPositionFrom(CurrentEphemerisProvider(), planet, jd)
+}
+
+// planetPositionFrom 从提供者获取行星位置（经由星历缓存），失败时记录错误并回退到内置算法；南交点由北交点推得
+func planetPositionFrom(provider EphemerisProvider, planet models.PlanetID, jd float64) models.PlanetPosition {
+	if planet == models.SouthNode {
+		return southNodeOf(planetPositionFrom(provider, models.NorthNode, jd), jd)
+	}
+
+	var pos models.PlanetPosition
+	var err error
+	if ephemerisCache.usesCache(provider) {
+		pos, err = ephemerisCache.position(provider, planet, jd)
+	} else {
+		pos, err = provider.PlanetPosition(planet, jd)
 	}
-	return CalculatePlanetPositionSwe(planet, jd)
+	if err != nil {
+		reportProviderFailure(provider, string(planet), jd, err)
+		return builtinPlanetPosition(planet, jd)
+	}
+	return pos
 }
 
-// CalculateHousesUnified 统一计算宫位
-// 强制使用 Swiss Ephemeris 作为唯一数据源
-func CalculateHousesUnified(jd float64, lat, lon float64) ([]models.HouseCusp, float64, float64) {
-	if !IsSweAvailable() {
-		panic("Swiss Ephemeris is required but not available. Build with -tags swe")
+// providerFailures 星历提供者计算失败、回退到内置算法的次数，键为 "提供者/天体"（宫位为 "提供者/houses"）
+var providerFailures = struct {
+	sync.Mutex
+	counts map[string]int
+}{counts: map[string]int{}}
+
+// reportProviderFailure 记录提供者的计算失败；每个提供者与计算对象只在首次失败时写日志，避免逐时评分刷屏
+func reportProviderFailure(provider EphemerisProvider, what string, jd float64, err error) {
+	key := provider.Name() + "/" + what
+	providerFailures.Lock()
+	providerFailures.counts[key]++
+	first := providerFailures.counts[key] == 1
+	providerFailures.Unlock()
+	if first {
+		log.Printf("⚠️ Ephemeris provider %s failed for %s at JD %.5f, falling back to builtin: %v", provider.Name(), what, jd, err)
 	}
-	return CalculateHousesSwe(jd, lat, lon)
 }
 
-// ValidateSwissEphemeris 验证 Swiss Ephemeris 是否可用
-// 在应用启动时调用此函数确保数据源正确
-func ValidateSwissEphemeris() error {
-	if !IsSweAvailable() {
-		return fmt.Errorf("Swiss Ephemeris is not available. The application requires Swiss Ephemeris as the sole data source. Please rebuild with: CGO_ENABLED=1 go build -tags swe")
+// GetEphemerisFailures 返回各提供者回退到内置算法的次数
+func GetEphemerisFailures() map[string]int {
+	providerFailures.Lock()
+	defer providerFailures.Unlock()
+	counts := make(map[string]int, len(providerFailures.counts))
+	for k, v := range providerFailures.counts {
+		counts[k] = v
 	}
-	
-	// 初始化 Swiss Ephemeris
-	InitSwissEphemeris("")
-	
-	return nil
+	return counts
 }
 
-// GetDataSource 返回当前数据源信息
-func GetDataSource() string {
-	if IsSweAvailable() {
-		return "Swiss Ephemeris (High Precision)"
+// CalculateHousesUnified 统一计算宫位
+func CalculateHousesUnified(jd float64, lat, lon float64, system models.HouseSystem) ([]models.HouseCusp, float64, float64) {
+	provider := CurrentEphemerisProvider()
+	houses, asc, mc, err := provider.Houses(jd, lat, lon, system)
+	if err != nil {
+		reportProviderFailure(provider, "houses", jd, err)
+		return CalculateHouses(jd, lat, lon, system)
 	}
-	return "Built-in Algorithm (NOT RECOMMENDED)"
+	return houses, asc, mc
+}
+
+// CalculateRiseSetUnified 统一计算天体升落时刻（世界时儒略日）
+func CalculateRiseSetUnified(body models.PlanetID, jd, lat, lon float64, event RiseSetEvent) (float64, error) {
+	return CurrentEphemerisProvider().RiseSet(body, jd, lat, lon, event)
+}
+
+// FindEclipseUnified 统一查找日月食
+func FindEclipseUnified(jd float64, kind EclipseKind, backward bool) (EclipseInfo, error) {
+	return CurrentEphemerisProvider().NextEclipse(jd, kind, backward)
 }
 
+// InitEphemeris 在应用启动时选择星历提供者
+// name 为空时使用默认提供者：Swiss Ephemeris 可用时优先，否则使用纯 Go 高精度星历
+func InitEphemeris(name string) (EphemerisProvider, error) {
+	return SelectEphemerisProvider(name)
+}
+
+// GetDataSource 返回当前数据源信息
+func GetDataSource() string {
+	return CurrentEphemerisProvider().Description()
+}
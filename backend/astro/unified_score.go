@@ -86,17 +86,22 @@ func CalculateUnifiedHourlyScore(chart *models.NatalChart, t time.Time) UnifiedS
 
 // CalculateUnifiedHourlyScoreWithUser 计算小时级别分数（支持自定义因子）
 func CalculateUnifiedHourlyScoreWithUser(chart *models.NatalChart, t time.Time, userID string) UnifiedScore {
+	return unifiedHourlyScore(CurrentScoringConfig(), chart, t, userID)
+}
+
+// unifiedHourlyScore 按配置快照计算小时级别分数
+func unifiedHourlyScore(cfg *ScoringConfig, chart *models.NatalChart, t time.Time, userID string) UnifiedScore {
 	// 1. 获取行运位置
-	transitPositions := GetTransitPositions(t)
+	transitPositions := GetTransitPositionsForChart(chart, t)
 
 	// 2. 计算行运相位
-	aspects := CalculateTransitToNatalAspects(transitPositions, chart.Planets)
+	aspects := CalculateTransitToNatalAspects(transitPositions, NatalPoints(chart))
 
 	// 3. 计算每个维度的相位分数
 	dimensionScores := calculateDimensionAspectScores(aspects)
 
 	// 4. 计算影响因子并分配到各维度
-	factors := CalculateInfluenceFactors(chart, t, transitPositions)
+	factors := cfg.InfluenceFactors(chart, t, transitPositions)
 	factorDetails := distributeFactorsToDimensions(factors)
 
 	// 5. 应用因子调整到各维度
@@ -119,7 +124,7 @@ func CalculateUnifiedHourlyScoreWithUser(chart *models.NatalChart, t time.Time,
 		
 		// 应用自定义因子
 		var overallForCustom float64 = 0 // 用于接收综合分的自定义因子
-		ApplyCustomFactors(userID, dimScoresV2, &overallForCustom, t)
+		cfg.ApplyCustomFactors(userID, dimScoresV2, &overallForCustom, t)
 		
 		// 将修改后的分数写回
 		dimensionScores["career"] = dimScoresV2.Career
@@ -146,14 +151,14 @@ func CalculateUnifiedHourlyScoreWithUser(chart *models.NatalChart, t time.Time,
 
 	// 9. 应用视觉抖动
 	jitterSeed := GenerateSeedFromTime(t.Year(), int(t.Month()), t.Day(), t.Hour())
-	overall = ApplyJitter(overall, jitterSeed)
+	overall = cfg.Jitter.Apply(overall, jitterSeed)
 	for dim := range normalizedDimensions {
-		normalizedDimensions[dim] = ApplyJitter(normalizedDimensions[dim], jitterSeed+int64(dim[0]))
+		normalizedDimensions[dim] = cfg.Jitter.Apply(normalizedDimensions[dim], jitterSeed+int64(dim[0]))
 	}
 
 	// 10. 添加活跃的自定义因子到返回结果
 	if userID != "" {
-		activeCustomFactors := GetActiveCustomFactors(userID, t)
+		activeCustomFactors := cfg.ActiveCustomFactors(userID, t)
 		for _, cf := range activeCustomFactors {
 			factorDetails = append(factorDetails, FactorDetail{
 				Name:  cf.Description,
@@ -176,6 +181,11 @@ func CalculateUnifiedHourlyScoreWithUser(chart *models.NatalChart, t time.Time,
 
 // CalculateDailyScore 计算日级别分数（24小时平均）
 func CalculateDailyScore(chart *models.NatalChart, date time.Time) UnifiedScore {
+	return dailyUnifiedScore(CurrentScoringConfig(), chart, date)
+}
+
+// dailyUnifiedScore 按配置快照计算日级别分数
+func dailyUnifiedScore(cfg *ScoringConfig, chart *models.NatalChart, date time.Time) UnifiedScore {
 	// 设置为当天 00:00
 	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
 
@@ -189,7 +199,7 @@ func CalculateDailyScore(chart *models.NatalChart, date time.Time) UnifiedScore
 
 	for hour := 0; hour < 24; hour++ {
 		t := startOfDay.Add(time.Duration(hour) * time.Hour)
-		hourlyScore := CalculateUnifiedHourlyScore(chart, t)
+		hourlyScore := unifiedHourlyScore(cfg, chart, t, "")
 
 		totalOverall += hourlyScore.Overall
 		for _, d := range dimensions {
@@ -213,6 +223,11 @@ func CalculateDailyScore(chart *models.NatalChart, date time.Time) UnifiedScore
 
 // CalculateWeeklyScore 计算周级别分数（7天平均）
 func CalculateWeeklyScore(chart *models.NatalChart, startDate time.Time) UnifiedScore {
+	return weeklyUnifiedScore(CurrentScoringConfig(), chart, startDate)
+}
+
+// weeklyUnifiedScore 按配置快照计算周级别分数
+func weeklyUnifiedScore(cfg *ScoringConfig, chart *models.NatalChart, startDate time.Time) UnifiedScore {
 	// 确保从周一开始
 	weekday := int(startDate.Weekday())
 	if weekday == 0 {
@@ -228,7 +243,7 @@ func CalculateWeeklyScore(chart *models.NatalChart, startDate time.Time) Unified
 
 	for day := 0; day < 7; day++ {
 		date := monday.AddDate(0, 0, day)
-		dailyScore := CalculateDailyScore(chart, date)
+		dailyScore := dailyUnifiedScore(cfg, chart, date)
 
 		totalOverall += dailyScore.Overall
 		for _, d := range dimensions {
@@ -252,6 +267,11 @@ func CalculateWeeklyScore(chart *models.NatalChart, startDate time.Time) Unified
 
 // CalculateMonthlyScore 计算月级别分数（当月所有天平均）
 func CalculateMonthlyScore(chart *models.NatalChart, year int, month time.Month) UnifiedScore {
+	return monthlyUnifiedScore(CurrentScoringConfig(), chart, year, month)
+}
+
+// monthlyUnifiedScore 按配置快照计算月级别分数
+func monthlyUnifiedScore(cfg *ScoringConfig, chart *models.NatalChart, year int, month time.Month) UnifiedScore {
 	// 获取当月天数
 	firstDay := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
 	lastDay := firstDay.AddDate(0, 1, -1)
@@ -264,7 +284,7 @@ func CalculateMonthlyScore(chart *models.NatalChart, year int, month time.Month)
 
 	for day := 1; day <= daysInMonth; day++ {
 		date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
-		dailyScore := CalculateDailyScore(chart, date)
+		dailyScore := dailyUnifiedScore(cfg, chart, date)
 
 		totalOverall += dailyScore.Overall
 		for _, d := range dimensions {
@@ -288,12 +308,17 @@ func CalculateMonthlyScore(chart *models.NatalChart, year int, month time.Month)
 
 // CalculateYearlyScore 计算年级别分数（12个月平均）
 func CalculateYearlyScore(chart *models.NatalChart, year int) UnifiedScore {
+	return yearlyUnifiedScore(CurrentScoringConfig(), chart, year)
+}
+
+// yearlyUnifiedScore 按配置快照计算年级别分数
+func yearlyUnifiedScore(cfg *ScoringConfig, chart *models.NatalChart, year int) UnifiedScore {
 	var totalOverall float64
 	totalDimensions := make(map[string]float64)
 	dimensions := []string{"career", "relationship", "health", "finance", "spiritual"}
 
 	for month := time.January; month <= time.December; month++ {
-		monthlyScore := CalculateMonthlyScore(chart, year, month)
+		monthlyScore := monthlyUnifiedScore(cfg, chart, year, month)
 
 		totalOverall += monthlyScore.Overall
 		for _, d := range dimensions {
@@ -336,7 +361,7 @@ func calculateDimensionAspectScores(aspects []models.AspectData) map[string]floa
 		natalPlanet := aspect.Planet2
 
 		// 计算相位分值
-		aspectValue := getUnifiedAspectValue(string(aspect.AspectType), aspect.Orb)
+		aspectValue := getUnifiedAspectValue(aspect)
 
 		// 根据行星-维度权重分配分值
 		for _, d := range dimensions {
@@ -365,31 +390,13 @@ func calculateDimensionAspectScores(aspects []models.AspectData) map[string]floa
 	return scores
 }
 
-// getUnifiedAspectValue 获取相位的分值
-func getUnifiedAspectValue(aspectType string, orb float64) float64 {
-	// 基础分值
-	baseValue := 0.0
-	switch aspectType {
-	case "conjunction":
-		baseValue = 3.0 // 合相：中性偏强
-	case "sextile":
-		baseValue = 2.0 // 六分：和谐
-	case "square":
-		baseValue = -2.5 // 四分：紧张
-	case "trine":
-		baseValue = 3.0 // 三分：和谐
-	case "opposition":
-		baseValue = -2.0 // 对分：紧张
+// getUnifiedAspectValue 获取相位的分值（基础分值取自相位目录，按容许度强度衰减）
+func getUnifiedAspectValue(aspect models.AspectData) float64 {
+	def := GetAspectDefinition(aspect.AspectType)
+	if def == nil {
+		return 0
 	}
-
-	// 根据容许度衰减
-	maxOrb := 10.0
-	orbFactor := 1.0 - (orb / maxOrb)
-	if orbFactor < 0 {
-		orbFactor = 0
-	}
-
-	return baseValue * orbFactor
+	return def.Score * aspect.Strength
 }
 
 // distributeFactorsToDimensions 将因子分配到各维度
@@ -226,7 +226,7 @@ func TestHouseCuspCalculation(t *testing.T) {
 	lon := 116.4074
 
 	jd := DateToJulianDay(testDate)
-	houses, asc, mc := CalculateHouses(jd, lat, lon)
+	houses, asc, mc := CalculateHouses(jd, lat, lon, models.HousePlacidus)
 
 	t.Logf("测试日期: %s", testDate.Format("2006-01-02 15:04 MST"))
 	t.Logf("位置: 纬度=%.4f, 经度=%.4f", lat, lon)
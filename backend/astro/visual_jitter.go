@@ -17,7 +17,7 @@ type JitterConfig struct {
 	Seed      int64   `json:"seed"`      // 随机种子（0表示使用时间戳）
 }
 
-// DefaultJitterConfig 默认抖动配置
+// DefaultJitterConfig 默认抖动配置（运行时调整见 ScoringConfig）
 var DefaultJitterConfig = JitterConfig{
 	Enabled:   true,
 	Magnitude: 0.5,
@@ -27,7 +27,12 @@ var DefaultJitterConfig = JitterConfig{
 // ApplyJitter 对单个分数应用抖动（仅显示用）
 // seed 用于确定性随机，相同seed产生相同抖动
 func ApplyJitter(score float64, seed int64) float64 {
-	if !DefaultJitterConfig.Enabled {
+	return CurrentScoringConfig().Jitter.Apply(score, seed)
+}
+
+// Apply 按此抖动配置对单个分数应用抖动
+func (config JitterConfig) Apply(score float64, seed int64) float64 {
+	if !config.Enabled {
 		return score
 	}
 
@@ -35,7 +40,7 @@ func ApplyJitter(score float64, seed int64) float64 {
 	r := rand.New(rand.NewSource(seed))
 
 	// 抖动范围：±magnitude
-	jitter := (r.Float64() - 0.5) * 2 * DefaultJitterConfig.Magnitude
+	jitter := (r.Float64() - 0.5) * 2 * config.Magnitude
 
 	// 应用抖动
 	jittered := score + jitter
@@ -49,13 +54,14 @@ func ApplyJitter(score float64, seed int64) float64 {
 
 // ApplyJitterToResult 对整个分数结果应用抖动
 func ApplyJitterToResult(result *ScoreResult, baseSeed int64) *ScoreResult {
-	if result == nil || !DefaultJitterConfig.Enabled {
+	config := CurrentScoringConfig().Jitter
+	if result == nil || !config.Enabled {
 		return result
 	}
 
 	// 创建新的结果，避免修改原始数据
 	jittered := &ScoreResult{
-		Overall:    ApplyJitter(result.Overall, baseSeed),
+		Overall:    config.Apply(result.Overall, baseSeed),
 		Timestamp:  result.Timestamp,
 		BaseScores: result.BaseScores,
 		Factors:    result.Factors,
@@ -63,11 +69,11 @@ func ApplyJitterToResult(result *ScoreResult, baseSeed int64) *ScoreResult {
 
 	// 对各维度应用不同的seed
 	jittered.Dimensions = models.DimensionScoresV2{
-		Career:       ApplyJitter(result.Dimensions.Career, baseSeed+1),
-		Relationship: ApplyJitter(result.Dimensions.Relationship, baseSeed+2),
-		Health:       ApplyJitter(result.Dimensions.Health, baseSeed+3),
-		Finance:      ApplyJitter(result.Dimensions.Finance, baseSeed+4),
-		Spiritual:    ApplyJitter(result.Dimensions.Spiritual, baseSeed+5),
+		Career:       config.Apply(result.Dimensions.Career, baseSeed+1),
+		Relationship: config.Apply(result.Dimensions.Relationship, baseSeed+2),
+		Health:       config.Apply(result.Dimensions.Health, baseSeed+3),
+		Finance:      config.Apply(result.Dimensions.Finance, baseSeed+4),
+		Spiritual:    config.Apply(result.Dimensions.Spiritual, baseSeed+5),
 	}
 
 	return jittered
@@ -75,13 +81,18 @@ func ApplyJitterToResult(result *ScoreResult, baseSeed int64) *ScoreResult {
 
 // ApplyJitterToAggregated 对聚合分数应用抖动
 func ApplyJitterToAggregated(score *AggregatedScore, baseSeed int64) *AggregatedScore {
-	if score == nil || !DefaultJitterConfig.Enabled {
+	return applyJitterToAggregated(CurrentScoringConfig().Jitter, score, baseSeed)
+}
+
+// applyJitterToAggregated 按同一份抖动配置递归处理聚合分数
+func applyJitterToAggregated(config JitterConfig, score *AggregatedScore, baseSeed int64) *AggregatedScore {
+	if score == nil || !config.Enabled {
 		return score
 	}
 
 	// 创建新的结果
 	jittered := &AggregatedScore{
-		Overall:     ApplyJitter(score.Overall, baseSeed),
+		Overall:     config.Apply(score.Overall, baseSeed),
 		Features:    score.Features,
 		StartTime:   score.StartTime,
 		EndTime:     score.EndTime,
@@ -90,18 +101,18 @@ func ApplyJitterToAggregated(score *AggregatedScore, baseSeed int64) *Aggregated
 
 	// 对各维度应用不同的seed
 	jittered.Dimensions = models.DimensionScoresV2{
-		Career:       ApplyJitter(score.Dimensions.Career, baseSeed+1),
-		Relationship: ApplyJitter(score.Dimensions.Relationship, baseSeed+2),
-		Health:       ApplyJitter(score.Dimensions.Health, baseSeed+3),
-		Finance:      ApplyJitter(score.Dimensions.Finance, baseSeed+4),
-		Spiritual:    ApplyJitter(score.Dimensions.Spiritual, baseSeed+5),
+		Career:       config.Apply(score.Dimensions.Career, baseSeed+1),
+		Relationship: config.Apply(score.Dimensions.Relationship, baseSeed+2),
+		Health:       config.Apply(score.Dimensions.Health, baseSeed+3),
+		Finance:      config.Apply(score.Dimensions.Finance, baseSeed+4),
+		Spiritual:    config.Apply(score.Dimensions.Spiritual, baseSeed+5),
 	}
 
 	// 递归处理子分数
 	if len(score.SubScores) > 0 {
 		jittered.SubScores = make([]AggregatedScore, len(score.SubScores))
 		for i, sub := range score.SubScores {
-			subJittered := ApplyJitterToAggregated(&sub, baseSeed+int64(i)*10)
+			subJittered := applyJitterToAggregated(config, &sub, baseSeed+int64(i)*10)
 			jittered.SubScores[i] = *subJittered
 		}
 	}
@@ -129,8 +140,15 @@ func SmoothTransition(score1, score2 float64, progress float64) float64 {
 	return math.Round(result*10000) / 10000
 }
 
-// UpdateJitterConfig 更新抖动配置
-func UpdateJitterConfig(config JitterConfig) {
-	DefaultJitterConfig = config
+// UpdateJitterConfig 更新抖动配置，发布新的配置快照
+func UpdateJitterConfig(config JitterConfig) *ScoringConfig {
+	return updateScoringConfig(func(cfg *ScoringConfig) {
+		cfg.Jitter = config
+	})
+}
+
+// GetCurrentJitterConfig 获取当前抖动配置
+func GetCurrentJitterConfig() JitterConfig {
+	return CurrentScoringConfig().Jitter
 }
 
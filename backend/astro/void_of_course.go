@@ -3,111 +3,235 @@ package astro
 import (
 	"math"
 	"star/models"
+	"time"
 )
 
 // ==================== 月亮空亡计算 ====================
+// 月亮空亡：月亮在当前星座内与行运行星形成最后一个托勒密相位（精确时刻）之后，
+// 直到进入下一星座为止的时段。目标行星按各自的实时位置计算，换座按所用黄道（恒星黄道的星座边界随岁差移动）
 
 // VoidOfCourseInfo 月亮空亡信息
 type VoidOfCourseInfo struct {
-	IsVoid       bool    `json:"isVoid"`
-	StartTime    string  `json:"startTime,omitempty"`
-	EndTime      string  `json:"endTime,omitempty"`
-	Duration     float64 `json:"duration"` // 小时
-	NextSign     string  `json:"nextSign,omitempty"`
-	LastAspect   string  `json:"lastAspect,omitempty"`
-	Influence    float64 `json:"influence"` // -15 到 0
+	IsVoid     bool    `json:"isVoid"`
+	StartTime  string  `json:"startTime,omitempty"` // 空亡开始（最后相位精确时刻，RFC3339 UTC）
+	EndTime    string  `json:"endTime,omitempty"`   // 空亡结束（月亮换座时刻）
+	Duration   float64 `json:"duration"`            // 小时
+	NextSign   string  `json:"nextSign,omitempty"`
+	LastAspect string  `json:"lastAspect,omitempty"`
+	Influence  float64 `json:"influence"` // -15 到 0
 }
 
-// CalculateVoidOfCourse 计算月亮空亡
-// 月亮空亡：月亮在当前星座内不再形成主要相位，直到进入下一个星座
-func CalculateVoidOfCourse(jd float64, natalPositions []models.PlanetPosition) VoidOfCourseInfo {
-	moonPos := CalculatePlanetPositionUnified(models.Moon, jd)
-	currentSign := moonPos.Sign
-
-	// 获取当前行运行星位置 - 使用 Swiss Ephemeris
-	transitPositions := GetPlanetPositionsUnified(jd)
-
-	// 月亮在当前星座内剩余的度数
-	degreesLeftInSign := 30 - moonPos.SignDegree
-
-	// 月亮每天移动约 13.2 度
-	moonDailyMotion := 13.2
-	hoursToNextSign := (degreesLeftInSign / moonDailyMotion) * 24
-
-	// 检查月亮在离开当前星座前是否会形成任何主要相位
-	hasUpcomingAspect := false
-	var lastAspectDesc string
-
-	// 搜索接下来的时间内是否有相位
-	searchSteps := 48 // 搜索 48 小时
-	for step := 1; step <= searchSteps; step++ {
-		futureJd := jd + float64(step)/24.0 // 每小时一步
-		futureMoonPos := CalculatePlanetPositionUnified(models.Moon, futureJd)
-
-		// 如果月亮已经换座，停止搜索
-		if futureMoonPos.Sign != currentSign {
-			break
-		}
+// VoidOfCourseAspect 月亮离座前的最后一个相位
+type VoidOfCourseAspect struct {
+	Planet models.PlanetID   `json:"planet"`
+	Aspect models.AspectType `json:"aspect"`
+	Time   time.Time         `json:"time"`
+}
 
-		// 检查是否有主要相位形成
-		for _, transit := range transitPositions {
-			if transit.ID == models.Moon {
-				continue
-			}
+// VoidOfCourseWindow 一段月亮空亡窗口
+type VoidOfCourseWindow struct {
+	Start      time.Time           `json:"start"`
+	End        time.Time           `json:"end"`
+	Duration   float64             `json:"duration"` // 小时
+	Sign       models.ZodiacID     `json:"sign"`
+	NextSign   models.ZodiacID     `json:"nextSign"`
+	LastAspect *VoidOfCourseAspect `json:"lastAspect,omitempty"` // 入座后未形成任何相位时为空
+}
 
-			// 计算角距
-			diff := math.Abs(futureMoonPos.Longitude - transit.Longitude)
-			if diff > 180 {
-				diff = 360 - diff
-			}
+// vocAspectBodies 判定空亡时参与相位的天体（不含交点与凯龙）
+var vocAspectBodies = []models.PlanetID{
+	models.Sun, models.Mercury, models.Venus, models.Mars, models.Jupiter,
+	models.Saturn, models.Uranus, models.Neptune, models.Pluto,
+}
 
-			// 检查主要相位（容许度 1°）
-			for _, angle := range []float64{0, 60, 90, 120, 180} {
-				if math.Abs(diff-angle) < 1.0 {
-					hasUpcomingAspect = true
-				aspectName := getVocAspectName(angle)
-				planetInfo := GetPlanetInfo(transit.ID)
-				lastAspectDesc = "Moon " + aspectName + " " + planetInfo.Name
-					break
+// vocAspectAngles 托勒密相位对应的月亮与目标黄经差（0-360°）
+var vocAspectAngles = []struct {
+	angle  float64
+	aspect models.AspectType
+}{
+	{0, models.Conjunction},
+	{60, models.Sextile},
+	{90, models.Square},
+	{120, models.Trine},
+	{180, models.Opposition},
+	{240, models.Trine},
+	{270, models.Square},
+	{300, models.Sextile},
+}
+
+// 月亮在一个星座内停留 1.9-2.8 天
+const (
+	moonSignLookback = 3.0
+	moonSignMinDays  = 1.0
+)
+
+// vocZodiac 空亡窗口所用的黄道
+type vocZodiac struct {
+	mode     models.ZodiacMode
+	ayanamsa models.Ayanamsa
+}
+
+// newVocZodiac 回归黄道不区分岁差体系
+func newVocZodiac(mode models.ZodiacMode, ayanamsa models.Ayanamsa) vocZodiac {
+	if mode != models.ZodiacSidereal {
+		return vocZodiac{mode: models.ZodiacTropical}
+	}
+	return vocZodiac{mode: mode, ayanamsa: ayanamsa}
+}
+
+// offset 黄道在 jd 时刻的偏移
+func (z vocZodiac) offset(jd float64) float64 {
+	return ZodiacOffset(z.mode, z.ayanamsa, jd)
+}
+
+// findVoidOfCourse 求 jd 时刻月亮所在星座内的空亡窗口
+func findVoidOfCourse(provider EphemerisProvider, jd float64, zodiac vocZodiac) (VoidOfCourseWindow, float64) {
+	entry := jd - moonSignLookback
+	if ingresses := findIngresses(provider, models.Moon, jd-moonSignLookback, jd, 0, zodiac.offset); len(ingresses) > 0 {
+		entry = DateToJulianDay(ingresses[len(ingresses)-1].Time)
+	}
+	exit := entry + moonSignLookback
+	var nextSign models.ZodiacID
+	if ingresses := findIngresses(provider, models.Moon, entry+moonSignMinDays, entry+moonSignLookback+moonSignMinDays, 0, zodiac.offset); len(ingresses) > 0 {
+		exit = DateToJulianDay(ingresses[0].Time)
+		nextSign = ingresses[0].ToSign
+	}
+
+	mid := (entry + exit) / 2
+	window := VoidOfCourseWindow{
+		Start:    julianDayToTime(entry),
+		End:      julianDayToTime(exit),
+		Sign:     GetZodiacByLongitude(planetPositionFrom(provider, models.Moon, mid).Longitude - zodiac.offset(mid)).ID,
+		NextSign: nextSign,
+	}
+
+	// 逐个天体扫描月亮与其黄经差，取入座后最后一次经过相位角的时刻
+	step := transitSearchSteps[models.Moon]
+	for _, body := range vocAspectBodies {
+		separation := func(x float64) float64 {
+			moon := planetPositionFrom(provider, models.Moon, x)
+			target := planetPositionFrom(provider, body, x)
+			return NormalizeAngle(moon.Longitude - target.Longitude)
+		}
+
+		prevX, prevSep := entry, separation(entry)
+		for prevX < exit {
+			x := math.Min(prevX+step, exit)
+			sep := separation(x)
+			for _, a := range vocAspectAngles {
+				if !crossesZero(normalizeSigned(prevSep-a.angle), normalizeSigned(sep-a.angle)) {
+					continue
+				}
+				angle := a.angle
+				root := bisect(func(t float64) float64 {
+					return normalizeSigned(separation(t) - angle)
+				}, prevX, x)
+				if t := julianDayToTime(root); t.After(window.Start) && t.Before(window.End) {
+					window.Start = t
+					window.LastAspect = &VoidOfCourseAspect{Planet: body, Aspect: a.aspect, Time: t}
 				}
 			}
-			if hasUpcomingAspect {
-				break
-			}
-		}
-		if hasUpcomingAspect {
-			break
+			prevX, prevSep = x, sep
 		}
 	}
 
-	isVoid := !hasUpcomingAspect && hoursToNextSign > 0.5 // 至少 30 分钟
+	window.Duration = window.End.Sub(window.Start).Hours()
+	return window, entry
+}
 
-	// 计算影响值
-	var influence float64
-	if isVoid {
-		// 空亡时间越长，影响越大（最大 -15）
-		influence = -math.Min(15, hoursToNextSign*0.5)
+// vocSpan 缓存的空亡窗口及其所在星座的入座时刻
+type vocSpan struct {
+	entry  float64
+	window VoidOfCourseWindow
+}
+
+// vocSpanKey 空亡窗口的缓存键
+type vocSpanKey struct {
+	provider string
+	zodiac   vocZodiac
+}
+
+// vocSpans 按星历源与黄道缓存的空亡窗口
+var vocSpans = newSpanCache[vocSpanKey, vocSpan](32)
+
+// VoidOfCourseWindowAt 求 jd 时刻月亮所在星座的空亡窗口（jd 可在窗口开始之前）
+func VoidOfCourseWindowAt(jd float64, zodiac models.ZodiacMode, ayanamsa models.Ayanamsa) VoidOfCourseWindow {
+	provider := CurrentEphemerisProvider()
+	t := julianDayToTime(jd)
+	key := vocSpanKey{provider.Name(), newVocZodiac(zodiac, ayanamsa)}
+
+	if span, ok := vocSpans.get(key, func(s vocSpan) bool {
+		return jd >= s.entry && t.Before(s.window.End)
+	}); ok {
+		return span.window
 	}
 
-	// 获取下一个星座
-	nextSignIdx := (int(moonPos.Longitude/30) + 1) % 12
-	nextSign := ZodiacSigns[nextSignIdx].Name
+	window, entry := findVoidOfCourse(provider, jd, key.zodiac)
 
-	return VoidOfCourseInfo{
-		IsVoid:      isVoid,
-		Duration:    hoursToNextSign,
-		NextSign:    nextSign,
-		LastAspect:  lastAspectDesc,
-		Influence:   influence,
+	vocSpans.put(key, vocSpan{entry: entry, window: window})
+	return window
+}
+
+// FindVoidOfCourseWindows 求与 [start, end) 有交集的全部空亡窗口，按时间排序
+func FindVoidOfCourseWindows(start, end time.Time, zodiac models.ZodiacMode, ayanamsa models.Ayanamsa) []VoidOfCourseWindow {
+	provider := CurrentEphemerisProvider()
+	from, to := DateToJulianDay(start.UTC()), DateToJulianDay(end.UTC())
+	z := newVocZodiac(zodiac, ayanamsa)
+
+	var windows []VoidOfCourseWindow
+	for jd := from; jd < to; {
+		window, _ := findVoidOfCourse(provider, jd, z)
+		if window.End.After(start) && window.Start.Before(end) {
+			windows = append(windows, window)
+		}
+		// 越过换座时刻进入下一星座
+		jd = DateToJulianDay(window.End) + 1.0/1440
 	}
+	return windows
 }
 
-// IsVoidOfCourseMoon 简化版月亮空亡检测
+// CalculateVoidOfCourse 计算月亮空亡
+// 不在空亡中时返回当前星座内即将到来的空亡窗口
+func CalculateVoidOfCourse(jd float64, zodiac models.ZodiacMode, ayanamsa models.Ayanamsa) VoidOfCourseInfo {
+	window := VoidOfCourseWindowAt(jd, zodiac, ayanamsa)
+	t := julianDayToTime(jd)
+	isVoid := !t.Before(window.Start) && t.Before(window.End)
+
+	info := VoidOfCourseInfo{
+		IsVoid:    isVoid,
+		StartTime: window.Start.Format(time.RFC3339),
+		EndTime:   window.End.Format(time.RFC3339),
+		Duration:  window.Duration,
+	}
+	if window.NextSign != "" {
+		info.NextSign = ZodiacSigns[signIndex(window.NextSign)].Name
+	}
+	if a := window.LastAspect; a != nil {
+		info.LastAspect = "Moon " + getVocAspectName(aspectAngle(a.Aspect)) + " " + GetPlanetInfo(a.Planet).Name
+	}
+	if isVoid {
+		// 空亡时间越长，影响越大（最大 -15）
+		info.Influence = -math.Min(15, window.Duration*0.5)
+	}
+	return info
+}
+
+// IsVoidOfCourseMoon 简化版月亮空亡检测（回归黄道）
 func IsVoidOfCourseMoon(jd float64) bool {
-	info := CalculateVoidOfCourse(jd, nil)
+	info := CalculateVoidOfCourse(jd, models.ZodiacTropical, "")
 	return info.IsVoid
 }
 
+// aspectAngle 托勒密相位的角度
+func aspectAngle(aspect models.AspectType) float64 {
+	for _, a := range vocAspectAngles {
+		if a.aspect == aspect {
+			return a.angle
+		}
+	}
+	return 0
+}
+
 // getVocAspectName 获取相位名
 func getVocAspectName(angle float64) string {
 	switch int(angle) {
@@ -125,4 +249,3 @@ func getVocAspectName(angle float64) string {
 		return ""
 	}
 }
-
@@ -1,6 +1,8 @@
 package astro
 
 import (
+	"math"
+	"star/models"
 	"testing"
 	"time"
 )
@@ -13,7 +15,7 @@ func TestVoidOfCourse(t *testing.T) {
 	t.Log("========== 月亮空亡测试 ==========")
 	t.Logf("测试日期: %s", testDate.Format("2006-01-02 15:04"))
 
-	info := CalculateVoidOfCourse(jd, nil)
+	info := CalculateVoidOfCourse(jd, models.ZodiacTropical, "")
 	
 	t.Logf("月亮空亡: %v", info.IsVoid)
 	t.Logf("持续时间: %.2f 小时", info.Duration)
@@ -45,7 +47,7 @@ func TestVoidOfCourseMultipleDates(t *testing.T) {
 	
 	for _, date := range testDates {
 		jd := DateToJulianDay(date)
-		info := CalculateVoidOfCourse(jd, nil)
+		info := CalculateVoidOfCourse(jd, models.ZodiacTropical, "")
 		
 		status := "正常"
 		if info.IsVoid {
@@ -71,3 +73,76 @@ func TestIsVoidOfCourseMoon(t *testing.T) {
 	// 验证函数不崩溃即可
 }
 
+
+// TestVoidOfCourseWindows 测试精确的月亮空亡窗口
+// 参考：2025-03-02 13:51 UT 月亮（白羊）刑火星后空亡，至 03-03 10:36 UT 进入金牛
+func TestVoidOfCourseWindows(t *testing.T) {
+	useProvider(t, NewPreciseProvider())
+
+	windows := FindVoidOfCourseWindows(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), models.ZodiacTropical, "")
+	if len(windows) < 12 || len(windows) > 15 {
+		t.Fatalf("一个月应有约 13 段空亡窗口，实际 %d", len(windows))
+	}
+
+	var aries *VoidOfCourseWindow
+	for i, w := range windows {
+		if !w.Start.Before(w.End) {
+			t.Errorf("空亡窗口起止颠倒: %+v", w)
+		}
+		if i > 0 && !windows[i-1].End.Before(w.Start) {
+			t.Errorf("空亡窗口重叠: %s 与 %s", windows[i-1].End, w.Start)
+		}
+		if w.Sign == "aries" && w.Start.Month() == time.March && w.Start.Day() == 2 {
+			aries = &windows[i]
+		}
+	}
+	if aries == nil {
+		t.Fatal("未找到 2025-03-02 白羊座空亡窗口")
+	}
+	t.Logf("白羊座空亡: %s - %s (%.2f 小时)", aries.Start, aries.End, aries.Duration)
+
+	start := time.Date(2025, 3, 2, 13, 51, 0, 0, time.UTC)
+	end := time.Date(2025, 3, 3, 10, 36, 0, 0, time.UTC)
+	if d := aries.Start.Sub(start); d < -2*time.Minute || d > 2*time.Minute {
+		t.Errorf("空亡应始于 %s，实际 %s", start, aries.Start)
+	}
+	if d := aries.End.Sub(end); d < -2*time.Minute || d > 2*time.Minute {
+		t.Errorf("空亡应止于 %s，实际 %s", end, aries.End)
+	}
+	if aries.LastAspect == nil || aries.LastAspect.Planet != "mars" || aries.LastAspect.Aspect != "square" {
+		t.Errorf("最后相位应为月亮刑火星: %+v", aries.LastAspect)
+	}
+	if aries.NextSign != "taurus" {
+		t.Errorf("下一星座应为金牛座，实际 %s", aries.NextSign)
+	}
+
+	// 窗口内外的空亡判定与信息字段
+	info := CalculateVoidOfCourse(DateToJulianDay(time.Date(2025, 3, 2, 20, 0, 0, 0, time.UTC)), models.ZodiacTropical, "")
+	if !info.IsVoid || info.LastAspect != "Moon Square Mars" || info.NextSign != "Taurus" {
+		t.Errorf("2025-03-02 20:00 应处于空亡: %+v", info)
+	}
+	if info.StartTime != aries.Start.Format(time.RFC3339) || info.EndTime != aries.End.Format(time.RFC3339) {
+		t.Errorf("空亡起止时间未填写: %+v", info)
+	}
+	if before := CalculateVoidOfCourse(DateToJulianDay(time.Date(2025, 3, 2, 12, 0, 0, 0, time.UTC)), models.ZodiacTropical, ""); before.IsVoid {
+		t.Errorf("最后相位之前不应空亡: %+v", before)
+	}
+
+	// 因子生命周期覆盖真实窗口
+	factors := calculateVoidOfCourseFactorsV2(&models.NatalChart{}, DateToJulianDay(time.Date(2025, 3, 2, 20, 0, 0, 0, time.UTC)), 1, time.Date(2025, 3, 2, 20, 0, 0, 0, time.UTC))
+	if len(factors) != 1 || !factors[0].Lifecycle.StartTime.Equal(aries.Start) || !factors[0].Lifecycle.EndTime.Equal(aries.End) {
+		t.Errorf("空亡因子生命周期应为 %s - %s: %+v", aries.Start, aries.End, factors)
+	}
+
+	// 恒星黄道的星座边界不同：窗口在月亮进入恒星黄道的下一星座时结束
+	jd := DateToJulianDay(time.Date(2025, 3, 2, 20, 0, 0, 0, time.UTC))
+	sidereal := VoidOfCourseWindowAt(jd, models.ZodiacSidereal, models.AyanamsaLahiri)
+	exit := DateToJulianDay(sidereal.End)
+	lon := NormalizeAngle(planetPositionFrom(CurrentEphemerisProvider(), models.Moon, exit).Longitude - ZodiacOffset(models.ZodiacSidereal, models.AyanamsaLahiri, exit))
+	if sidereal.End.Equal(aries.End) || math.Abs(normalizeSigned(lon-math.Round(lon/30)*30)) > 0.01 {
+		t.Errorf("恒星黄道的空亡应在月亮进入恒星黄道星座时结束: %+v（黄经 %.3f°）", sidereal, lon)
+	}
+	if sidereal.Sign != models.Pisces || sidereal.NextSign != models.Aries {
+		t.Errorf("恒星黄道下月亮应从双鱼座进入白羊座: %+v", sidereal)
+	}
+}
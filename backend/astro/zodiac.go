@@ -0,0 +1,169 @@
+package astro
+
+import (
+	"fmt"
+	"math"
+	"star/models"
+	"strings"
+)
+
+// ==================== 黄道模式与岁差体系 ====================
+
+// AyanamsaInfo 岁差体系信息
+// 与 Swiss Ephemeris 相同，由参考历元 T0 及该历元的岁差值定义，其余时刻按总岁差外推
+type AyanamsaInfo struct {
+	ID      models.Ayanamsa
+	Name    string
+	T0      float64 // 参考历元（儒略日，TT）
+	ValueT0 float64 // 参考历元的岁差值（度）
+}
+
+// Ayanamsas 支持的岁差体系
+var Ayanamsas = []AyanamsaInfo{
+	{models.AyanamsaLahiri, "Lahiri", 2435553.5, 23.245524743},
+	{models.AyanamsaFaganBradley, "Fagan-Bradley", 2433282.42346, 24.042044444},
+	{models.AyanamsaRaman, "Raman", 2415020.0, 21.01444},
+	{models.AyanamsaKrishnamurti, "Krishnamurti", 2415020.0, 22.363889},
+	{models.AyanamsaYukteshwar, "Sri Yukteshwar", 2415020.0, 22.478803},
+	{models.AyanamsaDjwhalKhul, "Djwhal Khul", 2415020.0, 28.359679},
+	{models.AyanamsaJNBhasin, "J.N. Bhasin", 2415020.0, 20.057541},
+}
+
+// DefaultAyanamsa 默认岁差体系
+const DefaultAyanamsa = models.AyanamsaLahiri
+
+// GetAyanamsaInfo 获取岁差体系信息
+func GetAyanamsaInfo(id models.Ayanamsa) *AyanamsaInfo {
+	for _, a := range Ayanamsas {
+		if a.ID == id {
+			return &a
+		}
+	}
+	return nil
+}
+
+// ParseZodiacMode 解析黄道模式，空值返回回归黄道
+func ParseZodiacMode(value models.ZodiacMode) (models.ZodiacMode, error) {
+	switch strings.ToLower(strings.TrimSpace(string(value))) {
+	case "", string(models.ZodiacTropical):
+		return models.ZodiacTropical, nil
+	case string(models.ZodiacSidereal):
+		return models.ZodiacSidereal, nil
+	}
+	return "", fmt.Errorf("不支持的黄道模式: %s", value)
+}
+
+// ParseAyanamsa 解析岁差体系，空值返回默认 Lahiri
+func ParseAyanamsa(value models.Ayanamsa) (models.Ayanamsa, error) {
+	s := strings.TrimSpace(string(value))
+	if s == "" {
+		return DefaultAyanamsa, nil
+	}
+	for _, a := range Ayanamsas {
+		if strings.EqualFold(s, string(a.ID)) {
+			return a.ID, nil
+		}
+	}
+	return "", fmt.Errorf("不支持的岁差体系: %s", s)
+}
+
+// ResolveZodiac 解析出生数据中的黄道设置，无法识别时回退到回归黄道/Lahiri
+// 回归黄道下岁差体系返回空值
+func ResolveZodiac(birthData models.BirthData) (models.ZodiacMode, models.Ayanamsa) {
+	mode, err := ParseZodiacMode(birthData.Zodiac)
+	if err != nil || mode == models.ZodiacTropical {
+		return models.ZodiacTropical, ""
+	}
+	ayanamsa, err := ParseAyanamsa(birthData.Ayanamsa)
+	if err != nil {
+		ayanamsa = DefaultAyanamsa
+	}
+	return models.ZodiacSidereal, ayanamsa
+}
+
+// generalPrecession 自 J2000 起的黄经总岁差（IAU 1976，度）
+func generalPrecession(jd float64) float64 {
+	T := (jd - J2000) / 36525.0
+	return (5029.0966*T + 1.11113*T*T - 0.000006*T*T*T) / 3600
+}
+
+// MeanAyanamsa 计算平岁差值（不含章动，度）
+func MeanAyanamsa(ayanamsa models.Ayanamsa, jd float64) float64 {
+	info := GetAyanamsaInfo(ayanamsa)
+	if info == nil {
+		info = GetAyanamsaInfo(DefaultAyanamsa)
+	}
+	return info.ValueT0 + generalPrecession(jd) - generalPrecession(info.T0)
+}
+
+// ZodiacOffset 返回需从回归黄经中减去的偏移（度），回归黄道为 0
+// 恒星黄道的偏移包含黄经章动，使其与含章动的视回归黄经相减后得到恒星黄经
+func ZodiacOffset(mode models.ZodiacMode, ayanamsa models.Ayanamsa, jd float64) float64 {
+	if mode != models.ZodiacSidereal {
+		return 0
+	}
+	dpsi, _ := Nutation(jd)
+	return MeanAyanamsa(ayanamsa, jd) + dpsi
+}
+
+// ChartZodiacOffset 返回本命盘所用黄道在指定时刻的偏移
+func ChartZodiacOffset(chart *models.NatalChart, jd float64) float64 {
+	if chart == nil {
+		return 0
+	}
+	return ZodiacOffset(chart.Zodiac, chart.Ayanamsa, jd)
+}
+
+// ==================== 黄道转换 ====================
+
+// setPositionLongitude 设置行星黄经并重新计算星座与尊贵度
+func setPositionLongitude(pos *models.PlanetPosition, longitude float64) {
+	longitude = NormalizeAngle(longitude)
+	zodiac := GetZodiacByLongitude(longitude)
+
+	pos.Longitude = longitude
+	pos.Sign = zodiac.ID
+	pos.SignName = zodiac.Name
+	pos.SignSymbol = zodiac.Symbol
+	pos.SignDegree = math.Mod(longitude, 30)
+	pos.DignityScore = EssentialDignityScore(pos.ID, longitude)
+}
+
+// ApplyZodiacToPositions 将回归黄道位置转换到目标黄道
+// offset 为 ZodiacOffset 的结果，为 0 时原样返回
+func ApplyZodiacToPositions(positions []models.PlanetPosition, offset float64) []models.PlanetPosition {
+	if offset == 0 {
+		return positions
+	}
+
+	result := make([]models.PlanetPosition, len(positions))
+	copy(result, positions)
+	for i := range result {
+		setPositionLongitude(&result[i], result[i].Longitude-offset)
+	}
+	return result
+}
+
+// ApplyZodiacToHouses 将回归黄道宫位转换到目标黄道
+// 整宫制需按转换后的上升星座重新划分，其余分宫制整体平移
+func ApplyZodiacToHouses(houses []models.HouseCusp, asc, mc float64, system models.HouseSystem, offset float64) ([]models.HouseCusp, float64, float64) {
+	if offset == 0 {
+		return houses, asc, mc
+	}
+
+	asc = NormalizeAngle(asc - offset)
+	mc = NormalizeAngle(mc - offset)
+
+	result := make([]models.HouseCusp, len(houses))
+	if system == models.HouseWholeSign {
+		for i, cusp := range equalCusps(math.Floor(asc/30) * 30) {
+			result[i] = createHouseCusp(i+1, cusp)
+		}
+		return result, asc, mc
+	}
+
+	for i, h := range houses {
+		result[i] = createHouseCusp(h.House, NormalizeAngle(h.Cusp-offset))
+	}
+	return result, asc, mc
+}
@@ -0,0 +1,116 @@
+package astro
+
+import (
+	"math"
+	"star/models"
+	"testing"
+)
+
+// TestMeanAyanamsa 测试岁差值（参考 Swiss Ephemeris 在 J2000 的取值）
+func TestMeanAyanamsa(t *testing.T) {
+	tests := []struct {
+		ayanamsa models.Ayanamsa
+		expected float64
+	}{
+		{models.AyanamsaLahiri, 23.857},
+		{models.AyanamsaFaganBradley, 24.740},
+		{models.AyanamsaRaman, 22.410},
+		{models.AyanamsaKrishnamurti, 23.760},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.ayanamsa), func(t *testing.T) {
+			got := MeanAyanamsa(tt.ayanamsa, J2000)
+			t.Logf("%s J2000 岁差: %.6f°", tt.ayanamsa, got)
+			if math.Abs(got-tt.expected) > 0.01 {
+				t.Errorf("期望约 %.3f°, 实际 %.6f°", tt.expected, got)
+			}
+		})
+	}
+
+	// 岁差每年约增加 50.3″
+	rate := (MeanAyanamsa(models.AyanamsaLahiri, J2000+365.25) - MeanAyanamsa(models.AyanamsaLahiri, J2000)) * 3600
+	if math.Abs(rate-50.29) > 0.05 {
+		t.Errorf("年岁差速率异常: %.3f″", rate)
+	}
+}
+
+// TestApplyZodiacToPositions 测试恒星黄道转换后星座与尊贵度同步更新
+func TestApplyZodiacToPositions(t *testing.T) {
+	tropical := []models.PlanetPosition{
+		{ID: models.Sun, Longitude: 130}, // 回归黄道狮子座 10°
+		{ID: models.Mars, Longitude: 5},  // 回归黄道白羊座 5°
+	}
+	for i := range tropical {
+		setPositionLongitude(&tropical[i], tropical[i].Longitude)
+	}
+
+	sidereal := ApplyZodiacToPositions(tropical, 24)
+
+	if sidereal[0].Sign != models.Cancer || math.Abs(sidereal[0].SignDegree-16) > 1e-9 {
+		t.Errorf("太阳应位于巨蟹座 16°，实际 %s %.2f°", sidereal[0].Sign, sidereal[0].SignDegree)
+	}
+	if sidereal[1].Sign != models.Pisces || math.Abs(sidereal[1].Longitude-341) > 1e-9 {
+		t.Errorf("火星应位于双鱼座 341°，实际 %s %.2f°", sidereal[1].Sign, sidereal[1].Longitude)
+	}
+	if tropical[1].DignityScore <= sidereal[1].DignityScore {
+		t.Errorf("火星离开白羊座后尊贵度应降低")
+	}
+	if tropical[0].Longitude != 130 {
+		t.Errorf("转换不应修改原始位置")
+	}
+
+	if got := ApplyZodiacToPositions(tropical, 0); &got[0] != &tropical[0] {
+		t.Errorf("回归黄道应原样返回")
+	}
+}
+
+// TestApplyZodiacToHouses 测试恒星黄道下的宫位转换
+func TestApplyZodiacToHouses(t *testing.T) {
+	asc, mc := 95.0, 5.0 // 回归黄道上升巨蟹 5°
+	offset := 24.0
+
+	whole := make([]models.HouseCusp, 12)
+	for i, cusp := range equalCusps(90) {
+		whole[i] = createHouseCusp(i+1, cusp)
+	}
+	houses, sidAsc, sidMc := ApplyZodiacToHouses(whole, asc, mc, models.HouseWholeSign, offset)
+	if math.Abs(sidAsc-71) > 1e-9 || math.Abs(sidMc-341) > 1e-9 {
+		t.Errorf("轴点转换错误: ASC %.2f MC %.2f", sidAsc, sidMc)
+	}
+	// 恒星黄道上升在双子座，整宫制第1宫应从双子座 0° 开始
+	if houses[0].Sign != models.Gemini || houses[0].Cusp != 60 {
+		t.Errorf("整宫制第1宫应为双子座 0°，实际 %s %.2f°", houses[0].Sign, houses[0].Cusp)
+	}
+
+	equal := make([]models.HouseCusp, 12)
+	for i, cusp := range equalCusps(asc) {
+		equal[i] = createHouseCusp(i+1, cusp)
+	}
+	houses, _, _ = ApplyZodiacToHouses(equal, asc, mc, models.HouseEqual, offset)
+	for i, h := range houses {
+		if math.Abs(AngleDifference(h.Cusp, NormalizeAngle(asc-offset+float64(i)*30))) > 1e-9 {
+			t.Errorf("等宫制第%d宫转换错误: %.2f", i+1, h.Cusp)
+		}
+	}
+}
+
+// TestResolveZodiac 测试黄道设置解析
+func TestResolveZodiac(t *testing.T) {
+	mode, ayanamsa := ResolveZodiac(models.BirthData{})
+	if mode != models.ZodiacTropical || ayanamsa != "" {
+		t.Errorf("默认应为回归黄道，实际 %s/%s", mode, ayanamsa)
+	}
+
+	mode, ayanamsa = ResolveZodiac(models.BirthData{Zodiac: "sidereal"})
+	if mode != models.ZodiacSidereal || ayanamsa != models.AyanamsaLahiri {
+		t.Errorf("恒星黄道默认应使用 Lahiri，实际 %s/%s", mode, ayanamsa)
+	}
+
+	if err := ValidateBirthData(models.BirthData{Zodiac: "draconic"}); err == nil {
+		t.Errorf("无效黄道模式应返回错误")
+	}
+	if err := ValidateBirthData(models.BirthData{Zodiac: "sidereal", Ayanamsa: "unknown"}); err == nil {
+		t.Errorf("无效岁差体系应返回错误")
+	}
+}
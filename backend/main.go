@@ -2,18 +2,27 @@ package main
 
 import (
 	"log"
+	"os"
+	"os/signal"
 	"star/api"
 	"star/astro"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 )
 
 func main() {
 	log.Println("🌟 Starting Star API Server...")
 
-	// 验证 Swiss Ephemeris 是否可用（唯一数据源）
-	if err := astro.ValidateSwissEphemeris(); err != nil {
+	// 选择星历提供者（STAR_EPHEMERIS=swiss|precise|builtin|table，默认 swiss，无 CGO 时为 precise）
+	provider, err := astro.InitEphemeris(os.Getenv("STAR_EPHEMERIS"))
+	if err != nil {
 		log.Fatalf("❌ %v", err)
 	}
-	log.Printf("✅ Data Source: %s", astro.GetDataSource())
+	log.Printf("✅ Data Source: %s [%s]", provider.Description(), provider.Name())
+
+	setupEphemerisCache()
 
 	// 确保在程序结束时关闭 Swiss Ephemeris
 	defer astro.CloseSwissEphemeris()
@@ -26,3 +35,71 @@ func main() {
 	}
 }
 
+// setupEphemerisCache 配置星历缓存
+//
+//	STAR_EPHEMERIS_CACHE=off          关闭缓存
+//	STAR_EPHEMERIS_CACHE_FILE=path    启动时加载缓存文件，退出时写回
+//	STAR_EPHEMERIS_PRECOMPUTE=1950-2050  启动时预计算指定年份范围
+func setupEphemerisCache() {
+	if strings.EqualFold(os.Getenv("STAR_EPHEMERIS_CACHE"), "off") {
+		astro.SetEphemerisCacheEnabled(false)
+		log.Println("⚠️ Ephemeris cache disabled")
+		return
+	}
+
+	path := os.Getenv("STAR_EPHEMERIS_CACHE_FILE")
+	if path != "" {
+		if err := astro.LoadEphemerisCache(path); err != nil {
+			log.Printf("⚠️ Failed to load ephemeris cache: %v", err)
+		}
+	}
+
+	if span := os.Getenv("STAR_EPHEMERIS_PRECOMPUTE"); span != "" {
+		from, to, ok := parseYearRange(span)
+		if !ok {
+			log.Printf("⚠️ Invalid STAR_EPHEMERIS_PRECOMPUTE: %q", span)
+		} else {
+			start := time.Now()
+			startJD := astro.DateToJulianDay(time.Date(from, 1, 1, 0, 0, 0, 0, time.UTC))
+			endJD := astro.DateToJulianDay(time.Date(to+1, 1, 1, 0, 0, 0, 0, time.UTC))
+			if err := astro.PrecomputeEphemeris(startJD, endJD); err != nil {
+				log.Printf("⚠️ Ephemeris precompute failed: %v", err)
+			} else {
+				log.Printf("✅ Ephemeris precomputed for %d-%d in %v", from, to, time.Since(start).Round(time.Millisecond))
+			}
+		}
+	}
+
+	if path == "" {
+		return
+	}
+	if err := astro.SaveEphemerisCache(path); err != nil {
+		log.Printf("⚠️ Failed to save ephemeris cache: %v", err)
+	}
+
+	// 退出时写回运行期间新增的缓存段
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+		<-sig
+		if err := astro.SaveEphemerisCache(path); err != nil {
+			log.Printf("⚠️ Failed to save ephemeris cache: %v", err)
+		}
+		astro.CloseSwissEphemeris()
+		os.Exit(0)
+	}()
+}
+
+// parseYearRange 解析 "1950-2050" 形式的年份范围
+func parseYearRange(span string) (int, int, bool) {
+	parts := strings.SplitN(span, "-", 2)
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	from, err1 := strconv.Atoi(strings.TrimSpace(parts[0]))
+	to, err2 := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err1 != nil || err2 != nil || from > to {
+		return 0, 0, false
+	}
+	return from, to, true
+}
@@ -20,6 +20,42 @@ const (
 	Pluto     PlanetID = "pluto"
 	NorthNode PlanetID = "northNode"
 	Chiron    PlanetID = "chiron"
+
+	// 扩展天体（按请求选择）
+	SouthNode PlanetID = "southNode" // 南交点（北交点的对冲点）
+	Ceres     PlanetID = "ceres"
+	Pallas    PlanetID = "pallas"
+	Juno      PlanetID = "juno"
+	Vesta     PlanetID = "vesta"
+	Lilith    PlanetID = "lilith"    // 黑月莉莉丝（月球平远地点）
+	OscLilith PlanetID = "oscLilith" // 黑月莉莉丝（月球密切远地点）
+	Eris      PlanetID = "eris"
+	MeanNode  PlanetID = "meanNode" // 北交点平交点，星历提供者内部使用，星盘中按 NodeType 替换北交点
+)
+
+// 阿拉伯点（希腊点），在星盘中与天体一样参与相位、行运与年限法
+const (
+	LotFortune   PlanetID = "fortune"   // 福点
+	LotSpirit    PlanetID = "spirit"    // 精神点
+	LotEros      PlanetID = "eros"      // 爱欲点
+	LotNecessity PlanetID = "necessity" // 必然点
+	LotCourage   PlanetID = "courage"   // 勇气点
+	LotVictory   PlanetID = "victory"   // 胜利点
+	LotNemesis   PlanetID = "nemesis"   // 复仇点
+	LotMarriage  PlanetID = "marriage"  // 婚姻点
+	LotFather    PlanetID = "father"    // 父亲点
+	LotMother    PlanetID = "mother"    // 母亲点
+	LotChildren  PlanetID = "children"  // 子女点
+	LotSiblings  PlanetID = "siblings"  // 兄弟点
+	LotSubstance PlanetID = "substance" // 财帛点
+)
+
+// Sect 星盘的日夜区分（太阳在地平线上为日间盘）
+type Sect string
+
+const (
+	SectDay   Sect = "day"
+	SectNight Sect = "night"
 )
 
 // ZodiacID 星座标识符
@@ -49,6 +85,18 @@ const (
 	Square      AspectType = "square"      // 四分相 90°
 	Trine       AspectType = "trine"       // 三分相 120°
 	Opposition  AspectType = "opposition"  // 对分相 180°
+
+	// 次要相位
+	Semisextile    AspectType = "semisextile"    // 半六分相 30°
+	Semisquare     AspectType = "semisquare"     // 半四分相 45°
+	Quintile       AspectType = "quintile"       // 五分相 72°
+	Sesquiquadrate AspectType = "sesquiquadrate" // 八分之三相 135°
+	Biquintile     AspectType = "biquintile"     // 倍五分相 144°
+	Quincunx       AspectType = "quincunx"       // 梅花相 150°
+
+	// 赤纬相位
+	Parallel       AspectType = "parallel"       // 平行：赤纬相同且同侧
+	Contraparallel AspectType = "contraparallel" // 反平行：赤纬相同且分居赤道两侧
 )
 
 // TimeGranularity 时间粒度
@@ -71,6 +119,60 @@ const (
 	DignityDetriment  Dignity = "detriment"  // 落陷
 	DignityFall       Dignity = "fall"       // 失势
 	DignityPeregrine  Dignity = "peregrine"  // 游离
+	DignityTriplicity Dignity = "triplicity" // 三分性主星
+	DignityTerm       Dignity = "term"       // 界主星
+	DignityFace       Dignity = "face"       // 面主星
+)
+
+// TermSystem 界（Terms/Bounds）体系
+type TermSystem string
+
+const (
+	TermsEgyptian  TermSystem = "egyptian"  // 埃及界
+	TermsPtolemaic TermSystem = "ptolemaic" // 托勒密界（Lilly 所用版本）
+)
+
+// HouseSystem 分宫制
+type HouseSystem string
+
+const (
+	HousePlacidus      HouseSystem = "placidus"      // Placidus (P)
+	HouseWholeSign     HouseSystem = "wholeSign"     // 整宫制 (W)
+	HouseEqual         HouseSystem = "equal"         // 等宫制 (E)
+	HouseKoch          HouseSystem = "koch"          // Koch (K)
+	HousePorphyry      HouseSystem = "porphyry"      // Porphyry (O)
+	HouseRegiomontanus HouseSystem = "regiomontanus" // Regiomontanus (R)
+	HouseCampanus      HouseSystem = "campanus"      // Campanus (C)
+	HouseAlcabitius    HouseSystem = "alcabitius"    // Alcabitius (B)
+)
+
+// ZodiacMode 黄道模式
+type ZodiacMode string
+
+const (
+	ZodiacTropical ZodiacMode = "tropical" // 回归黄道
+	ZodiacSidereal ZodiacMode = "sidereal" // 恒星黄道
+)
+
+// Ayanamsa 恒星黄道岁差体系
+type Ayanamsa string
+
+const (
+	AyanamsaLahiri       Ayanamsa = "lahiri"       // Lahiri (Chitrapaksha)
+	AyanamsaFaganBradley Ayanamsa = "faganBradley" // Fagan-Bradley
+	AyanamsaRaman        Ayanamsa = "raman"        // B.V. Raman
+	AyanamsaKrishnamurti Ayanamsa = "krishnamurti" // Krishnamurti (KP)
+	AyanamsaYukteshwar   Ayanamsa = "yukteshwar"   // Sri Yukteshwar
+	AyanamsaDjwhalKhul   Ayanamsa = "djwhalKhul"   // Djwhal Khul
+	AyanamsaJNBhasin     Ayanamsa = "jnBhasin"     // J.N. Bhasin
+)
+
+// NodeType 月亮交点类型
+type NodeType string
+
+const (
+	NodeTrue NodeType = "true" // 真交点（密切轨道交点）
+	NodeMean NodeType = "mean" // 平交点
 )
 
 // ==================== 核心数据结构 ====================
@@ -87,6 +189,13 @@ type BirthData struct {
 	Latitude  float64 `json:"latitude"`
 	Longitude float64 `json:"longitude"`
 	Timezone  float64 `json:"timezone"` // 时区偏移（小时），支持半时区如 5.5
+
+	HouseSystem HouseSystem `json:"houseSystem,omitempty"` // 分宫制，默认 Placidus
+	Zodiac      ZodiacMode  `json:"zodiac,omitempty"`      // 黄道模式，默认回归黄道
+	Ayanamsa    Ayanamsa    `json:"ayanamsa,omitempty"`    // 恒星黄道岁差体系，默认 Lahiri
+	NodeType    NodeType    `json:"nodeType,omitempty"`    // 月亮交点类型，默认真交点
+	Terms       TermSystem  `json:"terms,omitempty"`       // 界体系，默认埃及界
+	Bodies      []PlanetID  `json:"bodies,omitempty"`      // 星盘包含的天体，默认十大行星、北交点与凯龙星
 }
 
 // ToTime 将出生数据转换为 time.Time
@@ -109,6 +218,99 @@ type PlanetPosition struct {
 	Retrograde   bool     `json:"retrograde"`
 	House        int      `json:"house"`
 	DignityScore float64  `json:"dignityScore"`
+
+	// 运动与赤道坐标
+	Speed            float64 `json:"speed"`            // 黄经速度（度/天，逆行为负）
+	LatitudeSpeed    float64 `json:"latitudeSpeed"`    // 黄纬速度（度/天）
+	Declination      float64 `json:"declination"`      // 赤纬（度）
+	DeclinationSpeed float64 `json:"declinationSpeed"` // 赤纬速度（度/天）
+	Distance         float64 `json:"distance"`         // 地心距离（AU，未知时为 0）
+	OutOfBounds      bool    `json:"outOfBounds"`      // 出界：赤纬超过太阳的最大赤纬（黄赤交角）
+
+	// 后天尊贵（本命盘与行运因子计算时填充）
+	Accidental *AccidentalDignity `json:"accidental,omitempty"`
+}
+
+// LotPosition 阿拉伯点位置
+type LotPosition struct {
+	PlanetPosition
+	Formula  string `json:"formula"`  // 实际采用的公式，如 "ASC + Moon - Sun"
+	Reversed bool   `json:"reversed"` // 夜间盘按公式反转
+}
+
+// EssentialDignity 行星在所在度数的先天尊贵（Lilly 计分）
+type EssentialDignity struct {
+	Planet     PlanetID  `json:"planet"`
+	Sign       ZodiacID  `json:"sign"`
+	Dignities  []Dignity `json:"dignities"`            // 成立的尊贵与无力，无任何尊贵的古典行星为 peregrine
+	Triplicity string    `json:"triplicity,omitempty"` // 三分性主星身份：day / night / participating
+	TermRuler  PlanetID  `json:"termRuler,omitempty"`
+	FaceRuler  PlanetID  `json:"faceRuler,omitempty"`
+	Almuten    PlanetID  `json:"almuten,omitempty"` // 所在度数的胜利星
+	Score      float64   `json:"score"`             // 尊贵分加权合计
+}
+
+// AccidentalDignity 行星的后天尊贵：宫位、与太阳的距离、速度、东出西入与日夜区分
+type AccidentalDignity struct {
+	House       string  `json:"house,omitempty"`       // angular / succedent / cadent
+	SolarPhase  string  `json:"solarPhase,omitempty"`  // cazimi / combust / underBeams，远离太阳时为空
+	SunDistance float64 `json:"sunDistance,omitempty"` // 与太阳的黄经距离（度）
+	Motion      string  `json:"motion"`                // swift / slow：相对平均速度，逆行计为 slow
+	SpeedRatio  float64 `json:"speedRatio"`            // 速度与平均速度之比（逆行为负）
+	Orientation string  `json:"orientation,omitempty"` // oriental（先于太阳升起）/ occidental
+	Sect        string  `json:"sect,omitempty"`        // inSect / outOfSect，外行星不分日夜
+	Hayz        bool    `json:"hayz"`                  // 得时：合乎日夜、半球与星座阴阳
+	Score       float64 `json:"score"`                 // 后天尊贵分合计
+}
+
+// MutualReception 两颗古典行星各在对方守护或旺相的星座
+type MutualReception struct {
+	Planet1 PlanetID `json:"planet1"`
+	Planet2 PlanetID `json:"planet2"`
+	Type    string   `json:"type"` // domicile / exaltation / mixed（一方入对方守护、另一方入对方旺相）
+}
+
+// DispositorNode 行星及其逐级定位星
+type DispositorNode struct {
+	Planet     PlanetID   `json:"planet"`
+	Dispositor PlanetID   `json:"dispositor"`         // 所在星座的古典守护星
+	Chain      []PlanetID `json:"chain"`              // 逐级定位星，回到已出现的行星或星盘中没有的行星为止
+	Final      PlanetID   `json:"final,omitempty"`    // 定位链终止的入庙行星，终止于循环时为空
+	Disposes   []PlanetID `json:"disposes,omitempty"` // 直接定位的行星
+}
+
+// DispositorTree 星盘的定位星树
+type DispositorTree struct {
+	Nodes           []DispositorNode  `json:"nodes"`
+	FinalDispositor PlanetID          `json:"finalDispositor,omitempty"` // 所有定位链都终止于同一颗入庙行星时
+	SelfDisposited  []PlanetID        `json:"selfDisposited,omitempty"`  // 入庙而自我定位的行星
+	Loops           [][]PlanetID      `json:"loops,omitempty"`           // 定位星循环（两星循环即入庙互容）
+	Receptions      []MutualReception `json:"receptions,omitempty"`      // 互容
+}
+
+// ChartRulerInfo 命主星及其状态
+type ChartRulerInfo struct {
+	Planet          PlanetID   `json:"planet"`
+	Candidates      []PlanetID `json:"candidates"` // 上升星座的古典守护星（及现代守护星）
+	Sign            ZodiacID   `json:"sign"`
+	House           int        `json:"house"`
+	DignityScore    float64    `json:"dignityScore"`
+	AccidentalScore float64    `json:"accidentalScore"`
+	Reception       string     `json:"reception,omitempty"` // 与其定位星互容的类型
+	DispositorChain []PlanetID `json:"dispositorChain,omitempty"`
+	FinalDispositor PlanetID   `json:"finalDispositor,omitempty"`
+	Strength        float64    `json:"strength"` // 先天 + 后天尊贵 + 互容 + 定位星支持
+}
+
+// FixedStarContact 本命天体或四轴与恒星的合相/平行
+type FixedStarContact struct {
+	Star     string  `json:"star"`
+	StarName string  `json:"starName"`
+	Point    string  `json:"point"` // 天体 ID 或 ascendant/midheaven
+	Name     string  `json:"name"`
+	Kind     string  `json:"kind"` // conjunction / parallel
+	Orb      float64 `json:"orb"`
+	Nature   string  `json:"nature"` // benefic / malefic
 }
 
 // HouseCusp 宫位
@@ -127,12 +329,74 @@ type AspectData struct {
 	ExactAngle     float64    `json:"exactAngle"`
 	ActualAngle    float64    `json:"actualAngle"`
 	Orb            float64    `json:"orb"`
+	AllowedOrb     float64    `json:"allowedOrb"` // 该天体组合在当前情境下的容许度
 	Applying       bool       `json:"applying"`
+	TimeToExact    float64    `json:"timeToExact"` // 距精确成相的小时数（离相为负，表示已过去的时间）
 	Strength       float64    `json:"strength"`
 	Weight         float64    `json:"weight"`
 	Interpretation string     `json:"interpretation,omitempty"`
 }
 
+// PatternType 图形相位类型
+type PatternType string
+
+const (
+	PatternGrandTrine      PatternType = "grandTrine"      // 大三角
+	PatternTSquare         PatternType = "tSquare"         // T 三角
+	PatternGrandCross      PatternType = "grandCross"      // 大十字
+	PatternKite            PatternType = "kite"            // 风筝
+	PatternYod             PatternType = "yod"             // 上帝之指
+	PatternMysticRectangle PatternType = "mysticRectangle" // 神秘长方形
+	PatternGrandSextile    PatternType = "grandSextile"    // 大六角
+	PatternStellium        PatternType = "stellium"        // 群星（同星座或同宫位）
+	PatternThorsHammer     PatternType = "thorsHammer"     // 雷神之锤
+	PatternCradle          PatternType = "cradle"          // 摇篮
+)
+
+// PatternPoint 图形相位的参与者
+type PatternPoint struct {
+	Planet PlanetID `json:"planet"`
+	Chart  string   `json:"chart,omitempty"` // 多盘检测时所属的盘：natal / transit，合盘为 a / b
+}
+
+// AspectPattern 图形相位
+type AspectPattern struct {
+	Type      PatternType    `json:"type"`
+	Name      string         `json:"name"`
+	Points    []PatternPoint `json:"points"`
+	Apex      *PatternPoint  `json:"apex,omitempty"`     // 顶点：T 三角、上帝之指、雷神之锤的焦点，风筝的头部
+	Element   string         `json:"element,omitempty"`  // 参与者同属一个元素时
+	Modality  string         `json:"modality,omitempty"` // 参与者同属一个模式时
+	Sign      ZodiacID       `json:"sign,omitempty"`     // 星座群星
+	House     int            `json:"house,omitempty"`    // 宫位群星
+	Orb       float64        `json:"orb"`                // 组成相位的平均容许度偏差（群星为黄经跨度）
+	Tightness float64        `json:"tightness"`          // 紧密度 0-1
+	Strength  float64        `json:"strength"`           // 格局分值 × 紧密度，和谐格局为正、紧张格局为负
+}
+
+// ChartShapeType 星盘形状（Jones 分类）
+type ChartShapeType string
+
+const (
+	ShapeBundle     ChartShapeType = "bundle"     // 集中型：行星集中在 120° 内
+	ShapeBowl       ChartShapeType = "bowl"       // 碗型：行星集中在 180° 内
+	ShapeBucket     ChartShapeType = "bucket"     // 提桶型：碗型加上对面的单颗把手行星
+	ShapeLocomotive ChartShapeType = "locomotive" // 火车头型：行星分布在 240° 内
+	ShapeSeesaw     ChartShapeType = "seesaw"     // 跷跷板型：两组行星隔空相对
+	ShapeSplash     ChartShapeType = "splash"     // 分散型：行星均匀分布
+	ShapeSplay      ChartShapeType = "splay"      // 扩散型：行星成三组以上不规则分布
+)
+
+// ChartShape 星盘形状
+type ChartShape struct {
+	Type       ChartShapeType `json:"type"`
+	Name       string         `json:"name"`
+	Span       float64        `json:"span"`              // 行星占据的最小弧长
+	LargestGap float64        `json:"largestGap"`        // 最大空隙
+	Handle     PlanetID       `json:"handle,omitempty"`  // 提桶型的把手行星
+	Leading    PlanetID       `json:"leading,omitempty"` // 火车头型的领头行星（空隙后按黄道顺序的第一颗）
+}
+
 // ==================== 星盘结构 ====================
 
 // NatalChart 本命盘
@@ -142,12 +406,36 @@ type NatalChart struct {
 	Houses          []HouseCusp        `json:"houses"`
 	Ascendant       float64            `json:"ascendant"`
 	Midheaven       float64            `json:"midheaven"`
+	HouseSystem     HouseSystem        `json:"houseSystem"`
+	Zodiac          ZodiacMode         `json:"zodiac"`
+	Ayanamsa        Ayanamsa           `json:"ayanamsa,omitempty"`
+	AyanamsaValue   float64            `json:"ayanamsaValue,omitempty"`
 	Aspects         []AspectData       `json:"aspects"`
-	Patterns        []string           `json:"patterns"`
+	Parallels       []AspectData       `json:"parallels"`             // 赤纬平行/反平行
+	OutOfBounds     []PlanetID         `json:"outOfBounds,omitempty"` // 出界行星
+	Patterns        []AspectPattern    `json:"patterns"`
 	ElementBalance  map[string]float64 `json:"elementBalance"`
 	ModalityBalance map[string]float64 `json:"modalityBalance"`
+	Shape           *ChartShape        `json:"shape,omitempty"` // 星盘形状
+	Hemispheres     map[string]float64 `json:"hemispheres"`     // 东西南北半球的加权占比
+	Quadrants       map[string]float64 `json:"quadrants"`       // 四个象限的加权占比
 	DominantPlanets []PlanetID         `json:"dominantPlanets"`
 	ChartRuler      PlanetID           `json:"chartRuler"`
+	ChartRulerInfo  *ChartRulerInfo    `json:"chartRulerInfo,omitempty"` // 命主星的状态与定位链
+	Sect            Sect               `json:"sect"`
+	Lots            []LotPosition      `json:"lots,omitempty"`        // 阿拉伯点
+	FixedStars      []FixedStarContact `json:"fixedStars,omitempty"`  // 恒星合相/平行
+	Dignities       []EssentialDignity `json:"dignities,omitempty"`   // 先天尊贵
+	Dispositors     *DispositorTree    `json:"dispositors,omitempty"` // 定位星树与互容
+
+	// CurrentLocation 当前所在地（行星时等依赖观测地点的因子使用），为空时使用出生地
+	CurrentLocation *GeoLocation `json:"currentLocation,omitempty"`
+}
+
+// GeoLocation 地理位置
+type GeoLocation struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
 }
 
 // ProgressedChart 推运盘
@@ -296,6 +584,19 @@ type AnnualProfection struct {
 	LordNatalHouse int      `json:"lordNatalHouse"`
 	LordNatalSign  ZodiacID `json:"lordNatalSign"`
 	Description    string   `json:"description"`
+
+	ActivatedLots  []PlanetID      `json:"activatedLots,omitempty"`  // 落在年度星座的阿拉伯点
+	LotProfections []LotProfection `json:"lotProfections,omitempty"` // 以各阿拉伯点为起点的年限
+}
+
+// LotProfection 以阿拉伯点为起点的年限（如以福点推财务年主）
+type LotProfection struct {
+	Lot      PlanetID `json:"lot"`
+	LotName  string   `json:"lotName"`
+	Sign     ZodiacID `json:"sign"`
+	SignName string   `json:"signName"`
+	Lord     PlanetID `json:"lord"`
+	LordName string   `json:"lordName"`
 }
 
 // CycleAnalysis 周期分析
@@ -442,6 +743,12 @@ const (
 	FactorVoidOfCourse   InfluenceFactorType = "voidOfCourse"
 	FactorPersonal       InfluenceFactorType = "personal"
 	FactorCustom         InfluenceFactorType = "custom"
+	FactorParallel       InfluenceFactorType = "parallel"          // 行运与本命的赤纬平行/反平行
+	FactorOutOfBounds    InfluenceFactorType = "outOfBounds"       // 行运行星出界
+	FactorIngress        InfluenceFactorType = "ingress"           // 重要换座
+	FactorEclipse        InfluenceFactorType = "eclipse"           // 日月食触发本命点
+	FactorFixedStar      InfluenceFactorType = "fixedStar"         // 慢行星合相/平行恒星
+	FactorAccidental     InfluenceFactorType = "accidentalDignity" // 行运行星的后天尊贵
 )
 
 // FactorTimeLevel 因子时间级别
@@ -505,6 +812,9 @@ type InfluenceFactor struct {
 	// 正负性质
 	IsPositive bool `json:"isPositive"`
 
+	// 相位阶段（applying 入相 / separating 离相，仅相位类因子）
+	Phase string `json:"phase,omitempty"`
+
 	// 占星学依据
 	AstroReason string `json:"astroReason,omitempty"`
 }
@@ -522,6 +832,12 @@ type FactorWeights struct {
 	VoidOfCourse   float64 `json:"voidOfCourse"`
 	Personal       float64 `json:"personal"`
 	Custom         float64 `json:"custom"`
+	Parallel       float64 `json:"parallel"`
+	OutOfBounds    float64 `json:"outOfBounds"`
+	Ingress        float64 `json:"ingress"`
+	Eclipse        float64 `json:"eclipse"`
+	FixedStar      float64 `json:"fixedStar"`
+	Accidental     float64 `json:"accidentalDignity"`
 }
 
 // DimensionWeights 维度权重配置（可运营调整）
@@ -578,9 +894,10 @@ type NatalBaseScores struct {
 
 // TransitDuration 行运持续时间
 type TransitDuration struct {
-	Start string `json:"start"`
-	Peak  string `json:"peak"`
-	End   string `json:"end"`
+	Start string   `json:"start"`           // 进入容许度（RFC3339）
+	Peak  string   `json:"peak"`            // 本次精确成相
+	End   string   `json:"end"`             // 离开容许度
+	Exact []string `json:"exact,omitempty"` // 窗口内全部精确成相时刻（逆行时可有三次）
 }
 
 // TransitInterpretation 行运解读
@@ -597,6 +914,7 @@ type TransitEvent struct {
 	NatalPlanet    PlanetID              `json:"natalPlanet"`
 	Aspect         AspectData            `json:"aspect"`
 	Phase          string                `json:"phase"`
+	Pass           int                   `json:"pass,omitempty"` // 窗口内第几次精确成相
 	Intensity      float64               `json:"intensity"`
 	Duration       TransitDuration       `json:"duration"`
 	Interpretation TransitInterpretation `json:"interpretation"`
@@ -619,19 +937,21 @@ type TransitScore struct {
 
 // TransitResult 行运结果
 type TransitResult struct {
-	StartDate      string         `json:"startDate"`
-	EndDate        string         `json:"endDate"`
-	Events         []TransitEvent `json:"events"`
-	OverallScore   float64        `json:"overallScore"`
-	DominantThemes []string       `json:"dominantThemes"`
+	StartDate      string          `json:"startDate"`
+	EndDate        string          `json:"endDate"`
+	Events         []TransitEvent  `json:"events"`
+	OverallScore   float64         `json:"overallScore"`
+	DominantThemes []string        `json:"dominantThemes"`
+	Patterns       []AspectPattern `json:"patterns,omitempty"` // 起始日行运与本命共同构成的图形相位
 }
 
 // ==================== 用户结构 ====================
 
 // UserSettings 用户设置
 type UserSettings struct {
-	FactorWeights  *FactorWeights         `json:"factorWeights,omitempty"`
-	DisplayOptions map[string]interface{} `json:"displayOptions,omitempty"`
+	FactorWeights   *FactorWeights         `json:"factorWeights,omitempty"`
+	CurrentLocation *GeoLocation           `json:"currentLocation,omitempty"` // 当前所在地，用于行星时
+	DisplayOptions  map[string]interface{} `json:"displayOptions,omitempty"`
 }
 
 // User 用户
@@ -65,8 +65,8 @@ func GetAllUsers() []*models.User {
 	return users
 }
 
-// UpdateUser 更新用户
-func UpdateUser(id, name string, birthData models.BirthData) (*models.User, error) {
+// UpdateUser 更新用户；location 非空时同时更新当前所在地
+func UpdateUser(id, name string, birthData models.BirthData, location *models.GeoLocation) (*models.User, error) {
 	userMutex.Lock()
 	defer userMutex.Unlock()
 
@@ -78,12 +78,27 @@ func UpdateUser(id, name string, birthData models.BirthData) (*models.User, erro
 	// 更新信息
 	user.Name = name
 	user.BirthData = birthData
+	if location != nil {
+		user.Settings.CurrentLocation = location
+	}
 	user.NatalChart = astro.CalculateNatalChart(birthData)
+	user.NatalChart.CurrentLocation = user.Settings.CurrentLocation
 	user.UpdatedAt = time.Now()
 
 	return user, nil
 }
 
+// RecalculateNatalCharts 重新计算全部用户的本命盘（阿拉伯点目录变更后，已存储的本命盘中的点随之更新）
+func RecalculateNatalCharts() {
+	userMutex.Lock()
+	defer userMutex.Unlock()
+
+	for _, user := range userStore {
+		user.NatalChart = astro.CalculateNatalChart(user.BirthData)
+		user.NatalChart.CurrentLocation = user.Settings.CurrentLocation
+	}
+}
+
 // DeleteUser 删除用户
 func DeleteUser(id string) error {
 	userMutex.Lock()
@@ -111,6 +126,7 @@ func GetUserSnapshot(user *models.User) *models.UserSnapshot {
 	chart := user.NatalChart
 	if chart == nil {
 		chart = astro.CalculateNatalChart(user.BirthData)
+		chart.CurrentLocation = user.Settings.CurrentLocation
 	}
 
 	// 计算每日预测
@@ -162,6 +178,7 @@ func GetAgentContext() *models.AgentContext {
 		chart := user.NatalChart
 		if chart == nil {
 			chart = astro.CalculateNatalChart(user.BirthData)
+			chart.CurrentLocation = user.Settings.CurrentLocation
 		}
 
 		// 计算年龄
@@ -186,7 +203,7 @@ func GetAgentContext() *models.AgentContext {
 		}
 
 		// 当前月亮信息
-		transitPositions := astro.GetTransitPositions(now)
+		transitPositions := astro.GetTransitPositionsForChart(chart, now)
 		var currentMoonSign models.ZodiacID
 		for _, p := range transitPositions {
 			if p.ID == models.Moon {